@@ -0,0 +1,108 @@
+// Package events provides a small in-process event bus shared by the Deep Tree
+// Echo subsystems, so consumers can observe playmate, wisdom, and memory activity
+// without each subsystem implementing its own fan-out.
+package events
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Kind identifies a type of event
+type Kind string
+
+const (
+	// KindWonder is published when the playmate records a wonder
+	KindWonder Kind = "playmate.wonder"
+	// KindWisdomGrowth is published when a wisdom dimension grows
+	KindWisdomGrowth Kind = "wisdom.growth"
+	// KindMemoryInsert is published when a memory is inserted
+	KindMemoryInsert Kind = "memory.insert"
+)
+
+// DefaultBufferSize is the per-subscriber buffer used when NewBus is given a non-positive size
+const DefaultBufferSize = 64
+
+// Event is a notification published on a Bus
+type Event struct {
+	Kind      Kind                   `json:"kind"`
+	Source    string                 `json:"source"`
+	Timestamp time.Time              `json:"timestamp"`
+	Data      map[string]interface{} `json:"data,omitempty"`
+}
+
+// Bus fans events out to subscribers. Publishing never blocks: each subscriber
+// has a bounded buffer, and events that would overflow it are dropped for that
+// subscriber only, so a slow subscriber cannot stall publishers.
+type Bus struct {
+	mu         sync.RWMutex
+	bufferSize int
+	nextID     int
+	subs       map[Kind]map[int]chan Event
+	dropped    int64
+}
+
+// NewBus creates a bus with the given per-subscriber buffer size
+func NewBus(bufferSize int) *Bus {
+	if bufferSize <= 0 {
+		bufferSize = DefaultBufferSize
+	}
+	return &Bus{
+		bufferSize: bufferSize,
+		subs:       make(map[Kind]map[int]chan Event),
+	}
+}
+
+// Publish delivers an event to every subscriber of its kind without blocking.
+// A zero Timestamp is set to the current time. Publishing on a nil Bus is a no-op.
+func (b *Bus) Publish(event Event) {
+	if b == nil {
+		return
+	}
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for _, ch := range b.subs[event.Kind] {
+		select {
+		case ch <- event:
+		default:
+			atomic.AddInt64(&b.dropped, 1)
+		}
+	}
+}
+
+// Subscribe returns a channel receiving events of the given kind and a cancel
+// function that unsubscribes and closes the channel. Cancel is safe to call more than once.
+func (b *Bus) Subscribe(kind Kind) (<-chan Event, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	id := b.nextID
+	b.nextID++
+	ch := make(chan Event, b.bufferSize)
+	if b.subs[kind] == nil {
+		b.subs[kind] = make(map[int]chan Event)
+	}
+	b.subs[kind][id] = ch
+
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			delete(b.subs[kind], id)
+			close(ch)
+		})
+	}
+	return ch, cancel
+}
+
+// Dropped returns how many deliveries were dropped because a subscriber's buffer was full
+func (b *Bus) Dropped() int64 {
+	return atomic.LoadInt64(&b.dropped)
+}
@@ -0,0 +1,127 @@
+package events
+
+import (
+	"testing"
+	"time"
+)
+
+// receive waits briefly for an event, failing the test if none arrives
+func receive(t *testing.T, ch <-chan Event) Event {
+	t.Helper()
+	select {
+	case event := <-ch:
+		return event
+	case <-time.After(time.Second):
+		t.Fatal("no event received")
+		return Event{}
+	}
+}
+
+func TestPublishDeliversToEverySubscriber(t *testing.T) {
+	bus := NewBus(4)
+	first, cancelFirst := bus.Subscribe(KindWonder)
+	defer cancelFirst()
+	second, cancelSecond := bus.Subscribe(KindWonder)
+	defer cancelSecond()
+	other, cancelOther := bus.Subscribe(KindMemoryInsert)
+	defer cancelOther()
+
+	bus.Publish(Event{Kind: KindWonder, Source: "test", Data: map[string]interface{}{"n": 1}})
+
+	for _, ch := range []<-chan Event{first, second} {
+		event := receive(t, ch)
+		if event.Kind != KindWonder || event.Source != "test" || event.Data["n"] != 1 {
+			t.Errorf("received %+v, want the published wonder", event)
+		}
+		if event.Timestamp.IsZero() {
+			t.Error("published event has no timestamp")
+		}
+	}
+	select {
+	case event := <-other:
+		t.Errorf("subscriber of another kind received %+v", event)
+	default:
+	}
+}
+
+func TestPublishKeepsTimestamp(t *testing.T) {
+	bus := NewBus(1)
+	ch, cancel := bus.Subscribe(KindWisdomGrowth)
+	defer cancel()
+
+	at := time.Date(2026, 3, 14, 9, 0, 0, 0, time.UTC)
+	bus.Publish(Event{Kind: KindWisdomGrowth, Timestamp: at})
+	if got := receive(t, ch).Timestamp; !got.Equal(at) {
+		t.Errorf("timestamp = %v, want %v", got, at)
+	}
+}
+
+func TestSlowSubscriberDoesNotStallPublishers(t *testing.T) {
+	bus := NewBus(2)
+	slow, cancelSlow := bus.Subscribe(KindMemoryInsert) // Never read until the end
+	defer cancelSlow()
+	fast, cancelFast := bus.Subscribe(KindMemoryInsert)
+	defer cancelFast()
+
+	received := make(chan int)
+	go func() {
+		n := 0
+		for range fast {
+			n++
+		}
+		received <- n
+	}()
+
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 100; i++ {
+			bus.Publish(Event{Kind: KindMemoryInsert})
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("publishing blocked on a slow subscriber")
+	}
+
+	// The slow subscriber keeps only what fits in its buffer
+	if len(slow) != 2 {
+		t.Errorf("slow subscriber buffered %d events, want 2", len(slow))
+	}
+	cancelFast()
+	n := <-received
+	if dropped := bus.Dropped(); dropped != int64(98+100-n) {
+		t.Errorf("Dropped = %d, want %d for the slow subscriber and %d for the fast one", dropped, 98+100-n, 100-n)
+	}
+}
+
+func TestCancelUnsubscribes(t *testing.T) {
+	bus := NewBus(1)
+	ch, cancel := bus.Subscribe(KindWonder)
+	cancel()
+	cancel() // Safe to repeat
+
+	if _, ok := <-ch; ok {
+		t.Error("channel still open after cancel")
+	}
+	// Publishing after cancel must not send on the closed channel
+	bus.Publish(Event{Kind: KindWonder})
+	if dropped := bus.Dropped(); dropped != 0 {
+		t.Errorf("Dropped = %d, want 0 with no subscribers", dropped)
+	}
+}
+
+func TestNilBusPublishIsNoop(t *testing.T) {
+	var bus *Bus
+	bus.Publish(Event{Kind: KindWonder})
+}
+
+func TestNewBusDefaultsBufferSize(t *testing.T) {
+	bus := NewBus(0)
+	ch, cancel := bus.Subscribe(KindWonder)
+	defer cancel()
+	if cap(ch) != DefaultBufferSize {
+		t.Errorf("buffer size = %d, want %d", cap(ch), DefaultBufferSize)
+	}
+}
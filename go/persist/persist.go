@@ -0,0 +1,85 @@
+// Package persist provides the on-disk encodings shared by the Deep Tree Echo
+// subsystems. State can be written as indented JSON (readable, diffable) or as
+// gob (compact and fast for large memory graphs and growth histories), and
+// either can be gzip-compressed.
+package persist
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Format identifies a persistence encoding
+type Format string
+
+const (
+	// FormatJSON writes indented JSON
+	FormatJSON Format = "json"
+	// FormatGob writes a compact binary gob stream
+	FormatGob Format = "gob"
+)
+
+// gobMagic prefixes gob files so Decode can tell them apart from JSON
+var gobMagic = []byte("DTEGOB1\n")
+
+// gzipMagic is the header every gzip stream starts with
+var gzipMagic = []byte{0x1f, 0x8b}
+
+func init() {
+	// Generic values decoded from JSON metadata travel through interface{} fields
+	gob.Register(map[string]interface{}{})
+	gob.Register([]interface{}{})
+}
+
+// Encode serializes v in the given format. An empty format means JSON.
+func Encode(v interface{}, format Format) ([]byte, error) {
+	switch format {
+	case FormatJSON, "":
+		return json.MarshalIndent(v, "", "  ")
+	case FormatGob:
+		var buf bytes.Buffer
+		buf.Write(gobMagic)
+		if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	default:
+		return nil, fmt.Errorf("unknown persistence format: %s", format)
+	}
+}
+
+// Compress gzip-compresses encoded data; Decode detects and undoes it
+func Compress(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Decode deserializes data into v, detecting compression and the format from its header
+func Decode(data []byte, v interface{}) error {
+	if bytes.HasPrefix(data, gzipMagic) {
+		zr, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return err
+		}
+		defer zr.Close()
+		if data, err = io.ReadAll(zr); err != nil {
+			return err
+		}
+	}
+
+	if bytes.HasPrefix(data, gobMagic) {
+		return gob.NewDecoder(bytes.NewReader(data[len(gobMagic):])).Decode(v)
+	}
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,111 @@
+package persist
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type record struct {
+	ID        string
+	Embedding []float32
+	Metadata  map[string]interface{}
+	Created   time.Time
+}
+
+func sampleRecords(n int) map[string]*record {
+	records := make(map[string]*record, n)
+	created := time.Date(2026, 3, 14, 9, 0, 0, 0, time.UTC)
+	for i := 0; i < n; i++ {
+		id := fmt.Sprintf("episodic_%d", i)
+		embedding := make([]float32, 64)
+		for j := range embedding {
+			embedding[j] = float32(i*j%17) / 17
+		}
+		records[id] = &record{
+			ID:        id,
+			Embedding: embedding,
+			Metadata:  map[string]interface{}{"topic": "tides", "tags": []interface{}{"sea", "moon"}},
+			Created:   created.Add(time.Duration(i) * time.Minute),
+		}
+	}
+	return records
+}
+
+func TestRoundTrip(t *testing.T) {
+	want := sampleRecords(3)
+	for _, format := range []Format{FormatJSON, FormatGob, ""} {
+		for _, compress := range []bool{false, true} {
+			data, err := Encode(want, format)
+			if err != nil {
+				t.Fatalf("Encode(%q): %v", format, err)
+			}
+			if compress {
+				if data, err = Compress(data); err != nil {
+					t.Fatalf("Compress: %v", err)
+				}
+			}
+
+			var got map[string]*record
+			if err := Decode(data, &got); err != nil {
+				t.Fatalf("Decode(%q, compress=%v): %v", format, compress, err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("format %q, compress=%v: round trip changed the records", format, compress)
+			}
+		}
+	}
+}
+
+func TestEncodeGobHasMagicHeader(t *testing.T) {
+	data, err := Encode(sampleRecords(1), FormatGob)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(data, gobMagic) {
+		t.Errorf("gob data starts %q, want the magic header", data[:len(gobMagic)])
+	}
+}
+
+func TestEncodeUnknownFormat(t *testing.T) {
+	if _, err := Encode(sampleRecords(1), Format("yaml")); err == nil {
+		t.Error("Encode with an unknown format succeeded")
+	}
+}
+
+func BenchmarkEncode(b *testing.B) {
+	records := sampleRecords(1000)
+	for _, format := range []Format{FormatJSON, FormatGob} {
+		b.Run(string(format), func(b *testing.B) {
+			var size int
+			for i := 0; i < b.N; i++ {
+				data, err := Encode(records, format)
+				if err != nil {
+					b.Fatal(err)
+				}
+				size = len(data)
+			}
+			b.ReportMetric(float64(size), "bytes/file")
+		})
+	}
+}
+
+func BenchmarkDecode(b *testing.B) {
+	records := sampleRecords(1000)
+	for _, format := range []Format{FormatJSON, FormatGob} {
+		data, err := Encode(records, format)
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.Run(string(format), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				var got map[string]*record
+				if err := Decode(data, &got); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
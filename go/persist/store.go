@@ -0,0 +1,31 @@
+package persist
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// Store reads and writes encoded state by key, letting state live somewhere
+// other than the local filesystem, such as object storage or a database.
+// Read must return an error matching os.ErrNotExist (via errors.Is) when the
+// key has never been written, so a first run starts fresh instead of failing.
+type Store interface {
+	Read(key string) ([]byte, error)
+	Write(key string, data []byte) error
+}
+
+// FileStore is the default Store; keys are file paths
+type FileStore struct{}
+
+// Read returns the contents of the file at key
+func (FileStore) Read(key string) ([]byte, error) {
+	return os.ReadFile(key)
+}
+
+// Write replaces the file at key, creating its directory if needed
+func (FileStore) Write(key string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(key), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(key, data, 0644)
+}
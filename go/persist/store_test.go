@@ -0,0 +1,31 @@
+package persist
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileStoreRoundTrip(t *testing.T) {
+	var store Store = FileStore{}
+	key := filepath.Join(t.TempDir(), "nested", "dir", "state")
+
+	if _, err := store.Read(key); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("Read of an unwritten key = %v, want os.ErrNotExist", err)
+	}
+	if err := store.Write(key, []byte("first")); err != nil {
+		t.Fatalf("Write into a missing directory: %v", err)
+	}
+	if err := store.Write(key, []byte("second")); err != nil {
+		t.Fatal(err)
+	}
+	data, err := store.Read(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, []byte("second")) {
+		t.Errorf("Read = %q, want the latest write", data)
+	}
+}
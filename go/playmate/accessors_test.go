@@ -0,0 +1,87 @@
+package playmate
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestAccessorsReturnCopies mutates everything the read accessors return
+// while the autonomous loop runs. Run with -race: any shared state between
+// the copies and the playmate shows up as a data race or a changed value.
+func TestAccessorsReturnCopies(t *testing.T) {
+	p, _ := newTestPlaymate(t, func(c *PlaymateConfig) {
+		c.ThoughtInterval = time.Millisecond
+		c.MaintenanceInterval = time.Millisecond
+		c.AutoPractice = true
+	})
+	interest := p.LearnInterest(InterestExploration, "tides", []string{"moon", "sea"})
+	skill := p.PracticeSkill("juggling", "keeping three balls up")
+	d := mustStartDiscussion(t, p, "tides", "ana")
+	if err := p.AddMessage(d.ID, "ana", "why does the tide follow the moon?"); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := p.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				for _, in := range p.ListInterests() {
+					in.Topic = "mutated"
+					in.Keywords[0] = "mutated"
+					in.Strength = -1
+				}
+				for _, s := range p.ListSkills() {
+					s.Name = "mutated"
+					s.Milestones = append(s.Milestones, "mutated")
+				}
+				for _, active := range p.GetActiveDiscussions() {
+					active.Participants[1] = "mutated"
+					active.Messages[0].Content = "mutated"
+				}
+				if got, err := p.GetDiscussion(d.ID); err == nil {
+					got.Insights = append(got.Insights, "mutated")
+					got.Active = false
+				}
+				// Results of the mutating calls are copies too
+				if in := p.LearnInterest(InterestExploration, "tides", nil); in != nil {
+					in.Keywords = nil
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	p.Stop()
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if got := p.Interests[interest.ID]; got.Topic != "tides" || got.Keywords[0] != "moon" || got.Strength < 0 {
+		t.Errorf("interest changed through a copy: %+v", got)
+	}
+	if got := p.Skills[skill.ID]; got.Name != "juggling" {
+		t.Errorf("skill changed through a copy: %+v", got)
+	}
+	for _, m := range p.Skills[skill.ID].Milestones {
+		if m == "mutated" {
+			t.Errorf("skill milestones changed through a copy: %v", p.Skills[skill.ID].Milestones)
+		}
+	}
+	got := p.Discussions[d.ID]
+	if !got.Active || got.Participants[1] != "ana" || got.Messages[0].Content != "why does the tide follow the moon?" {
+		t.Errorf("discussion changed through a copy: %+v", got)
+	}
+	for _, insight := range got.Insights {
+		if insight == "mutated" {
+			t.Errorf("discussion insights changed through a copy: %v", got.Insights)
+		}
+	}
+}
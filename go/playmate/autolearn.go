@@ -0,0 +1,72 @@
+package playmate
+
+import "sort"
+
+// CategoryClassifier infers an interest category from its keywords, most salient first
+type CategoryClassifier func(keywords []string) InterestCategory
+
+// categoryKeywords maps telltale words to the interest category they suggest
+var categoryKeywords = map[string]InterestCategory{
+	"art": InterestCreativity, "draw": InterestCreativity, "music": InterestCreativity,
+	"paint": InterestCreativity, "poem": InterestCreativity, "poetry": InterestCreativity,
+	"song": InterestCreativity, "story": InterestCreativity, "write": InterestCreativity,
+	"family": InterestSocial, "friend": InterestSocial, "friends": InterestSocial,
+	"people": InterestSocial, "team": InterestSocial,
+	"explore": InterestExploration, "journey": InterestExploration, "travel": InterestExploration,
+	"trip": InterestExploration, "discover": InterestExploration,
+	"game": InterestPlay, "games": InterestPlay, "play": InterestPlay,
+	"puzzle": InterestPlay, "riddle": InterestPlay,
+	"meaning": InterestWisdom, "purpose": InterestWisdom, "truth": InterestWisdom,
+	"wisdom": InterestWisdom, "mindful": InterestWisdom,
+	"learn": InterestSkills, "practice": InterestSkills, "skill": InterestSkills,
+	"train": InterestSkills, "technique": InterestSkills,
+}
+
+// defaultCategoryClassifier picks the category of the first keyword found in
+// categoryKeywords, falling back to knowledge
+func defaultCategoryClassifier(keywords []string) InterestCategory {
+	for _, k := range keywords {
+		if category, ok := categoryKeywords[k]; ok {
+			return category
+		}
+	}
+	return InterestKnowledge
+}
+
+// autoLearnedKeywords is how many of a discussion's keywords accompany an auto-learned interest
+const autoLearnedKeywords = 5
+
+// autoLearnInterests learns an interest for each keyword of a new message whose
+// count across the discussion has just reached the auto-learn threshold, so
+// each keyword is learned once per discussion (must hold lock)
+func (p *Playmate) autoLearnInterests(discussion *Discussion, msg DiscussionMessage) {
+	if p.autoLearnThreshold == 0 {
+		return
+	}
+
+	texts := make([]string, len(discussion.Messages))
+	for i, m := range discussion.Messages {
+		texts[i] = m.Content
+	}
+	counts := keywordCounts(texts)
+	added := keywordCounts([]string{msg.Content})
+
+	crossed := make([]string, 0)
+	for w, n := range added {
+		if total := counts[w]; total >= p.autoLearnThreshold && total-n < p.autoLearnThreshold {
+			crossed = append(crossed, w)
+		}
+	}
+	if len(crossed) == 0 {
+		return
+	}
+	sort.Strings(crossed)
+
+	related := extractKeywords(texts, autoLearnedKeywords)
+	for _, topic := range crossed {
+		keywords := mergeKeywords([]string{topic}, related)
+		interest := p.learnInterest(p.classifyCategory(keywords), topic, keywords)
+		p.logger.Info("learned interest from discussion", "interest", interest.ID, "discussion", discussion.ID)
+	}
+	p.dirty = true
+}
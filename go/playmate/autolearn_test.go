@@ -0,0 +1,125 @@
+package playmate
+
+import "testing"
+
+// interestsByID lists the playmate's interests keyed by ID
+func interestsByID(p *Playmate) map[string]*Interest {
+	interests := make(map[string]*Interest)
+	for _, interest := range p.ListInterests() {
+		interests[interest.ID] = interest
+	}
+	return interests
+}
+
+// hasKeyword reports whether keywords contains want
+func hasKeyword(keywords []string, want string) bool {
+	for _, k := range keywords {
+		if k == want {
+			return true
+		}
+	}
+	return false
+}
+
+func TestAutoLearnInterestFromMessages(t *testing.T) {
+	p, _ := newTestPlaymate(t, func(c *PlaymateConfig) { c.AutoLearnInterests = true })
+	d := mustStartDiscussion(t, p, "stargazing", "ana")
+
+	messages := []string{
+		"I bought new telescopes for the club",
+		"the telescopes show the rings of saturn",
+		"the moons of saturn are faint",
+	}
+	for _, msg := range messages {
+		if err := p.AddMessage(d.ID, "ana", msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, ok := interestsByID(p)["knowledge_telescopes"]; ok {
+		t.Fatal("telescopes learned after two mentions, below the threshold")
+	}
+
+	if _, err := p.SendMessage(d.ID, "ana", "telescopes need dark skies"); err != nil {
+		t.Fatal(err)
+	}
+	interests := interestsByID(p)
+	learned, ok := interests["knowledge_telescopes"]
+	if !ok {
+		t.Fatalf("no telescopes interest after three mentions: %v", interests)
+	}
+	if len(learned.Keywords) == 0 || learned.Keywords[0] != "telescopes" {
+		t.Errorf("learned keywords = %v, want the topic first", learned.Keywords)
+	}
+	if !hasKeyword(learned.Keywords, "saturn") {
+		t.Errorf("learned keywords = %v, want related discussion keywords such as saturn", learned.Keywords)
+	}
+	for id := range interests {
+		if id == "knowledge_the" {
+			t.Error("learned an interest from a stopword")
+		}
+	}
+
+	// Further mentions don't learn the same keyword again
+	if err := p.AddMessage(d.ID, "ana", "telescopes everywhere"); err != nil {
+		t.Fatal(err)
+	}
+	if again := interestsByID(p)["knowledge_telescopes"]; again.EngageCount != learned.EngageCount {
+		t.Errorf("engage count = %d after another mention, want %d", again.EngageCount, learned.EngageCount)
+	}
+}
+
+func TestAutoLearnCategory(t *testing.T) {
+	t.Run("default classifier", func(t *testing.T) {
+		p, _ := newTestPlaymate(t, func(c *PlaymateConfig) {
+			c.AutoLearnInterests = true
+			c.AutoLearnThreshold = 2
+		})
+		d := mustStartDiscussion(t, p, "games", "ana")
+		for _, msg := range []string{"that puzzle was hard", "another puzzle tomorrow"} {
+			if err := p.AddMessage(d.ID, "ana", msg); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if _, ok := interestsByID(p)["play_puzzle"]; !ok {
+			t.Errorf("interests = %v, want puzzle learned as play", interestsByID(p))
+		}
+	})
+
+	t.Run("custom classifier", func(t *testing.T) {
+		var seen []string
+		p, _ := newTestPlaymate(t, func(c *PlaymateConfig) {
+			c.AutoLearnInterests = true
+			c.AutoLearnThreshold = 2
+			c.CategoryClassifier = func(keywords []string) InterestCategory {
+				seen = keywords
+				return InterestSocial
+			}
+		})
+		d := mustStartDiscussion(t, p, "games", "ana")
+		for _, msg := range []string{"that puzzle was hard", "another puzzle tomorrow"} {
+			if err := p.AddMessage(d.ID, "ana", msg); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if _, ok := interestsByID(p)["social_puzzle"]; !ok {
+			t.Errorf("interests = %v, want puzzle learned as social", interestsByID(p))
+		}
+		if len(seen) == 0 || seen[0] != "puzzle" {
+			t.Errorf("classifier saw keywords %v, want the topic first", seen)
+		}
+	})
+}
+
+func TestAutoLearnDisabledByDefault(t *testing.T) {
+	p, _ := newTestPlaymate(t, nil)
+	before := len(p.ListInterests())
+	d := mustStartDiscussion(t, p, "stargazing", "ana")
+	for i := 0; i < 5; i++ {
+		if err := p.AddMessage(d.ID, "ana", "telescopes telescopes"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if after := len(p.ListInterests()); after != before {
+		t.Errorf("interests grew from %d to %d with auto-learning off", before, after)
+	}
+}
@@ -0,0 +1,90 @@
+package playmate
+
+import (
+	"context"
+	"testing"
+)
+
+func TestPlaymateClose(t *testing.T) {
+	store := &memoryStore{}
+	p, _ := newTestPlaymate(t, func(c *PlaymateConfig) {
+		c.PersistPath = "playmate"
+		c.Store = store
+	})
+	p.LearnInterest(InterestExploration, "tides", nil)
+
+	if err := p.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if store.writes != 1 {
+		t.Fatalf("Close wrote %d times, want 1 for a dirty playmate", store.writes)
+	}
+	select {
+	case <-p.stopChan:
+	default:
+		t.Error("Close did not stop autonomous operation")
+	}
+
+	// Further changes are not flushed by a second Close
+	p.LearnInterest(InterestExploration, "stars", nil)
+	if err := p.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if store.writes != 1 {
+		t.Errorf("second Close wrote again: %d writes", store.writes)
+	}
+
+	loaded, _ := newTestPlaymate(t, func(c *PlaymateConfig) {
+		c.PersistPath = "playmate"
+		c.Store = store
+	})
+	if len(loaded.ListInterests()) != 1 {
+		t.Errorf("loaded %d interests, want the 1 saved on Close", len(loaded.ListInterests()))
+	}
+}
+
+func TestPlaymateCloseClean(t *testing.T) {
+	store := &memoryStore{}
+	p, _ := newTestPlaymate(t, func(c *PlaymateConfig) {
+		c.PersistPath = "playmate"
+		c.Store = store
+	})
+	p.LearnInterest(InterestExploration, "tides", nil)
+	if err := p.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if store.writes != 1 {
+		t.Errorf("Close of a clean playmate wrote: %d writes, want only the explicit Save", store.writes)
+	}
+}
+
+func TestWisdomCultivatorClose(t *testing.T) {
+	store := &memoryStore{}
+	wc, _ := newTestCultivator(t, &WisdomConfig{PersistPath: "wisdom", Store: store})
+	if err := wc.Save(); err != nil {
+		t.Fatal(err)
+	}
+	wc.AddInsight(context.Background(), "Rest comes before clarity", "evening walk", 0.8)
+	writes := store.writes
+
+	if err := wc.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if store.writes != writes+1 {
+		t.Fatalf("Close wrote %d times, want 1 for a dirty cultivator", store.writes-writes)
+	}
+	if err := wc.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if store.writes != writes+1 {
+		t.Errorf("second Close wrote again: %d writes", store.writes-writes)
+	}
+
+	loaded, _ := newTestCultivator(t, &WisdomConfig{PersistPath: "wisdom", Store: store})
+	if len(loaded.Insights) != len(wc.Insights) {
+		t.Errorf("loaded %d insights, want the %d saved on Close", len(loaded.Insights), len(wc.Insights))
+	}
+}
@@ -0,0 +1,10 @@
+package playmate
+
+// DepthScorer returns how much depth a message adds to a discussion, given the
+// messages that preceded it
+type DepthScorer func(msg DiscussionMessage, prev []DiscussionMessage) int
+
+// defaultDepthScorer counts every message as one step deeper
+func defaultDepthScorer(msg DiscussionMessage, prev []DiscussionMessage) int {
+	return 1
+}
@@ -0,0 +1,77 @@
+package playmate
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDefaultDepthScorer(t *testing.T) {
+	p, _ := newTestPlaymate(t, nil)
+	d := mustStartDiscussion(t, p, "music", "alice")
+	for i := 0; i < 3; i++ {
+		if err := p.AddMessage(d.ID, "alice", "ok"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := p.SendMessage(d.ID, "alice", "fine"); err != nil {
+		t.Fatal(err)
+	}
+
+	got, _ := p.GetDiscussion(d.ID)
+	if got.Depth != 4 {
+		t.Errorf("depth after four messages = %d, want 4", got.Depth)
+	}
+}
+
+func TestCustomDepthScorer(t *testing.T) {
+	var prevLens []int
+	scorer := func(msg DiscussionMessage, prev []DiscussionMessage) int {
+		prevLens = append(prevLens, len(prev))
+		return 1 + strings.Count(msg.Content, "?")*2
+	}
+	p, _ := newTestPlaymate(t, func(c *PlaymateConfig) { c.DepthScorer = scorer })
+
+	shallow := mustStartDiscussion(t, p, "weather", "alice")
+	for i := 0; i < 3; i++ {
+		if err := p.AddMessage(shallow.ID, "alice", "ok"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	deep := mustStartDiscussion(t, p, "music", "bob")
+	if err := p.AddMessage(deep.ID, "bob", "Why does a minor chord sound sad?"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.SendMessage(deep.ID, "bob", "Is it culture? Or physics?"); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.AddMessage(deep.ID, "bob", "ok"); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []int{0, 1, 2, 0, 1, 2}; !reflect.DeepEqual(prevLens, want) {
+		t.Errorf("scorer saw previous message counts %v, want %v", prevLens, want)
+	}
+
+	for _, tt := range []struct {
+		id           string
+		depth        int
+		wantInsights int
+	}{
+		{shallow.ID, 3, 0},
+		{deep.ID, 3 + 5 + 1, 1},
+	} {
+		got, _ := p.GetDiscussion(tt.id)
+		if got.Depth != tt.depth {
+			t.Errorf("%s depth = %d, want %d", got.Topic, got.Depth, tt.depth)
+		}
+		// Insight extraction follows the scored depth, not the message count
+		if err := p.EndDiscussion(tt.id); err != nil {
+			t.Fatal(err)
+		}
+		got, _ = p.GetDiscussion(tt.id)
+		if len(got.Insights) != tt.wantInsights {
+			t.Errorf("%s insights = %v, want %d", got.Topic, got.Insights, tt.wantInsights)
+		}
+	}
+}
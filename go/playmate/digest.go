@@ -0,0 +1,96 @@
+package playmate
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// digestThemes is how many recurring words the default summarizer names
+const digestThemes = 3
+
+// DailyDigest rolls a stretch of the stream of thoughts into a single entry,
+// keeping the newest raw thoughts as an archive (see DigestArchiveSize)
+type DailyDigest struct {
+	ID        string    `json:"id"`
+	Timestamp time.Time `json:"timestamp"`
+	Count     int       `json:"count"` // Thoughts digested, including any not archived
+	Summary   string    `json:"summary"`
+	Thoughts  []string  `json:"thoughts,omitempty"` // Archived raw thoughts, oldest first
+}
+
+// ThoughtSummarizer condenses a stretch of thoughts into a digest summary
+type ThoughtSummarizer func(thoughts []string) string
+
+// defaultThoughtSummarizer names how many thoughts there were and the words
+// that recurred most among them
+func defaultThoughtSummarizer(thoughts []string) string {
+	themes := extractKeywords(thoughts, digestThemes)
+	if len(themes) == 0 {
+		return fmt.Sprintf("%d thoughts drifted by.", len(thoughts))
+	}
+	return fmt.Sprintf("%d thoughts, mostly about %s.", len(thoughts), strings.Join(themes, ", "))
+}
+
+// DigestThoughts rolls the whole stream of thoughts into a digest and clears
+// the stream. It returns false when there were no thoughts to digest.
+func (p *Playmate) DigestThoughts() (DailyDigest, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.StreamOfThoughts) == 0 {
+		return DailyDigest{}, false
+	}
+	return p.digestThoughts(len(p.StreamOfThoughts)).clone(), true
+}
+
+// GetDigests returns a copy of the thought digests, oldest first
+func (p *Playmate) GetDigests() []DailyDigest {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	digests := make([]DailyDigest, len(p.Digests))
+	for i, digest := range p.Digests {
+		digests[i] = digest.clone()
+	}
+	return digests
+}
+
+// digestThoughts rolls the oldest n thoughts into a digest, removes them from
+// the stream, and returns the digest. The digest archives at most
+// digestArchiveSize of the thoughts, and the oldest digests are dropped past
+// maxDigests (must hold lock).
+func (p *Playmate) digestThoughts(n int) DailyDigest {
+	thoughts := p.StreamOfThoughts[:n]
+	archived := 0
+	if p.digestArchiveSize > 0 {
+		archived = p.digestArchiveSize
+		if archived > n {
+			archived = n
+		}
+	}
+	digest := DailyDigest{
+		ID:        p.ids.NewID("digest"),
+		Timestamp: p.clock.Now(),
+		Count:     n,
+		Summary:   p.summarizeThoughts(thoughts),
+	}
+	if archived > 0 {
+		digest.Thoughts = append([]string(nil), thoughts[n-archived:]...)
+	}
+
+	p.Digests = append(p.Digests, digest)
+	if len(p.Digests) > p.maxDigests {
+		p.Digests = append([]DailyDigest(nil), p.Digests[len(p.Digests)-p.maxDigests:]...)
+	}
+	p.StreamOfThoughts = append([]string(nil), p.StreamOfThoughts[n:]...)
+	p.dirty = true
+	p.logger.Info("digested thoughts", "count", digest.Count)
+	return digest
+}
+
+func (d DailyDigest) clone() DailyDigest {
+	c := d
+	c.Thoughts = append([]string(nil), d.Thoughts...)
+	return c
+}
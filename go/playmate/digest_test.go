@@ -0,0 +1,181 @@
+package playmate
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDayOfThoughtsRollsIntoDigest(t *testing.T) {
+	var summarized []string
+	p, clock := newTestPlaymate(t, func(c *PlaymateConfig) {
+		c.NoveltyWindow = 0
+		c.ThoughtSummarizer = func(thoughts []string) string {
+			summarized = thoughts
+			return fmt.Sprintf("a day of %d thoughts", len(thoughts))
+		}
+	})
+
+	// Think through the waking hours, 09:00 to 21:00
+	for hour := 0; hour < 12; hour++ {
+		p.generateThought(context.Background())
+		clock.Advance(time.Hour)
+	}
+	p.mu.RLock()
+	day := append([]string(nil), p.StreamOfThoughts...)
+	p.mu.RUnlock()
+	if len(day) != 12 {
+		t.Fatalf("stream holds %d thoughts before rest, want 12", len(day))
+	}
+
+	// Resting at 22:00 closes the wake cycle
+	clock.Advance(time.Hour)
+	p.updateWakeRest()
+	if got := currentState(p); got != StateDreaming {
+		t.Fatalf("state at 22:00 = %s, want dreaming", got)
+	}
+
+	digests := p.GetDigests()
+	if len(digests) != 1 {
+		t.Fatalf("got %d digests at the end of the day, want 1", len(digests))
+	}
+	digest := digests[0]
+	if digest.Count != 12 || digest.Summary != "a day of 12 thoughts" {
+		t.Errorf("digest = %d thoughts %q, want 12 and the summarizer's summary", digest.Count, digest.Summary)
+	}
+	if !reflect.DeepEqual(digest.Thoughts, day) || !reflect.DeepEqual(summarized, day) {
+		t.Errorf("digest archived %v, want the day's thoughts in order", digest.Thoughts)
+	}
+	if !digest.Timestamp.Equal(clock.Now()) {
+		t.Errorf("digest timestamp = %v, want %v", digest.Timestamp, clock.Now())
+	}
+	p.mu.RLock()
+	remaining := len(p.StreamOfThoughts)
+	p.mu.RUnlock()
+	if remaining != 0 {
+		t.Errorf("stream holds %d thoughts after the digest, want none", remaining)
+	}
+
+	// Nothing new to digest the next night
+	clock.Advance(8 * time.Hour)
+	p.updateWakeRest()
+	clock.Advance(16 * time.Hour)
+	p.updateWakeRest()
+	if n := len(p.GetDigests()); n != 1 {
+		t.Errorf("got %d digests after a thoughtless day, want 1", n)
+	}
+}
+
+func TestDigestThoughts(t *testing.T) {
+	p, _ := newTestPlaymate(t, nil)
+	if _, ok := p.DigestThoughts(); ok {
+		t.Error("DigestThoughts with an empty stream reported a digest")
+	}
+
+	p.mu.Lock()
+	for _, thought := range []string{"the tide follows the moon", "moon rise over the tide", "a kettle boils"} {
+		p.appendThought(thought)
+	}
+	p.mu.Unlock()
+
+	digest, ok := p.DigestThoughts()
+	if !ok || digest.Count != 3 {
+		t.Fatalf("DigestThoughts = %+v, %v; want three thoughts", digest, ok)
+	}
+	if !strings.HasPrefix(digest.Summary, "3 thoughts, mostly about") || !strings.Contains(digest.Summary, "tide") {
+		t.Errorf("default summary = %q, want the count and recurring themes", digest.Summary)
+	}
+
+	// Digests are copies
+	digest.Thoughts[0] = "changed"
+	if got := p.GetDigests()[0].Thoughts[0]; got != "the tide follows the moon" {
+		t.Errorf("modifying a returned digest changed the archive to %q", got)
+	}
+}
+
+func TestDefaultThoughtSummarizer(t *testing.T) {
+	if got := defaultThoughtSummarizer([]string{"the", "a it"}); got != "2 thoughts drifted by." {
+		t.Errorf("summary without salient words = %q", got)
+	}
+}
+
+func TestDigestsSurviveSaveLoad(t *testing.T) {
+	store := &memoryStore{}
+	configure := func(c *PlaymateConfig) {
+		c.PersistPath = "playmate"
+		c.Store = store
+	}
+	p, _ := newTestPlaymate(t, configure)
+	p.mu.Lock()
+	p.appendThought("the tide follows the moon")
+	p.mu.Unlock()
+	want, _ := p.DigestThoughts()
+	if err := p.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, _ := newTestPlaymate(t, configure)
+	got := loaded.GetDigests()
+	if len(got) != 1 || got[0].ID != want.ID || !reflect.DeepEqual(got[0].Thoughts, want.Thoughts) {
+		t.Errorf("loaded digests = %+v, want %+v", got, want)
+	}
+}
+
+func TestDigestArchiveSize(t *testing.T) {
+	tests := []struct {
+		name string
+		size int
+		want []string
+	}{
+		{"default keeps all", 0, []string{"one", "two", "three"}},
+		{"keeps the newest", 2, []string{"two", "three"}},
+		{"summary only", -1, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, _ := newTestPlaymate(t, func(c *PlaymateConfig) { c.DigestArchiveSize = tt.size })
+			p.mu.Lock()
+			for _, thought := range []string{"one", "two", "three"} {
+				p.appendThought(thought)
+			}
+			p.mu.Unlock()
+
+			digest, ok := p.DigestThoughts()
+			if !ok || digest.Count != 3 || digest.Summary == "" {
+				t.Fatalf("DigestThoughts = %+v, %v; want a summary of three thoughts", digest, ok)
+			}
+			if !reflect.DeepEqual(digest.Thoughts, tt.want) {
+				t.Errorf("archived %q, want %q", digest.Thoughts, tt.want)
+			}
+		})
+	}
+}
+
+func TestMaxDigestsDropsOldest(t *testing.T) {
+	p, _ := newTestPlaymate(t, func(c *PlaymateConfig) { c.MaxDigests = 2 })
+	for _, thought := range []string{"first", "second", "third"} {
+		p.mu.Lock()
+		p.appendThought(thought)
+		p.mu.Unlock()
+		p.DigestThoughts()
+	}
+
+	digests := p.GetDigests()
+	if len(digests) != 2 {
+		t.Fatalf("kept %d digests, want 2", len(digests))
+	}
+	if digests[0].Thoughts[0] != "second" || digests[1].Thoughts[0] != "third" {
+		t.Errorf("kept digests of %q and %q, want the two newest", digests[0].Thoughts, digests[1].Thoughts)
+	}
+}
+
+func TestInvalidMaxDigests(t *testing.T) {
+	config := DefaultPlaymateConfig()
+	config.MaxDigests = -1
+	if _, err := NewPlaymate(config); err == nil {
+		t.Error("NewPlaymate accepted a negative MaxDigests")
+	}
+}
@@ -0,0 +1,65 @@
+package playmate
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// DiscussionFilter selects discussions; zero-valued fields match everything
+type DiscussionFilter struct {
+	Participant string    // Exact participant name
+	Topic       string    // Case-insensitive topic substring
+	Active      *bool     // Active status, if set
+	After       time.Time // Started at or after this time
+	Before      time.Time // Started before this time
+}
+
+// matches reports whether a discussion satisfies every set field of the filter
+func (f DiscussionFilter) matches(d *Discussion) bool {
+	if f.Participant != "" {
+		found := false
+		for _, participant := range d.Participants {
+			if participant == f.Participant {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if f.Topic != "" && !strings.Contains(strings.ToLower(d.Topic), strings.ToLower(f.Topic)) {
+		return false
+	}
+	if f.Active != nil && d.Active != *f.Active {
+		return false
+	}
+	if !f.After.IsZero() && d.StartedAt.Before(f.After) {
+		return false
+	}
+	if !f.Before.IsZero() && !d.StartedAt.Before(f.Before) {
+		return false
+	}
+	return true
+}
+
+// FindDiscussions returns copies of the discussions matching a filter, oldest first
+func (p *Playmate) FindDiscussions(filter DiscussionFilter) []*Discussion {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	found := make([]*Discussion, 0)
+	for _, d := range p.Discussions {
+		if filter.matches(d) {
+			found = append(found, d.clone())
+		}
+	}
+	sort.Slice(found, func(i, j int) bool {
+		if !found[i].StartedAt.Equal(found[j].StartedAt) {
+			return found[i].StartedAt.Before(found[j].StartedAt)
+		}
+		return found[i].ID < found[j].ID
+	})
+	return found
+}
@@ -0,0 +1,73 @@
+package playmate
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFindDiscussions(t *testing.T) {
+	p, clock := newTestPlaymate(t, nil)
+	start := clock.Now()
+
+	tides := mustStartDiscussion(t, p, "Ocean tides", "ana")
+	if err := p.EndDiscussion(tides.ID); err != nil {
+		t.Fatal(err)
+	}
+	clock.Advance(time.Hour)
+	stars := mustStartDiscussion(t, p, "Stars at night", "ben")
+	clock.Advance(time.Hour)
+	waves := mustStartDiscussion(t, p, "Tidal waves", "ana")
+
+	active, inactive := true, false
+	tests := []struct {
+		name   string
+		filter DiscussionFilter
+		want   []string
+	}{
+		{"everything", DiscussionFilter{}, []string{tides.ID, stars.ID, waves.ID}},
+		{"participant", DiscussionFilter{Participant: "ana"}, []string{tides.ID, waves.ID}},
+		{"unknown participant", DiscussionFilter{Participant: "cho"}, nil},
+		{"topic substring ignores case", DiscussionFilter{Topic: "TID"}, []string{tides.ID, waves.ID}},
+		{"active", DiscussionFilter{Active: &active}, []string{stars.ID, waves.ID}},
+		{"inactive", DiscussionFilter{Active: &inactive}, []string{tides.ID}},
+		{"after is inclusive", DiscussionFilter{After: start.Add(time.Hour)}, []string{stars.ID, waves.ID}},
+		{"before is exclusive", DiscussionFilter{Before: start.Add(time.Hour)}, []string{tides.ID}},
+		{"time range", DiscussionFilter{After: start.Add(time.Minute), Before: start.Add(2 * time.Hour)}, []string{stars.ID}},
+		{"participant and active", DiscussionFilter{Participant: "ana", Active: &active}, []string{waves.ID}},
+		{"topic and participant", DiscussionFilter{Topic: "tid", Participant: "ben"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			found := p.FindDiscussions(tt.filter)
+			var got []string
+			for _, d := range found {
+				got = append(got, d.ID)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("found %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("found %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestFindDiscussionsReturnsCopies(t *testing.T) {
+	p, _ := newTestPlaymate(t, nil)
+	d := mustStartDiscussion(t, p, "tides", "ana")
+
+	found := p.FindDiscussions(DiscussionFilter{})
+	found[0].Topic = "mutated"
+	found[0].Participants[0] = "mutated"
+
+	got, err := p.GetDiscussion(d.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Topic != "tides" || got.Participants[0] == "mutated" {
+		t.Errorf("discussion changed through a search result: %+v", got)
+	}
+}
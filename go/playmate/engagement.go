@@ -0,0 +1,87 @@
+package playmate
+
+import "time"
+
+// StateChange records when the playmate entered a state
+type StateChange struct {
+	State PlaymateState `json:"state"`
+	At    time.Time     `json:"at"`
+}
+
+// EngagementStats summarizes the playmate's activity over a window of time
+type EngagementStats struct {
+	Window             time.Duration                   `json:"window"`
+	DiscussionsStarted int                             `json:"discussions_started"`
+	DiscussionsEnded   int                             `json:"discussions_ended"`
+	MessagesExchanged  int                             `json:"messages_exchanged"`
+	InsightsProduced   int                             `json:"insights_produced"`
+	WondersRecorded    int                             `json:"wonders_recorded"`
+	TimeInState        map[PlaymateState]time.Duration `json:"time_in_state"`
+}
+
+// recordStateChange appends a state entry to the history, trimming old ones (must hold lock)
+func (p *Playmate) recordStateChange(state PlaymateState) {
+	p.StateHistory = append(p.StateHistory, StateChange{State: state, At: p.clock.Now()})
+	if len(p.StateHistory) > 1000 {
+		p.StateHistory = p.StateHistory[500:]
+	}
+}
+
+// EngagementReport summarizes activity within the last window: discussions
+// started and ended, messages exchanged, insights from ended discussions,
+// wonders recorded, and how long the playmate spent in each state
+func (p *Playmate) EngagementReport(window time.Duration) EngagementStats {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	now := p.clock.Now()
+	since := now.Add(-window)
+	inWindow := func(t time.Time) bool {
+		return !t.Before(since) && !t.After(now)
+	}
+
+	stats := EngagementStats{
+		Window:      window,
+		TimeInState: make(map[PlaymateState]time.Duration),
+	}
+
+	for _, d := range p.Discussions {
+		if inWindow(d.StartedAt) {
+			stats.DiscussionsStarted++
+		}
+		if d.EndedAt != nil && inWindow(*d.EndedAt) {
+			stats.DiscussionsEnded++
+			stats.InsightsProduced += len(d.Insights)
+		}
+		for _, msg := range d.Messages {
+			if inWindow(msg.Timestamp) {
+				stats.MessagesExchanged++
+			}
+		}
+	}
+
+	for _, w := range p.Wonders {
+		if inWindow(w.Timestamp) {
+			stats.WondersRecorded++
+		}
+	}
+
+	// Each state lasts until the next change, clipped to the window
+	for i, change := range p.StateHistory {
+		start, end := change.At, now
+		if i+1 < len(p.StateHistory) {
+			end = p.StateHistory[i+1].At
+		}
+		if start.Before(since) {
+			start = since
+		}
+		if end.After(now) {
+			end = now
+		}
+		if end.After(start) {
+			stats.TimeInState[change.State] += end.Sub(start)
+		}
+	}
+
+	return stats
+}
@@ -0,0 +1,106 @@
+package playmate
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestEngagementReport(t *testing.T) {
+	p, clock := newTestPlaymate(t, nil)
+
+	clock.Advance(10 * time.Minute) // 09:10, awake since 09:00
+	d := mustStartDiscussion(t, p, "tides", "Ada")
+
+	clock.Advance(5 * time.Minute) // 09:15
+	for _, content := range []string{"ok", "fine"} {
+		if err := p.AddMessage(d.ID, "Ada", content); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	clock.Advance(5 * time.Minute) // 09:20
+	p.mu.Lock()
+	p.Discussions[d.ID].Depth = 10 // Deep enough to yield an insight
+	p.mu.Unlock()
+	if err := p.EndDiscussion(d.ID); err != nil {
+		t.Fatal(err)
+	}
+	p.RecordWonder("the moon pulls the sea", "tides", 0.8)
+
+	clock.Advance(10 * time.Minute) // 09:30
+	p.mu.Lock()
+	if err := p.transition(StateAwake); err != nil {
+		t.Fatal(err)
+	}
+	p.mu.Unlock()
+
+	clock.Advance(30 * time.Minute) // 10:00
+
+	tests := []struct {
+		name   string
+		window time.Duration
+		want   EngagementStats
+	}{
+		{
+			name:   "whole session",
+			window: 2 * time.Hour,
+			want: EngagementStats{
+				DiscussionsStarted: 1,
+				DiscussionsEnded:   1,
+				MessagesExchanged:  2,
+				InsightsProduced:   1,
+				WondersRecorded:    1,
+				TimeInState: map[PlaymateState]time.Duration{
+					StateAwake:      40 * time.Minute,
+					StateEngaged:    10 * time.Minute,
+					StateReflecting: 10 * time.Minute,
+				},
+			},
+		},
+		{
+			name:   "from 09:15",
+			window: 45 * time.Minute,
+			want: EngagementStats{
+				DiscussionsEnded:  1,
+				MessagesExchanged: 2,
+				InsightsProduced:  1,
+				WondersRecorded:   1,
+				TimeInState: map[PlaymateState]time.Duration{
+					StateAwake:      30 * time.Minute,
+					StateEngaged:    5 * time.Minute,
+					StateReflecting: 10 * time.Minute,
+				},
+			},
+		},
+		{
+			name:   "last quarter hour",
+			window: 15 * time.Minute,
+			want: EngagementStats{
+				TimeInState: map[PlaymateState]time.Duration{
+					StateAwake: 15 * time.Minute,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.want.Window = tt.window
+			if got := p.EngagementReport(tt.window); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("EngagementReport(%v) =\n%+v\nwant\n%+v", tt.window, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStateHistoryTrimmed(t *testing.T) {
+	p, _ := newTestPlaymate(t, nil)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for i := 0; i < 1200; i++ {
+		p.recordStateChange(StateAwake)
+	}
+	if len(p.StateHistory) > 1000 {
+		t.Errorf("state history holds %d entries, want at most 1000", len(p.StateHistory))
+	}
+}
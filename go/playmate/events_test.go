@@ -0,0 +1,55 @@
+package playmate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/o9nn/un9n/go/events"
+)
+
+// nextEvent waits briefly for an event, failing the test if none arrives
+func nextEvent(t *testing.T, ch <-chan events.Event) events.Event {
+	t.Helper()
+	select {
+	case event := <-ch:
+		return event
+	case <-time.After(time.Second):
+		t.Fatal("no event published")
+		return events.Event{}
+	}
+}
+
+func TestRecordWonderPublishesEvent(t *testing.T) {
+	bus := events.NewBus(8)
+	ch, cancel := bus.Subscribe(events.KindWonder)
+	defer cancel()
+	p, clock := newTestPlaymate(t, func(c *PlaymateConfig) { c.Events = bus })
+
+	wonder := p.RecordWonder("the moon pulls the sea", "tides", 0.8)
+	event := nextEvent(t, ch)
+	if event.Source != "playmate" || event.Data["id"] != wonder.ID || event.Data["intensity"] != 0.8 {
+		t.Errorf("event = %+v, want the recorded wonder", event)
+	}
+	if !event.Timestamp.Equal(clock.Now()) {
+		t.Errorf("timestamp = %v, want the playmate clock's %v", event.Timestamp, clock.Now())
+	}
+}
+
+func TestGrowDimensionPublishesEvent(t *testing.T) {
+	bus := events.NewBus(8)
+	ch, cancel := bus.Subscribe(events.KindWisdomGrowth)
+	defer cancel()
+	wc, clock := newTestCultivator(t, &WisdomConfig{Events: bus})
+
+	wc.GrowDimension(DimensionCompassion, 0.05, "kind word")
+	event := nextEvent(t, ch)
+	if event.Source != "wisdom" || event.Data["dimension"] != string(DimensionCompassion) || event.Data["trigger"] != "kind word" {
+		t.Errorf("event = %+v, want compassion growth from the kind word", event)
+	}
+	if delta, _ := event.Data["delta"].(float64); delta <= 0 {
+		t.Errorf("delta = %v, want positive growth", event.Data["delta"])
+	}
+	if !event.Timestamp.Equal(clock.Now()) {
+		t.Errorf("timestamp = %v, want the cultivator clock's %v", event.Timestamp, clock.Now())
+	}
+}
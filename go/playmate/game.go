@@ -0,0 +1,151 @@
+package playmate
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+var (
+	// ErrNotPlayful is returned when the playmate isn't playful enough to propose a game
+	ErrNotPlayful = errors.New("not in a playful mood")
+	// ErrTooTired is returned when the playmate lacks the energy to play
+	ErrTooTired = errors.New("too tired to play")
+)
+
+// GameKind identifies a type of game
+type GameKind string
+
+const (
+	GameRiddle          GameKind = "riddle"
+	GameWordAssociation GameKind = "word_association"
+	GameWhatIf          GameKind = "what_if"
+)
+
+// gameKinds lists the kinds ProposeGame chooses from
+var gameKinds = []GameKind{GameRiddle, GameWordAssociation, GameWhatIf}
+
+const (
+	// minGamePlayfulness is the playfulness below which ProposeGame declines
+	minGamePlayfulness = 0.3
+	// minGameEnergy is the energy below which ProposeGame declines
+	minGameEnergy = 0.2
+	// gameEnergyCost is the energy spent proposing a game
+	gameEnergyCost = 0.05
+)
+
+// Game is a simple challenge proposed by the playmate
+type Game struct {
+	ID         string    `json:"id"`
+	Kind       GameKind  `json:"kind"`
+	Topic      string    `json:"topic"`
+	Prompt     string    `json:"prompt"`
+	Answer     string    `json:"answer,omitempty"` // Empty for open-ended games
+	Difficulty float64   `json:"difficulty"`       // 0.0 to 1.0
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// GameFunc generates the prompt and answer for a game of the given kind about a topic
+type GameFunc func(kind GameKind, topic string, difficulty float64) (prompt, answer string, err error)
+
+// defaultGameFunc builds games from simple templates
+func defaultGameFunc(kind GameKind, topic string, difficulty float64) (string, string, error) {
+	switch kind {
+	case GameRiddle:
+		return fmt.Sprintf("I'm thinking of something connected to %s. Can you guess what it is?", topic), topic, nil
+	case GameWordAssociation:
+		return fmt.Sprintf("Word association! What's the first word that comes to mind for \"%s\"?", topic), "", nil
+	case GameWhatIf:
+		return fmt.Sprintf("What if %s worked completely backwards? What would change?", topic), "", nil
+	default:
+		return "", "", fmt.Errorf("unknown game kind: %s", kind)
+	}
+}
+
+// ProposeGame generates a game about one of the playmate's interests and starts
+// playing it. It declines with ErrNotPlayful or ErrTooTired when the playmate
+// lacks the playfulness or energy to play.
+func (p *Playmate) ProposeGame() (*Game, error) {
+	p.mu.Lock()
+	if p.Playfulness < minGamePlayfulness {
+		p.mu.Unlock()
+		return nil, ErrNotPlayful
+	}
+	if p.Energy < minGameEnergy || p.State == StateResting || p.State == StateDreaming {
+		p.mu.Unlock()
+		return nil, ErrTooTired
+	}
+
+	kind := gameKinds[p.rand.Intn(len(gameKinds))]
+	interest := p.pickGameInterest()
+	topic, difficulty := "anything at all", 0.3
+	if interest != nil {
+		topic = interest.Topic
+		difficulty = clamp(interest.Strength, 0.1, 1.0)
+	}
+	p.mu.Unlock()
+
+	// Generate outside the lock; a custom game func may be slow
+	prompt, answer, err := p.gameFunc(kind, topic, difficulty)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate game: %w", err)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// The state may have changed while the game was generated
+	if err := p.transition(StatePlaying); err != nil {
+		return nil, err
+	}
+
+	game := &Game{
+		ID:         p.ids.NewID("game"),
+		Kind:       kind,
+		Topic:      topic,
+		Prompt:     prompt,
+		Answer:     answer,
+		Difficulty: difficulty,
+		CreatedAt:  p.clock.Now(),
+	}
+
+	p.Energy = max(0, p.Energy-gameEnergyCost)
+	p.TotalGames++
+	p.adjustMood(0.1, "play")
+
+	if interest != nil {
+		if current, ok := p.Interests[interest.ID]; ok {
+			current.EngageCount++
+			current.Engagement = min(1.0, current.Engagement+0.05)
+			current.LastEngaged = p.clock.Now()
+		}
+	}
+
+	p.dirty = true
+	return game, nil
+}
+
+// EndGame stops playing and returns the playmate to its awake state
+func (p *Playmate) EndGame() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.State == StatePlaying {
+		p.transition(StateAwake)
+	}
+}
+
+// pickGameInterest chooses an interest to play about, or nil if there are none (must hold lock)
+func (p *Playmate) pickGameInterest() *Interest {
+	if len(p.Interests) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(p.Interests))
+	for id := range p.Interests {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return p.Interests[ids[p.rand.Intn(len(ids))]]
+}
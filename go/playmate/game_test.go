@@ -0,0 +1,143 @@
+package playmate
+
+import (
+	"errors"
+	"testing"
+)
+
+// stubGames records the games it was asked for and returns a fixed prompt
+type stubGames struct {
+	kinds  []GameKind
+	topics []string
+}
+
+func (s *stubGames) generate(kind GameKind, topic string, difficulty float64) (string, string, error) {
+	s.kinds = append(s.kinds, kind)
+	s.topics = append(s.topics, topic)
+	return "stub prompt about " + topic, "stub answer", nil
+}
+
+func TestProposeGameStartsPlaying(t *testing.T) {
+	stub := &stubGames{}
+	p, _ := newTestPlaymate(t, func(c *PlaymateConfig) {
+		c.PlayfulnessLevel = 0.9
+		c.GameFunc = stub.generate
+	})
+	tides := p.LearnInterest(InterestExploration, "tides", nil)
+	engaged := tides.EngageCount
+
+	game, err := p.ProposeGame()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if game.Prompt != "stub prompt about tides" || game.Answer != "stub answer" || game.Topic != "tides" {
+		t.Errorf("game = %+v, want the stub's prompt and answer about tides", game)
+	}
+	if len(stub.kinds) != 1 || stub.kinds[0] != game.Kind {
+		t.Errorf("game func called with %v, want one call for %s", stub.kinds, game.Kind)
+	}
+	if game.Difficulty < 0.1 || game.Difficulty > 1.0 {
+		t.Errorf("difficulty = %v, want within [0.1, 1]", game.Difficulty)
+	}
+	if state := currentState(p); state != StatePlaying {
+		t.Errorf("state = %s, want %s", state, StatePlaying)
+	}
+
+	p.mu.RLock()
+	totalGames, energy, engageCount := p.TotalGames, p.Energy, p.Interests[tides.ID].EngageCount
+	p.mu.RUnlock()
+	if totalGames != 1 {
+		t.Errorf("TotalGames = %d, want 1", totalGames)
+	}
+	if energy != 1.0-gameEnergyCost {
+		t.Errorf("Energy = %v, want %v", energy, 1.0-gameEnergyCost)
+	}
+	if engageCount != engaged+1 {
+		t.Errorf("interest EngageCount = %d, want %d", engageCount, engaged+1)
+	}
+
+	p.EndGame()
+	if state := currentState(p); state != StateAwake {
+		t.Errorf("state after EndGame = %s, want %s", state, StateAwake)
+	}
+}
+
+func TestProposeGameWithoutInterests(t *testing.T) {
+	stub := &stubGames{}
+	p, _ := newTestPlaymate(t, func(c *PlaymateConfig) {
+		c.PlayfulnessLevel = 0.9
+		c.GameFunc = stub.generate
+	})
+	game, err := p.ProposeGame()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if game.Topic != "anything at all" {
+		t.Errorf("topic = %q, want the fallback topic", game.Topic)
+	}
+}
+
+func TestProposeGameDeclines(t *testing.T) {
+	tests := []struct {
+		name   string
+		adjust func(p *Playmate)
+		want   error
+	}{
+		{"low playfulness", func(p *Playmate) { p.Playfulness = minGamePlayfulness / 2 }, ErrNotPlayful},
+		{"low energy", func(p *Playmate) { p.Energy = minGameEnergy / 2 }, ErrTooTired},
+		{"resting", func(p *Playmate) { p.State = StateResting }, ErrTooTired},
+		{"dreaming", func(p *Playmate) { p.State = StateDreaming }, ErrTooTired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stub := &stubGames{}
+			p, _ := newTestPlaymate(t, func(c *PlaymateConfig) {
+				c.PlayfulnessLevel = 0.9
+				c.GameFunc = stub.generate
+			})
+			p.mu.Lock()
+			tt.adjust(p)
+			before := p.State
+			p.mu.Unlock()
+
+			game, err := p.ProposeGame()
+			if !errors.Is(err, tt.want) || game != nil {
+				t.Fatalf("ProposeGame = %v, %v; want nil, %v", game, err, tt.want)
+			}
+			if len(stub.kinds) != 0 {
+				t.Error("game func called although the playmate declined")
+			}
+			if state := currentState(p); state != before {
+				t.Errorf("state = %s, want unchanged %s", state, before)
+			}
+		})
+	}
+}
+
+func TestProposeGameFuncError(t *testing.T) {
+	failure := errors.New("no ideas")
+	p, _ := newTestPlaymate(t, func(c *PlaymateConfig) {
+		c.PlayfulnessLevel = 0.9
+		c.GameFunc = func(GameKind, string, float64) (string, string, error) {
+			return "", "", failure
+		}
+	})
+	if _, err := p.ProposeGame(); !errors.Is(err, failure) {
+		t.Fatalf("ProposeGame error = %v, want %v", err, failure)
+	}
+	if state := currentState(p); state != StateAwake {
+		t.Errorf("state = %s, want %s after a failed game", state, StateAwake)
+	}
+}
+
+func TestDefaultGameFunc(t *testing.T) {
+	for _, kind := range gameKinds {
+		prompt, _, err := defaultGameFunc(kind, "tides", 0.5)
+		if err != nil || prompt == "" {
+			t.Errorf("defaultGameFunc(%s) = %q, %v; want a prompt", kind, prompt, err)
+		}
+	}
+	if _, _, err := defaultGameFunc("charades", "tides", 0.5); err == nil {
+		t.Error("defaultGameFunc accepted an unknown kind")
+	}
+}
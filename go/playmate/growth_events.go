@@ -0,0 +1,48 @@
+package playmate
+
+import (
+	"sort"
+	"time"
+)
+
+// GrowthFilter selects growth events; zero-valued fields match everything
+type GrowthFilter struct {
+	Dimension WisdomDimension // Exact dimension
+	Trigger   string          // Exact trigger
+	After     time.Time       // Recorded at or after this time
+	Before    time.Time       // Recorded before this time
+}
+
+// matches reports whether a growth event satisfies every set field of the filter
+func (f GrowthFilter) matches(e GrowthEvent) bool {
+	if f.Dimension != "" && e.Dimension != f.Dimension {
+		return false
+	}
+	if f.Trigger != "" && e.Trigger != f.Trigger {
+		return false
+	}
+	if !f.After.IsZero() && e.Timestamp.Before(f.After) {
+		return false
+	}
+	if !f.Before.IsZero() && !e.Timestamp.Before(f.Before) {
+		return false
+	}
+	return true
+}
+
+// GrowthEvents returns copies of the growth events matching a filter, oldest first
+func (wc *WisdomCultivator) GrowthEvents(filter GrowthFilter) []GrowthEvent {
+	wc.mu.RLock()
+	defer wc.mu.RUnlock()
+
+	found := make([]GrowthEvent, 0)
+	for _, e := range wc.GrowthHistory {
+		if filter.matches(e) {
+			found = append(found, e)
+		}
+	}
+	sort.SliceStable(found, func(i, j int) bool {
+		return found[i].Timestamp.Before(found[j].Timestamp)
+	})
+	return found
+}
@@ -0,0 +1,62 @@
+package playmate
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestGrowthEventsFilters(t *testing.T) {
+	wc, _ := newTestCultivator(t, nil)
+	t0 := time.Date(2026, 3, 14, 9, 0, 0, 0, time.UTC)
+	at := func(h int) time.Time { return t0.Add(time.Duration(h) * time.Hour) }
+
+	// Stored out of order to check sorting
+	history := []GrowthEvent{
+		{Timestamp: at(2), Dimension: DimensionCompassion, Delta: 0.02, Trigger: "discussion"},
+		{Timestamp: at(0), Dimension: DimensionUnderstanding, Delta: 0.01, Trigger: "insight"},
+		{Timestamp: at(1), Dimension: DimensionCompassion, Delta: 0.03, Trigger: "insight"},
+		{Timestamp: at(3), Dimension: DimensionReflection, Delta: 0.01, Trigger: "discussion"},
+	}
+	wc.mu.Lock()
+	wc.GrowthHistory = append([]GrowthEvent(nil), history...)
+	wc.mu.Unlock()
+
+	tests := []struct {
+		name   string
+		filter GrowthFilter
+		want   []GrowthEvent
+	}{
+		{"everything", GrowthFilter{}, []GrowthEvent{history[1], history[2], history[0], history[3]}},
+		{"dimension", GrowthFilter{Dimension: DimensionCompassion}, []GrowthEvent{history[2], history[0]}},
+		{"trigger", GrowthFilter{Trigger: "discussion"}, []GrowthEvent{history[0], history[3]}},
+		{"dimension and trigger", GrowthFilter{Dimension: DimensionCompassion, Trigger: "insight"}, []GrowthEvent{history[2]}},
+		{"after is inclusive", GrowthFilter{After: at(2)}, []GrowthEvent{history[0], history[3]}},
+		{"before is exclusive", GrowthFilter{Before: at(1)}, []GrowthEvent{history[1]}},
+		{"range", GrowthFilter{After: at(1), Before: at(3)}, []GrowthEvent{history[2], history[0]}},
+		{"empty range", GrowthFilter{After: at(2), Before: at(2)}, []GrowthEvent{}},
+		{"no match", GrowthFilter{Trigger: "dream"}, []GrowthEvent{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := wc.GrowthEvents(tt.filter); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GrowthEvents = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGrowthEventsReturnsCopies(t *testing.T) {
+	wc, clock := newTestCultivator(t, nil)
+	clock.Advance(time.Hour)
+	wc.GrowDimension(DimensionPerspective, 0.05, "travel")
+
+	events := wc.GrowthEvents(GrowthFilter{Trigger: "travel"})
+	if len(events) != 1 || events[0].Dimension != DimensionPerspective || !events[0].Timestamp.Equal(clock.Now()) {
+		t.Fatalf("events = %+v, want the travel growth", events)
+	}
+	events[0].Trigger = "changed"
+	if again := wc.GrowthEvents(GrowthFilter{Trigger: "travel"}); len(again) != 1 {
+		t.Errorf("changing a returned event altered the history: %+v", again)
+	}
+}
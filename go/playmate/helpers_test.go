@@ -0,0 +1,108 @@
+package playmate
+
+import (
+	"math/rand"
+	"os"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock is a Clock that only moves when a test advances it
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2026, 3, 14, 9, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// newTestPlaymate creates a playmate from the default config as adjusted by
+// configure, with a fake clock starting mid-morning UTC and seeded randomness.
+// The playmate is stopped when the test ends.
+func newTestPlaymate(t *testing.T, configure func(*PlaymateConfig)) (*Playmate, *fakeClock) {
+	t.Helper()
+	clock := newFakeClock()
+	config := DefaultPlaymateConfig()
+	config.Clock = clock
+	config.Location = time.UTC
+	config.Rand = rand.New(rand.NewSource(1))
+	if configure != nil {
+		configure(config)
+	}
+	p, err := NewPlaymate(config)
+	if err != nil {
+		t.Fatalf("NewPlaymate: %v", err)
+	}
+	t.Cleanup(p.Stop)
+	return p, clock
+}
+
+// mustStartDiscussion starts a discussion, failing the test on error
+func mustStartDiscussion(t *testing.T, p *Playmate, topic, participant string) *Discussion {
+	t.Helper()
+	d, err := p.StartDiscussion(topic, participant)
+	if err != nil {
+		t.Fatalf("StartDiscussion(%q): %v", topic, err)
+	}
+	return d
+}
+
+// newTestCultivator creates a cultivator with a fake clock from config, which
+// may be nil
+func newTestCultivator(t *testing.T, config *WisdomConfig) (*WisdomCultivator, *fakeClock) {
+	t.Helper()
+	clock := newFakeClock()
+	if config == nil {
+		config = &WisdomConfig{}
+	}
+	config.Clock = clock
+	wc, err := NewWisdomCultivator(config)
+	if err != nil {
+		t.Fatalf("NewWisdomCultivator: %v", err)
+	}
+	return wc, clock
+}
+
+// currentState reads the playmate's state under its lock
+func currentState(p *Playmate) PlaymateState {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.State
+}
+
+// memoryStore is a persist.Store held in memory
+type memoryStore struct {
+	data   map[string][]byte
+	writes int
+}
+
+func (s *memoryStore) Read(key string) ([]byte, error) {
+	data, ok := s.data[key]
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+	return append([]byte(nil), data...), nil
+}
+
+func (s *memoryStore) Write(key string, data []byte) error {
+	if s.data == nil {
+		s.data = make(map[string][]byte)
+	}
+	s.data[key] = append([]byte(nil), data...)
+	s.writes++
+	return nil
+}
@@ -0,0 +1,211 @@
+package playmate
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// NewHTTPHandler exposes a playmate over HTTP with JSON request and response bodies:
+//
+//	GET  /state                       current state
+//	POST /discussions                 start a discussion {"topic", "participant"}
+//	GET  /discussions/{id}            get a discussion
+//	POST /discussions/{id}/messages   send a message {"from", "content"}
+//	POST /discussions/{id}/end        end a discussion
+//	POST /interests                   learn an interest {"category", "topic", "keywords"}
+//	POST /skills                      practice a skill {"name", "description"}
+func NewHTTPHandler(p *Playmate) http.Handler {
+	h := &httpHandler{p: p}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/state", h.handleState)
+	mux.HandleFunc("/discussions", h.handleDiscussions)
+	mux.HandleFunc("/discussions/", h.handleDiscussion)
+	mux.HandleFunc("/interests", h.handleInterests)
+	mux.HandleFunc("/skills", h.handleSkills)
+	return mux
+}
+
+type httpHandler struct {
+	p *Playmate
+}
+
+func (h *httpHandler) handleState(w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r, http.MethodGet) {
+		return
+	}
+	writeJSON(w, http.StatusOK, h.p.GetState())
+}
+
+func (h *httpHandler) handleDiscussions(w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r, http.MethodPost) {
+		return
+	}
+
+	var req struct {
+		Topic       string `json:"topic"`
+		Participant string `json:"participant"`
+	}
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if req.Topic == "" || req.Participant == "" {
+		writeError(w, http.StatusBadRequest, "topic and participant are required")
+		return
+	}
+
+	discussion, err := h.p.StartDiscussion(req.Topic, req.Participant)
+	if err != nil {
+		writeDiscussionError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, discussion)
+}
+
+// handleDiscussion routes /discussions/{id}[/messages|/end]
+func (h *httpHandler) handleDiscussion(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/discussions/"), "/"), "/")
+	if parts[0] == "" || len(parts) > 2 {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	id := parts[0]
+
+	if len(parts) == 1 {
+		if !allowMethod(w, r, http.MethodGet) {
+			return
+		}
+		d, err := h.p.GetDiscussion(id)
+		if err != nil {
+			writeDiscussionError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, d)
+		return
+	}
+
+	switch parts[1] {
+	case "messages":
+		if !allowMethod(w, r, http.MethodPost) {
+			return
+		}
+		var req struct {
+			From    string `json:"from"`
+			Content string `json:"content"`
+		}
+		if !readJSON(w, r, &req) {
+			return
+		}
+		if req.From == "" || req.Content == "" {
+			writeError(w, http.StatusBadRequest, "from and content are required")
+			return
+		}
+		msg, err := h.p.SendMessage(id, req.From, req.Content)
+		if err != nil {
+			writeDiscussionError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusCreated, msg)
+
+	case "end":
+		if !allowMethod(w, r, http.MethodPost) {
+			return
+		}
+		if err := h.p.EndDiscussion(id); err != nil {
+			writeDiscussionError(w, err)
+			return
+		}
+		d, err := h.p.GetDiscussion(id)
+		if err != nil {
+			writeDiscussionError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, d)
+
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+	}
+}
+
+func (h *httpHandler) handleInterests(w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r, http.MethodPost) {
+		return
+	}
+
+	var req struct {
+		Category InterestCategory `json:"category"`
+		Topic    string           `json:"topic"`
+		Keywords []string         `json:"keywords"`
+	}
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if req.Category == "" || req.Topic == "" {
+		writeError(w, http.StatusBadRequest, "category and topic are required")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, h.p.LearnInterest(req.Category, req.Topic, req.Keywords))
+}
+
+func (h *httpHandler) handleSkills(w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r, http.MethodPost) {
+		return
+	}
+
+	var req struct {
+		Name        string `json:"name"`
+		Description string `json:"description"`
+	}
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if req.Name == "" {
+		writeError(w, http.StatusBadRequest, "name is required")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, h.p.PracticeSkill(req.Name, req.Description))
+}
+
+// allowMethod rejects requests whose method differs from the expected one
+func allowMethod(w http.ResponseWriter, r *http.Request, method string) bool {
+	if r.Method != method {
+		w.Header().Set("Allow", method)
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return false
+	}
+	return true
+}
+
+// readJSON decodes the request body, replying 400 on malformed input
+func readJSON(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON body: "+err.Error())
+		return false
+	}
+	return true
+}
+
+// writeDiscussionError maps discussion errors onto HTTP status codes
+func writeDiscussionError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, ErrDiscussionNotFound):
+		writeError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, ErrDiscussionInactive), errors.Is(err, ErrInvalidTransition):
+		writeError(w, http.StatusConflict, err.Error())
+	default:
+		writeError(w, http.StatusInternalServerError, err.Error())
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
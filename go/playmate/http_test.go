@@ -0,0 +1,133 @@
+package playmate
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// do sends a request to the handler and decodes the JSON response into out, if given
+func do(t *testing.T, h http.Handler, method, path, body string, out interface{}) int {
+	t.Helper()
+	var req *http.Request
+	if body == "" {
+		req = httptest.NewRequest(method, path, nil)
+	} else {
+		req = httptest.NewRequest(method, path, strings.NewReader(body))
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("%s %s: content type %q, want application/json", method, path, ct)
+	}
+	if out != nil {
+		if err := json.Unmarshal(rec.Body.Bytes(), out); err != nil {
+			t.Fatalf("%s %s: decoding %q: %v", method, path, rec.Body.String(), err)
+		}
+	}
+	return rec.Code
+}
+
+func TestHTTPDiscussionLifecycle(t *testing.T) {
+	p, _ := newTestPlaymate(t, nil)
+	h := NewHTTPHandler(p)
+
+	var started Discussion
+	if code := do(t, h, "POST", "/discussions", `{"topic":"tides","participant":"ana"}`, &started); code != http.StatusCreated {
+		t.Fatalf("start: status %d, want 201", code)
+	}
+	if started.ID == "" || started.Topic != "tides" || !started.Active {
+		t.Fatalf("started discussion = %+v", started)
+	}
+	path := "/discussions/" + started.ID
+
+	var msg DiscussionMessage
+	if code := do(t, h, "POST", path+"/messages", `{"from":"ana","content":"why does the tide follow the moon?"}`, &msg); code != http.StatusCreated {
+		t.Fatalf("send: status %d, want 201", code)
+	}
+	if msg.From != "ana" || msg.ID == "" {
+		t.Errorf("sent message = %+v", msg)
+	}
+
+	var fetched Discussion
+	if code := do(t, h, "GET", path, "", &fetched); code != http.StatusOK {
+		t.Fatalf("get: status %d, want 200", code)
+	}
+	if len(fetched.Messages) == 0 || fetched.Messages[0].ID != msg.ID {
+		t.Errorf("fetched messages = %+v, want the sent message first", fetched.Messages)
+	}
+
+	var ended Discussion
+	if code := do(t, h, "POST", path+"/end", "", &ended); code != http.StatusOK {
+		t.Fatalf("end: status %d, want 200", code)
+	}
+	if ended.Active || ended.EndedAt == nil {
+		t.Errorf("ended discussion = %+v, want inactive with an end time", ended)
+	}
+
+	if code := do(t, h, "POST", path+"/messages", `{"from":"ana","content":"one more thing"}`, nil); code != http.StatusConflict {
+		t.Errorf("send to ended discussion: status %d, want 409", code)
+	}
+}
+
+func TestHTTPErrors(t *testing.T) {
+	p, _ := newTestPlaymate(t, nil)
+	h := NewHTTPHandler(p)
+
+	tests := []struct {
+		method, path, body string
+		want               int
+	}{
+		{"GET", "/discussions/disc_missing", "", http.StatusNotFound},
+		{"POST", "/discussions/disc_missing/messages", `{"from":"ana","content":"hi"}`, http.StatusNotFound},
+		{"POST", "/discussions/disc_missing/end", "", http.StatusNotFound},
+		{"POST", "/discussions/disc_missing/archive", "", http.StatusNotFound},
+		{"POST", "/discussions", `{"topic":"tides"}`, http.StatusBadRequest},
+		{"POST", "/discussions", `{not json`, http.StatusBadRequest},
+		{"GET", "/discussions", "", http.StatusMethodNotAllowed},
+		{"POST", "/state", "", http.StatusMethodNotAllowed},
+		{"POST", "/interests", `{"topic":"tides"}`, http.StatusBadRequest},
+		{"POST", "/skills", `{"description":"no name"}`, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		var resp map[string]string
+		if code := do(t, h, tt.method, tt.path, tt.body, &resp); code != tt.want {
+			t.Errorf("%s %s: status %d, want %d", tt.method, tt.path, code, tt.want)
+		}
+		if resp["error"] == "" {
+			t.Errorf("%s %s: no error message in response", tt.method, tt.path)
+		}
+	}
+}
+
+func TestHTTPInterestsSkillsAndState(t *testing.T) {
+	p, _ := newTestPlaymate(t, nil)
+	h := NewHTTPHandler(p)
+
+	var interest Interest
+	if code := do(t, h, "POST", "/interests", `{"category":"exploration","topic":"tides","keywords":["moon"]}`, &interest); code != http.StatusOK {
+		t.Fatalf("learn interest: status %d, want 200", code)
+	}
+	if interest.Topic != "tides" || interest.Category != InterestExploration {
+		t.Errorf("learned interest = %+v", interest)
+	}
+
+	var skill Skill
+	if code := do(t, h, "POST", "/skills", `{"name":"juggling"}`, &skill); code != http.StatusOK {
+		t.Fatalf("practice skill: status %d, want 200", code)
+	}
+	if skill.Name != "juggling" || skill.PracticeCount == 0 {
+		t.Errorf("practiced skill = %+v", skill)
+	}
+
+	var state map[string]interface{}
+	if code := do(t, h, "GET", "/state", "", &state); code != http.StatusOK {
+		t.Fatalf("state: status %d, want 200", code)
+	}
+	if state["name"] != p.Name || state["total_interests"] != 1.0 {
+		t.Errorf("state = %v, want the playmate's name and one interest", state)
+	}
+}
@@ -0,0 +1,54 @@
+package playmate
+
+import "fmt"
+
+// noteInteraction records external interaction, resetting idle detection (must hold lock)
+func (p *Playmate) noteInteraction() {
+	p.lastInteraction = p.clock.Now()
+}
+
+// checkIdle turns a playmate left alone for IdleTimeout toward learning: it
+// moves into StateLearning, practices its most neglected skill or deepens its
+// most neglected interest, and returns to StateAwake so it keeps thinking and
+// resting as usual. The idle timer then restarts, so a playmate that stays
+// alone keeps learning once per timeout. It returns the item revisited, or
+// nil when the playmate is not idle.
+func (p *Playmate) checkIdle() *NeglectedItem {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.idleTimeout == 0 || p.quiet {
+		return nil
+	}
+	if p.State != StateAwake && p.State != StateReflecting && p.State != StateLearning {
+		return nil
+	}
+	now := p.clock.Now()
+	if now.Sub(p.lastInteraction) < p.idleTimeout {
+		return nil
+	}
+	p.lastInteraction = now
+
+	if err := p.transition(StateLearning); err != nil {
+		return nil
+	}
+	defer p.transition(StateAwake)
+
+	item := p.mostNeglected()
+	if item == nil {
+		return nil
+	}
+	switch item.Kind {
+	case "interest":
+		interest := p.Interests[item.ID]
+		p.learnInterest(interest.Category, interest.Topic, nil)
+		p.appendThought(fmt.Sprintf("With a quiet moment to myself, I went deeper into %s.", item.Name))
+	case "skill":
+		skill := p.Skills[item.ID]
+		p.practiceSkill(skill.Name, skill.Description)
+		p.appendThought(fmt.Sprintf("With a quiet moment to myself, I practiced %s.", item.Name))
+	}
+
+	p.logger.Debug("idle playmate turned to learning", "kind", item.Kind, "id", item.ID)
+	return item
+}
@@ -0,0 +1,149 @@
+package playmate
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// visitedStates lists the states recorded in the history after index from
+func visitedStates(p *Playmate, from int) []PlaymateState {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	states := make([]PlaymateState, 0)
+	for _, change := range p.StateHistory[from:] {
+		states = append(states, change.State)
+	}
+	return states
+}
+
+// historyLen returns the length of the state history
+func historyLen(p *Playmate) int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return len(p.StateHistory)
+}
+
+func TestIdlePlaymatePracticesNeglectedSkill(t *testing.T) {
+	p, clock := newTestPlaymate(t, func(c *PlaymateConfig) { c.IdleTimeout = time.Hour })
+	skill := p.PracticeSkill("chess", "the royal game")
+	clock.Advance(10 * time.Minute)
+	interest := p.LearnInterest(InterestExploration, "tides", nil)
+
+	clock.Advance(49 * time.Minute)
+	if item := p.checkIdle(); item != nil {
+		t.Fatalf("checkIdle before the timeout revisited %s", item.Name)
+	}
+
+	clock.Advance(time.Minute)
+	mark := historyLen(p)
+	item := p.checkIdle()
+	if item == nil || item.Kind != "skill" || item.ID != skill.ID {
+		t.Fatalf("checkIdle = %+v, want the neglected chess skill", item)
+	}
+	if got, want := visitedStates(p, mark), []PlaymateState{StateLearning, StateAwake}; !reflect.DeepEqual(got, want) {
+		t.Errorf("states while idle = %v, want %v", got, want)
+	}
+	practiced := p.ListSkills()[0]
+	if practiced.PracticeCount != 2 || !practiced.LastPracticed.Equal(clock.Now()) {
+		t.Errorf("skill after idle learning = %+v, want a second practice now", practiced)
+	}
+	p.mu.RLock()
+	last := p.StreamOfThoughts[len(p.StreamOfThoughts)-1]
+	p.mu.RUnlock()
+	if !strings.Contains(last, "practiced chess") {
+		t.Errorf("last thought = %q, want it to mention practicing chess", last)
+	}
+
+	// The idle timer restarts; the interest is now the most neglected
+	if item := p.checkIdle(); item != nil {
+		t.Errorf("checkIdle straight after learning revisited %s", item.Name)
+	}
+	clock.Advance(time.Hour)
+	if item := p.checkIdle(); item == nil || item.ID != interest.ID {
+		t.Errorf("second idle period revisited %+v, want the tides interest", item)
+	}
+	for _, i := range p.ListInterests() {
+		if i.ID == interest.ID && i.EngageCount != 2 {
+			t.Errorf("interest engage count = %d after idle learning, want 2", i.EngageCount)
+		}
+	}
+}
+
+func TestInteractionResetsIdleTimer(t *testing.T) {
+	p, clock := newTestPlaymate(t, func(c *PlaymateConfig) { c.IdleTimeout = time.Hour })
+	p.PracticeSkill("chess", "the royal game")
+	d := mustStartDiscussion(t, p, "openings", "ana")
+	if err := p.EndDiscussion(d.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	clock.Advance(50 * time.Minute)
+	if err := p.AddMessage(d.ID, "ana", "ok"); err != nil {
+		t.Fatal(err)
+	}
+	clock.Advance(50 * time.Minute)
+	if item := p.checkIdle(); item != nil {
+		t.Errorf("checkIdle 50 minutes after a message revisited %s", item.Name)
+	}
+	clock.Advance(10 * time.Minute)
+	if item := p.checkIdle(); item == nil {
+		t.Error("checkIdle an hour after the last message revisited nothing")
+	}
+}
+
+func TestIdleDetectionSkipsBusyStates(t *testing.T) {
+	p, clock := newTestPlaymate(t, func(c *PlaymateConfig) { c.IdleTimeout = time.Hour })
+	p.PracticeSkill("chess", "the royal game")
+	mustStartDiscussion(t, p, "openings", "ana")
+
+	clock.Advance(2 * time.Hour)
+	if item := p.checkIdle(); item != nil {
+		t.Errorf("engaged playmate revisited %s", item.Name)
+	}
+	if got := currentState(p); got != StateEngaged {
+		t.Errorf("state = %s, want engaged", got)
+	}
+}
+
+func TestIdleDetectionDisabled(t *testing.T) {
+	p, clock := newTestPlaymate(t, func(c *PlaymateConfig) { c.IdleTimeout = 0 })
+	p.PracticeSkill("chess", "the royal game")
+	clock.Advance(24 * time.Hour)
+	if item := p.checkIdle(); item != nil {
+		t.Errorf("checkIdle with no timeout revisited %s", item.Name)
+	}
+}
+
+func TestAutonomousLoopDetectsIdle(t *testing.T) {
+	p, clock := newTestPlaymate(t, func(c *PlaymateConfig) {
+		c.IdleTimeout = time.Hour
+		c.ThoughtInterval = 5 * time.Millisecond
+	})
+	p.PracticeSkill("chess", "the royal game")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := p.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	clock.Advance(2 * time.Hour)
+
+	learned := func() bool {
+		for _, state := range visitedStates(p, 0) {
+			if state == StateLearning {
+				return true
+			}
+		}
+		return false
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for !learned() {
+		if time.Now().After(deadline) {
+			t.Fatal("autonomous loop never turned an idle playmate to learning")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,92 @@
+package playmate
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// IDGenerator creates unique IDs for discussions, messages, wonders, insights,
+// and principles. Interests and skills are keyed by their category/topic and
+// name so that learning the same thing twice strengthens the existing entry.
+type IDGenerator interface {
+	NewID(prefix string) string
+}
+
+// sequentialIDGenerator numbers IDs from a monotonic counter, so the same
+// sequence of operations yields the same IDs on every run
+type sequentialIDGenerator struct {
+	counter uint64
+}
+
+// NewSequentialIDGenerator returns the default IDGenerator
+func NewSequentialIDGenerator() IDGenerator {
+	return &sequentialIDGenerator{}
+}
+
+func (g *sequentialIDGenerator) NewID(prefix string) string {
+	seq := atomic.AddUint64(&g.counter, 1)
+	return fmt.Sprintf("%s_%d", prefix, seq)
+}
+
+// reserve advances the counter past the sequence number ending id, if any
+func (g *sequentialIDGenerator) reserve(id string) {
+	i := strings.LastIndexByte(id, '_')
+	if i < 0 {
+		return
+	}
+	seq, err := strconv.ParseUint(id[i+1:], 10, 64)
+	if err != nil {
+		return
+	}
+	for {
+		current := atomic.LoadUint64(&g.counter)
+		if seq <= current || atomic.CompareAndSwapUint64(&g.counter, current, seq) {
+			return
+		}
+	}
+}
+
+// reserveIDs keeps a sequential generator from reissuing IDs already in use,
+// such as those restored by Load. Custom generators are responsible for their
+// own uniqueness.
+func reserveIDs(g IDGenerator, ids ...string) {
+	seq, ok := g.(*sequentialIDGenerator)
+	if !ok {
+		return
+	}
+	for _, id := range ids {
+		seq.reserve(id)
+	}
+}
+
+// reserveLoadedIDs reserves the IDs of loaded discussions, messages, wonders,
+// journal entries, and digests (must hold lock)
+func (p *Playmate) reserveLoadedIDs() {
+	for id, d := range p.Discussions {
+		reserveIDs(p.ids, id)
+		for _, msg := range d.Messages {
+			reserveIDs(p.ids, msg.ID)
+		}
+	}
+	for _, w := range p.Wonders {
+		reserveIDs(p.ids, w.ID)
+	}
+	for _, entry := range p.Journal {
+		reserveIDs(p.ids, entry.ID)
+	}
+	for _, digest := range p.Digests {
+		reserveIDs(p.ids, digest.ID)
+	}
+}
+
+// reserveLoadedIDs reserves the IDs of loaded principles and insights (must hold lock)
+func (wc *WisdomCultivator) reserveLoadedIDs() {
+	for id := range wc.Principles {
+		reserveIDs(wc.ids, id)
+	}
+	for _, insight := range wc.Insights {
+		reserveIDs(wc.ids, insight.ID)
+	}
+}
@@ -0,0 +1,103 @@
+package playmate
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestMessageIDsUniqueInTightLoop(t *testing.T) {
+	p, _ := newTestPlaymate(t, nil)
+	d := mustStartDiscussion(t, p, "tides", "ana")
+
+	const n = 1000
+	for i := 0; i < n; i++ {
+		if err := p.AddMessage(d.ID, "ana", fmt.Sprintf("message %d", i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, err := p.GetDiscussion(d.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	seen := map[string]bool{d.ID: true}
+	for _, msg := range got.Messages {
+		if seen[msg.ID] {
+			t.Fatalf("duplicate ID %s", msg.ID)
+		}
+		seen[msg.ID] = true
+	}
+	if len(got.Messages) < n {
+		t.Errorf("got %d messages, want at least %d", len(got.Messages), n)
+	}
+}
+
+func TestSequentialIDGeneratorConcurrent(t *testing.T) {
+	g := NewSequentialIDGenerator()
+
+	var mu sync.Mutex
+	seen := make(map[string]bool)
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 500; i++ {
+				id := g.NewID("msg")
+				mu.Lock()
+				if seen[id] {
+					t.Errorf("duplicate ID %s", id)
+				}
+				seen[id] = true
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if len(seen) != 4000 {
+		t.Errorf("generated %d distinct IDs, want 4000", len(seen))
+	}
+}
+
+func TestIDsNotReissuedAfterLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "playmate")
+	configure := func(c *PlaymateConfig) { c.PersistPath = path }
+
+	p, _ := newTestPlaymate(t, configure)
+	first := mustStartDiscussion(t, p, "tides", "ana")
+	if err := p.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, _ := newTestPlaymate(t, configure)
+	second := mustStartDiscussion(t, loaded, "stars", "ana")
+	if second.ID == first.ID {
+		t.Fatalf("reloaded playmate reissued %s", first.ID)
+	}
+	if got, err := loaded.GetDiscussion(first.ID); err != nil || got.Topic != "tides" {
+		t.Errorf("original discussion after a new one started = %+v, %v", got, err)
+	}
+}
+
+// prefixedIDs is an IDGenerator with a recognizable format
+type prefixedIDs struct {
+	mu sync.Mutex
+	n  int
+}
+
+func (g *prefixedIDs) NewID(prefix string) string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.n++
+	return fmt.Sprintf("custom-%s-%d", prefix, g.n)
+}
+
+func TestCustomIDGenerator(t *testing.T) {
+	p, _ := newTestPlaymate(t, func(c *PlaymateConfig) { c.IDGenerator = &prefixedIDs{} })
+	d := mustStartDiscussion(t, p, "tides", "ana")
+	if d.ID != "custom-disc-1" {
+		t.Errorf("discussion ID = %q, want custom-disc-1", d.ID)
+	}
+}
@@ -0,0 +1,59 @@
+package playmate
+
+import "strings"
+
+// engagementLearningRate is how far each discussion moves an interest's
+// engagement toward the discussion's score
+const engagementLearningRate = 0.3
+
+// EngagementScorer rates how engaging a finished discussion was, from 0.0 to 1.0
+type EngagementScorer func(d *Discussion) float64
+
+// defaultEngagementScorer weighs how deep a discussion went (full credit at
+// depth 10) against how positive its messages were on average
+func defaultEngagementScorer(d *Discussion) float64 {
+	depth := min(1.0, float64(d.Depth)/10.0)
+
+	sentiment := 0.0
+	for _, msg := range d.Messages {
+		sentiment += msg.Sentiment
+	}
+	if len(d.Messages) > 0 {
+		sentiment /= float64(len(d.Messages))
+	}
+
+	return clamp(0.6*depth+0.4*(sentiment+1)/2, 0, 1)
+}
+
+// updateInterestEngagement moves the engagement of every interest matching a
+// finished discussion's topic toward the discussion's engagement score (must hold lock)
+func (p *Playmate) updateInterestEngagement(d *Discussion) {
+	topicWords := keywordCounts([]string{d.Topic})
+	if len(topicWords) == 0 {
+		return
+	}
+
+	score := clamp(p.scoreEngagement(d), 0, 1)
+	for _, interest := range p.Interests {
+		if !interestMatches(interest, topicWords) {
+			continue
+		}
+		interest.Engagement = clamp(interest.Engagement+engagementLearningRate*(score-interest.Engagement), 0, 1)
+		p.dirty = true
+	}
+}
+
+// interestMatches reports whether any word of an interest's topic or keywords is among words
+func interestMatches(interest *Interest, words map[string]int) bool {
+	for w := range keywordCounts([]string{interest.Topic}) {
+		if words[w] > 0 {
+			return true
+		}
+	}
+	for _, k := range interest.Keywords {
+		if words[strings.ToLower(k)] > 0 {
+			return true
+		}
+	}
+	return false
+}
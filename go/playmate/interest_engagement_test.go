@@ -0,0 +1,66 @@
+package playmate
+
+import (
+	"math"
+	"testing"
+)
+
+// endDiscussionWith ends a discussion on topic after setting its depth and
+// giving it one message per sentiment
+func endDiscussionWith(t *testing.T, p *Playmate, topic string, depth int, sentiments ...float64) {
+	t.Helper()
+	d := mustStartDiscussion(t, p, topic, "ana")
+	p.mu.Lock()
+	d = p.Discussions[d.ID]
+	d.Depth = depth
+	for _, s := range sentiments {
+		d.Messages = append(d.Messages, DiscussionMessage{From: "ana", Sentiment: s})
+	}
+	p.mu.Unlock()
+	if err := p.EndDiscussion(d.ID); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDeepPositiveDiscussionRaisesEngagement(t *testing.T) {
+	p, _ := newTestPlaymate(t, nil)
+	p.LearnInterest(InterestExploration, "tides", []string{"ocean"})
+	p.LearnInterest(InterestCreativity, "origami", nil)
+
+	endDiscussionWith(t, p, "Ocean currents", 10, 0.8, 0.8)
+
+	interests := interestsByID(p)
+	// Score 0.6*1 + 0.4*0.9 = 0.96; engagement moves 30% of the way from 0.5
+	want := 0.5 + engagementLearningRate*(0.96-0.5)
+	if got := interests["exploration_tides"].Engagement; math.Abs(got-want) > 1e-9 {
+		t.Errorf("tides engagement = %v, want %v", got, want)
+	}
+	if got := interests["creativity_origami"].Engagement; got != 0.5 {
+		t.Errorf("unrelated origami engagement = %v, want unchanged 0.5", got)
+	}
+}
+
+func TestShallowNegativeDiscussionLowersEngagement(t *testing.T) {
+	p, _ := newTestPlaymate(t, nil)
+	p.LearnInterest(InterestExploration, "tides", nil)
+
+	endDiscussionWith(t, p, "tides again", 1, -0.6)
+
+	if got := interestsByID(p)["exploration_tides"].Engagement; got >= 0.5 {
+		t.Errorf("engagement after a shallow, negative discussion = %v, want below 0.5", got)
+	}
+}
+
+func TestCustomEngagementScorer(t *testing.T) {
+	p, _ := newTestPlaymate(t, func(c *PlaymateConfig) {
+		c.EngagementScorer = func(d *Discussion) float64 { return 3 } // Clamped to 1.0
+	})
+	p.LearnInterest(InterestExploration, "tides", nil)
+
+	for i := 0; i < 20; i++ {
+		endDiscussionWith(t, p, "tides", 0)
+	}
+	if got := interestsByID(p)["exploration_tides"].Engagement; got > 1.0 || got < 0.99 {
+		t.Errorf("engagement after repeated full scores = %v, want approaching 1.0", got)
+	}
+}
@@ -0,0 +1,87 @@
+package playmate
+
+import (
+	"sort"
+	"strings"
+)
+
+// relatedInterestThreshold is the keyword overlap (Jaccard similarity) at which two interests are related
+const relatedInterestThreshold = 0.25
+
+// InterestGraph links each interest ID to the IDs of interests sharing enough
+// keywords with it, sorted by ID. Every interest appears, even with no links.
+func (p *Playmate) InterestGraph() map[string][]string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	graph := make(map[string][]string, len(p.Interests))
+	for id := range p.Interests {
+		graph[id] = make([]string, 0)
+	}
+	for id, interest := range p.Interests {
+		for otherID, other := range p.Interests {
+			if id != otherID && keywordOverlap(interest, other) >= relatedInterestThreshold {
+				graph[id] = append(graph[id], otherID)
+			}
+		}
+		sort.Strings(graph[id])
+	}
+	return graph
+}
+
+// RelatedInterests returns copies of the interests related to the given one,
+// most closely related first. Unknown IDs have no related interests.
+func (p *Playmate) RelatedInterests(id string) []*Interest {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	related := make([]*Interest, 0)
+	interest, ok := p.Interests[id]
+	if !ok {
+		return related
+	}
+
+	overlaps := make(map[string]float64)
+	for otherID, other := range p.Interests {
+		if otherID == id {
+			continue
+		}
+		if overlap := keywordOverlap(interest, other); overlap >= relatedInterestThreshold {
+			overlaps[otherID] = overlap
+			related = append(related, other.clone())
+		}
+	}
+
+	sort.Slice(related, func(i, j int) bool {
+		a, b := overlaps[related[i].ID], overlaps[related[j].ID]
+		if a != b {
+			return a > b
+		}
+		return related[i].ID < related[j].ID
+	})
+	return related
+}
+
+// keywordOverlap returns the Jaccard similarity of two interests' keywords, ignoring case
+func keywordOverlap(a, b *Interest) float64 {
+	setA := make(map[string]bool, len(a.Keywords))
+	for _, k := range a.Keywords {
+		setA[strings.ToLower(k)] = true
+	}
+	setB := make(map[string]bool, len(b.Keywords))
+	for _, k := range b.Keywords {
+		setB[strings.ToLower(k)] = true
+	}
+
+	shared := 0
+	for k := range setA {
+		if setB[k] {
+			shared++
+		}
+	}
+	union := len(setA) + len(setB) - shared
+	if union == 0 {
+		return 0
+	}
+	return float64(shared) / float64(union)
+}
@@ -0,0 +1,84 @@
+package playmate
+
+import (
+	"reflect"
+	"testing"
+)
+
+func newInterestGraphPlaymate(t *testing.T) (*Playmate, map[string]string) {
+	t.Helper()
+	p, _ := newTestPlaymate(t, nil)
+	ids := make(map[string]string)
+	for _, in := range []struct {
+		topic    string
+		keywords []string
+	}{
+		{"tides", []string{"moon", "sea", "waves"}},
+		{"surfing", []string{"Sea", "waves", "board"}}, // Shares two of four keywords with tides
+		{"astronomy", []string{"moon", "stars"}},       // Shares one of four, exactly the threshold
+		{"sailing", []string{"sea", "wind", "boats"}},  // Shares one of five, below it
+		{"bread", []string{"flour"}},
+	} {
+		ids[in.topic] = p.LearnInterest(InterestExploration, in.topic, in.keywords).ID
+	}
+	return p, ids
+}
+
+func TestInterestGraphLinksSharedKeywords(t *testing.T) {
+	p, ids := newInterestGraphPlaymate(t)
+
+	want := map[string][]string{
+		ids["tides"]:     {ids["astronomy"], ids["surfing"]},
+		ids["surfing"]:   {ids["tides"]},
+		ids["astronomy"]: {ids["tides"]},
+		ids["sailing"]:   {},
+		ids["bread"]:     {},
+	}
+	if got := p.InterestGraph(); !reflect.DeepEqual(got, want) {
+		t.Errorf("InterestGraph = %v, want %v", got, want)
+	}
+}
+
+func TestRelatedInterestsOrderedByOverlap(t *testing.T) {
+	p, ids := newInterestGraphPlaymate(t)
+
+	related := p.RelatedInterests(ids["tides"])
+	got := make([]string, len(related))
+	for i, in := range related {
+		got[i] = in.Topic
+	}
+	if want := []string{"surfing", "astronomy"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("related to tides = %v, want %v", got, want)
+	}
+
+	// Results are copies
+	related[0].Keywords[0] = "changed"
+	if again := p.RelatedInterests(ids["tides"]); again[0].Keywords[0] != "Sea" {
+		t.Errorf("keywords = %v, want the stored interest unchanged", again[0].Keywords)
+	}
+
+	if got := p.RelatedInterests(ids["bread"]); len(got) != 0 {
+		t.Errorf("related to bread = %v, want none", got)
+	}
+	if got := p.RelatedInterests("missing"); got == nil || len(got) != 0 {
+		t.Errorf("related to an unknown interest = %v, want an empty list", got)
+	}
+}
+
+func TestKeywordOverlap(t *testing.T) {
+	tests := []struct {
+		a, b []string
+		want float64
+	}{
+		{[]string{"moon", "sea"}, []string{"MOON", "sea"}, 1},
+		{[]string{"moon"}, []string{"sea"}, 0},
+		{[]string{"moon", "moon", "sea"}, []string{"moon"}, 0.5},
+		{nil, nil, 0},
+	}
+	for _, tt := range tests {
+		got := keywordOverlap(&Interest{Keywords: tt.a}, &Interest{Keywords: tt.b})
+		if got != tt.want {
+			t.Errorf("keywordOverlap(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package playmate
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// journalThoughts is how many of the latest thoughts a journal entry notes
+const journalThoughts = 5
+
+// JournalEntry is a reflective summary of the playmate's recent activity
+type JournalEntry struct {
+	ID          string        `json:"id"`
+	Timestamp   time.Time     `json:"timestamp"`
+	State       PlaymateState `json:"state"`
+	Mood        float64       `json:"mood"`
+	Thoughts    []string      `json:"thoughts"`    // Latest thoughts
+	Discussions []string      `json:"discussions"` // Topics of discussions started in the period
+	Insights    []string      `json:"insights"`    // Insights from discussions ended in the period
+	Wonders     []string      `json:"wonders"`     // Descriptions of wonders recorded in the period
+	Summary     string        `json:"summary"`
+}
+
+// WriteJournalEntry summarizes the last day's thoughts, discussions, insights,
+// and wonders into a journal entry, appends it to the journal, and returns it.
+// It is meant to be called while the playmate is reflecting.
+func (p *Playmate) WriteJournalEntry() JournalEntry {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.clock.Now()
+	since := now.Add(-24 * time.Hour)
+
+	entry := JournalEntry{
+		ID:          p.ids.NewID("journal"),
+		Timestamp:   now,
+		State:       p.State,
+		Mood:        p.Mood,
+		Thoughts:    append([]string(nil), p.getRecentThoughts(journalThoughts)...),
+		Discussions: make([]string, 0),
+		Insights:    make([]string, 0),
+		Wonders:     make([]string, 0),
+	}
+
+	discussions := make([]*Discussion, 0, len(p.Discussions))
+	for _, d := range p.Discussions {
+		discussions = append(discussions, d)
+	}
+	sort.Slice(discussions, func(i, j int) bool {
+		if !discussions[i].StartedAt.Equal(discussions[j].StartedAt) {
+			return discussions[i].StartedAt.Before(discussions[j].StartedAt)
+		}
+		return discussions[i].ID < discussions[j].ID
+	})
+
+	for _, d := range discussions {
+		if !d.StartedAt.Before(since) {
+			entry.Discussions = append(entry.Discussions, d.Topic)
+		}
+		if d.EndedAt != nil && !d.EndedAt.Before(since) {
+			entry.Insights = append(entry.Insights, d.Insights...)
+		}
+	}
+
+	for _, w := range p.Wonders {
+		if !w.Timestamp.Before(since) {
+			entry.Wonders = append(entry.Wonders, w.Description)
+		}
+	}
+
+	entry.Summary = fmt.Sprintf("Today I took part in %d discussions, gained %d insights, and felt wonder %d times. My mood is %s.",
+		len(entry.Discussions), len(entry.Insights), len(entry.Wonders), describeMood(p.Mood))
+
+	p.Journal = append(p.Journal, entry)
+	p.dirty = true
+	return entry.clone()
+}
+
+// GetJournal returns a copy of the journal, oldest entry first
+func (p *Playmate) GetJournal() []JournalEntry {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	journal := make([]JournalEntry, len(p.Journal))
+	for i, entry := range p.Journal {
+		journal[i] = entry.clone()
+	}
+	return journal
+}
+
+// describeMood puts a mood value into words
+func describeMood(mood float64) string {
+	switch {
+	case mood >= 0.5:
+		return "bright"
+	case mood >= 0.1:
+		return "content"
+	case mood > -0.1:
+		return "calm"
+	case mood > -0.5:
+		return "subdued"
+	default:
+		return "low"
+	}
+}
+
+func (e JournalEntry) clone() JournalEntry {
+	c := e
+	c.Thoughts = append([]string(nil), e.Thoughts...)
+	c.Discussions = append([]string(nil), e.Discussions...)
+	c.Insights = append([]string(nil), e.Insights...)
+	c.Wonders = append([]string(nil), e.Wonders...)
+	return c
+}
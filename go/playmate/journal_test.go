@@ -0,0 +1,105 @@
+package playmate
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/o9nn/un9n/go/persist"
+)
+
+// deepDiscussion starts and ends a discussion deep enough to yield an insight
+func deepDiscussion(t *testing.T, p *Playmate, topic string) {
+	t.Helper()
+	d := mustStartDiscussion(t, p, topic, "ana")
+	p.mu.Lock()
+	p.Discussions[d.ID].Depth = 10
+	p.mu.Unlock()
+	if err := p.EndDiscussion(d.ID); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWriteJournalEntryCapturesLastDay(t *testing.T) {
+	p, clock := newTestPlaymate(t, nil)
+
+	// Yesterday's activity falls outside the entry
+	deepDiscussion(t, p, "bread")
+	p.RecordWonder("dough rises overnight", "bread", 0.5)
+	p.mu.Lock()
+	err := p.transition(StateAwake)
+	p.mu.Unlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clock.Advance(25 * time.Hour)
+	deepDiscussion(t, p, "tides")
+	p.RecordWonder("the moon pulls the sea", "tides", 0.8)
+	p.mu.Lock()
+	p.StreamOfThoughts = []string{"t1", "t2", "t3", "t4", "t5", "t6", "t7"}
+	p.mu.Unlock()
+
+	entry := p.WriteJournalEntry()
+	if !entry.Timestamp.Equal(clock.Now()) || entry.State != StateReflecting {
+		t.Errorf("entry at %v in %s, want %v while reflecting", entry.Timestamp, entry.State, clock.Now())
+	}
+	if want := []string{"t3", "t4", "t5", "t6", "t7"}; !reflect.DeepEqual(entry.Thoughts, want) {
+		t.Errorf("thoughts = %v, want the latest %v", entry.Thoughts, want)
+	}
+	if want := []string{"tides"}; !reflect.DeepEqual(entry.Discussions, want) {
+		t.Errorf("discussions = %v, want %v", entry.Discussions, want)
+	}
+	if len(entry.Insights) != 1 || !strings.Contains(entry.Insights[0], "tides") {
+		t.Errorf("insights = %v, want the one from the tides discussion", entry.Insights)
+	}
+	if want := []string{"the moon pulls the sea"}; !reflect.DeepEqual(entry.Wonders, want) {
+		t.Errorf("wonders = %v, want %v", entry.Wonders, want)
+	}
+	if !strings.Contains(entry.Summary, "1 discussions") || !strings.Contains(entry.Summary, "1 insights") {
+		t.Errorf("summary = %q, want it to count the day's activity", entry.Summary)
+	}
+
+	// The returned entry is a copy
+	entry.Wonders[0] = "changed"
+	journal := p.GetJournal()
+	if len(journal) != 1 || journal[0].Wonders[0] != "the moon pulls the sea" {
+		t.Errorf("journal = %+v, want the entry unaffected by changes to the copy", journal)
+	}
+}
+
+func TestJournalPersists(t *testing.T) {
+	for _, format := range []persist.Format{persist.FormatJSON, persist.FormatGob} {
+		t.Run(string(format), func(t *testing.T) {
+			store := &memoryStore{}
+			configure := func(c *PlaymateConfig) {
+				c.PersistPath = "playmate"
+				c.Store = store
+				c.Format = format
+			}
+			p, clock := newTestPlaymate(t, configure)
+			deepDiscussion(t, p, "tides")
+			p.RecordWonder("the moon pulls the sea", "tides", 0.8)
+			first := p.WriteJournalEntry()
+			clock.Advance(time.Hour)
+			second := p.WriteJournalEntry()
+			if err := p.Save(); err != nil {
+				t.Fatal(err)
+			}
+
+			loaded, _ := newTestPlaymate(t, configure)
+			journal := loaded.GetJournal()
+			if len(journal) != 2 {
+				t.Fatalf("loaded %d journal entries, want 2", len(journal))
+			}
+			for i, want := range []JournalEntry{first, second} {
+				got := journal[i]
+				if got.ID != want.ID || !got.Timestamp.Equal(want.Timestamp) || got.Summary != want.Summary ||
+					!reflect.DeepEqual(got.Wonders, want.Wonders) || !reflect.DeepEqual(got.Insights, want.Insights) {
+					t.Errorf("entry %d = %+v, want %+v", i, got, want)
+				}
+			}
+		})
+	}
+}
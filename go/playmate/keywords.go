@@ -0,0 +1,68 @@
+package playmate
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// stopwords are common words that carry no topical meaning
+var stopwords = map[string]bool{
+	"a": true, "about": true, "after": true, "all": true, "also": true, "am": true,
+	"an": true, "and": true, "any": true, "are": true, "as": true, "at": true,
+	"be": true, "because": true, "been": true, "but": true, "by": true, "can": true,
+	"could": true, "did": true, "do": true, "does": true, "for": true, "from": true,
+	"had": true, "has": true, "have": true, "he": true, "her": true, "him": true,
+	"his": true, "how": true, "i": true, "if": true, "in": true, "into": true,
+	"is": true, "it": true, "its": true, "just": true, "like": true, "me": true,
+	"more": true, "my": true, "no": true, "not": true, "of": true, "on": true,
+	"or": true, "our": true, "out": true, "she": true, "so": true, "some": true,
+	"than": true, "that": true, "the": true, "their": true, "them": true, "then": true,
+	"there": true, "these": true, "they": true, "this": true, "to": true, "too": true,
+	"up": true, "us": true, "very": true, "was": true, "we": true, "were": true,
+	"what": true, "when": true, "where": true, "which": true, "who": true, "why": true,
+	"will": true, "with": true, "would": true, "you": true, "your": true,
+}
+
+// tokenize lowercases text and splits it into words
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '\''
+	})
+}
+
+// extractKeywords returns up to n salient words from texts, most frequent first.
+// Stopwords and very short words are ignored; ties are broken alphabetically.
+func extractKeywords(texts []string, n int) []string {
+	counts := keywordCounts(texts)
+
+	keywords := make([]string, 0, len(counts))
+	for w := range counts {
+		keywords = append(keywords, w)
+	}
+	sort.Slice(keywords, func(i, j int) bool {
+		if counts[keywords[i]] != counts[keywords[j]] {
+			return counts[keywords[i]] > counts[keywords[j]]
+		}
+		return keywords[i] < keywords[j]
+	})
+
+	if n >= 0 && n < len(keywords) {
+		keywords = keywords[:n]
+	}
+	return keywords
+}
+
+// keywordCounts counts the salient words in texts, ignoring stopwords and very short words
+func keywordCounts(texts []string) map[string]int {
+	counts := make(map[string]int)
+	for _, text := range texts {
+		for _, w := range tokenize(text) {
+			if len(w) < 3 || stopwords[w] {
+				continue
+			}
+			counts[w]++
+		}
+	}
+	return counts
+}
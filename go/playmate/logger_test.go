@@ -0,0 +1,91 @@
+package playmate
+
+import (
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+// logEntry is one message captured by recordingLogger
+type logEntry struct {
+	level   string
+	msg     string
+	keyvals []interface{}
+}
+
+// value returns the value logged under key, if any
+func (e logEntry) value(key string) (interface{}, bool) {
+	for i := 0; i+1 < len(e.keyvals); i += 2 {
+		if e.keyvals[i] == key {
+			return e.keyvals[i+1], true
+		}
+	}
+	return nil, false
+}
+
+// recordingLogger captures every message for inspection
+type recordingLogger struct {
+	mu      sync.Mutex
+	entries []logEntry
+}
+
+func (l *recordingLogger) record(level, msg string, keyvals []interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = append(l.entries, logEntry{level: level, msg: msg, keyvals: keyvals})
+}
+
+func (l *recordingLogger) Debug(msg string, keyvals ...interface{}) { l.record("debug", msg, keyvals) }
+func (l *recordingLogger) Info(msg string, keyvals ...interface{})  { l.record("info", msg, keyvals) }
+func (l *recordingLogger) Warn(msg string, keyvals ...interface{})  { l.record("warn", msg, keyvals) }
+func (l *recordingLogger) Error(msg string, keyvals ...interface{}) { l.record("error", msg, keyvals) }
+
+// find returns the entries with the given message
+func (l *recordingLogger) find(msg string) []logEntry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var found []logEntry
+	for _, e := range l.entries {
+		if e.msg == msg {
+			found = append(found, e)
+		}
+	}
+	return found
+}
+
+func TestStateTransitionsAreLogged(t *testing.T) {
+	logger := &recordingLogger{}
+	p, _ := newTestPlaymate(t, func(c *PlaymateConfig) { c.Logger = logger })
+
+	d := mustStartDiscussion(t, p, "rivers", "ana")
+	if err := p.EndDiscussion(d.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	transitions := logger.find("state transition")
+	if len(transitions) != 2 {
+		t.Fatalf("got %d transition logs, want 2", len(transitions))
+	}
+	from, _ := transitions[1].value("from")
+	to, _ := transitions[1].value("to")
+	if from != StateEngaged || to != StateReflecting {
+		t.Errorf("second transition logged %v -> %v, want engaged -> reflecting", from, to)
+	}
+}
+
+func TestWisdomSaveIsLogged(t *testing.T) {
+	logger := &recordingLogger{}
+	path := filepath.Join(t.TempDir(), "wisdom.json")
+	wc, _ := newTestCultivator(t, &WisdomConfig{PersistPath: path, Logger: logger})
+
+	if err := wc.Save(); err != nil {
+		t.Fatal(err)
+	}
+	saves := logger.find("saved wisdom state")
+	if len(saves) != 1 {
+		t.Fatalf("got %d save logs, want 1", len(saves))
+	}
+	if got, _ := saves[0].value("path"); got != path {
+		t.Errorf("save logged path %v, want %s", got, path)
+	}
+}
@@ -0,0 +1,96 @@
+package playmate
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// NeglectedItem identifies the interest or skill that has gone longest without attention
+type NeglectedItem struct {
+	Kind  string    `json:"kind"` // "interest" or "skill"
+	ID    string    `json:"id"`
+	Name  string    `json:"name"`
+	Since time.Time `json:"since"`
+}
+
+// maintenanceLoop periodically revisits neglected interests and skills
+func (p *Playmate) maintenanceLoop(ctx context.Context) {
+	ticker := time.NewTicker(p.Config.MaintenanceInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-p.stopChan:
+			return
+		case <-ticker.C:
+			if p.IsPaused() {
+				continue
+			}
+			p.RunMaintenance()
+		}
+	}
+}
+
+// RunMaintenance selects the most neglected interest or skill and nudges
+// re-engagement with it. A revisited interest counts as engaged, so the next
+// run moves on to something else. Skills are practiced directly when
+// AutoPractice is enabled. Interest strengths are then normalized (see
+// InterestNormalization).
+// It returns the selected item, or nil when there is nothing to revisit or
+// quiet mode is on.
+func (p *Playmate) RunMaintenance() *NeglectedItem {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.quiet {
+		return nil
+	}
+	defer p.normalizeInterests()
+
+	item := p.mostNeglected()
+	if item == nil {
+		return nil
+	}
+
+	switch item.Kind {
+	case "interest":
+		p.Interests[item.ID].LastEngaged = p.clock.Now()
+		p.dirty = true
+		p.appendThought(fmt.Sprintf("It's been a while since I explored %s... I'd like to return to it.", item.Name))
+	case "skill":
+		if p.Config.AutoPractice {
+			skill := p.Skills[item.ID]
+			p.practiceSkill(skill.Name, skill.Description)
+			p.appendThought(fmt.Sprintf("I took some time to practice %s again.", item.Name))
+		} else {
+			p.appendThought(fmt.Sprintf("I haven't practiced %s lately... I should make time for it.", item.Name))
+		}
+	}
+
+	p.logger.Debug("maintenance selected neglected item", "kind", item.Kind, "id", item.ID)
+	return item
+}
+
+// mostNeglected returns the interest or skill with the oldest engagement (must hold lock).
+// Ties are broken by ID so the selection is deterministic.
+func (p *Playmate) mostNeglected() *NeglectedItem {
+	var oldest *NeglectedItem
+
+	consider := func(kind, id, name string, since time.Time) {
+		if oldest == nil || since.Before(oldest.Since) || (since.Equal(oldest.Since) && id < oldest.ID) {
+			oldest = &NeglectedItem{Kind: kind, ID: id, Name: name, Since: since}
+		}
+	}
+
+	for id, interest := range p.Interests {
+		consider("interest", id, interest.Topic, interest.LastEngaged)
+	}
+	for id, skill := range p.Skills {
+		consider("skill", id, skill.Name, skill.LastPracticed)
+	}
+
+	return oldest
+}
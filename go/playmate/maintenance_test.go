@@ -0,0 +1,101 @@
+package playmate
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRunMaintenanceSelectsMostNeglected(t *testing.T) {
+	p, clock := newTestPlaymate(t, nil)
+
+	tides := p.LearnInterest(InterestExploration, "tides", nil)
+	clock.Advance(time.Hour)
+	p.PracticeSkill("juggling", "keeping three balls up")
+	clock.Advance(time.Hour)
+	p.LearnInterest(InterestKnowledge, "stars", nil)
+
+	item := p.RunMaintenance()
+	if item == nil || item.Kind != "interest" || item.ID != tides.ID {
+		t.Fatalf("RunMaintenance() = %+v, want the tides interest", item)
+	}
+
+	p.mu.RLock()
+	last := p.StreamOfThoughts[len(p.StreamOfThoughts)-1]
+	p.mu.RUnlock()
+	if !strings.Contains(last, "tides") {
+		t.Errorf("last thought %q does not mention tides", last)
+	}
+}
+
+func TestRunMaintenanceAutoPracticesSkill(t *testing.T) {
+	p, clock := newTestPlaymate(t, func(c *PlaymateConfig) { c.AutoPractice = true })
+
+	skill := p.PracticeSkill("juggling", "keeping three balls up")
+	clock.Advance(time.Hour)
+	p.LearnInterest(InterestExploration, "tides", nil)
+
+	item := p.RunMaintenance()
+	if item == nil || item.Kind != "skill" || item.ID != skill.ID {
+		t.Fatalf("RunMaintenance() = %+v, want the juggling skill", item)
+	}
+
+	p.mu.RLock()
+	practiced := p.Skills[skill.ID]
+	count, last := practiced.PracticeCount, practiced.LastPracticed
+	p.mu.RUnlock()
+	if count != skill.PracticeCount+1 {
+		t.Errorf("practice count = %d, want %d", count, skill.PracticeCount+1)
+	}
+	if !last.Equal(clock.Now()) {
+		t.Errorf("last practiced = %v, want %v", last, clock.Now())
+	}
+}
+
+func TestRunMaintenanceBreaksTiesByID(t *testing.T) {
+	p, _ := newTestPlaymate(t, nil)
+
+	// With the clock fixed every item is equally neglected
+	a := p.LearnInterest(InterestExploration, "tides", nil)
+	b := p.LearnInterest(InterestKnowledge, "stars", nil)
+	want := a.ID
+	if b.ID < want {
+		want = b.ID
+	}
+
+	for i := 0; i < 5; i++ {
+		if item := p.RunMaintenance(); item == nil || item.ID != want {
+			t.Fatalf("run %d selected %+v, want %s", i, item, want)
+		}
+	}
+}
+
+func TestRunMaintenanceWithNothingToRevisit(t *testing.T) {
+	p, _ := newTestPlaymate(t, nil)
+	if item := p.RunMaintenance(); item != nil {
+		t.Errorf("RunMaintenance() = %+v, want nil", item)
+	}
+}
+
+func TestRunMaintenanceMarksInterestEngaged(t *testing.T) {
+	p, clock := newTestPlaymate(t, nil)
+
+	tides := p.LearnInterest(InterestExploration, "tides", nil)
+	clock.Advance(time.Hour)
+	stars := p.LearnInterest(InterestKnowledge, "stars", nil)
+	clock.Advance(time.Hour)
+
+	if item := p.RunMaintenance(); item == nil || item.ID != tides.ID {
+		t.Fatalf("first run selected %+v, want tides", item)
+	}
+	if item := p.RunMaintenance(); item == nil || item.ID != stars.ID {
+		t.Fatalf("second run selected %+v, want stars once tides was revisited", item)
+	}
+
+	p.mu.RLock()
+	engaged := p.Interests[tides.ID].LastEngaged
+	p.mu.RUnlock()
+	if !engaged.Equal(clock.Now()) {
+		t.Errorf("tides last engaged = %v, want %v", engaged, clock.Now())
+	}
+}
@@ -0,0 +1,144 @@
+package playmate
+
+import (
+	"errors"
+	"sort"
+)
+
+// ErrSelfMerge is returned when merging a playmate into itself
+var ErrSelfMerge = errors.New("cannot merge a playmate into itself")
+
+// Merge folds another playmate's learned state into this one, e.g. to reconcile
+// playmates that ran on separate devices. The other playmate is not modified.
+// Conflicts are resolved as follows:
+//   - Interests and skills are matched by ID. Engage and practice counts are
+//     summed, strength, curiosity, engagement, and proficiency take the higher
+//     value, keywords, insights, and milestones are unioned, and the latest
+//     engagement or practice time wins.
+//   - Discussions are matched by ID. When both sides have a discussion, the one
+//     with more messages wins, since it progressed further; ties keep ours.
+//   - Wonders are deduplicated by ID and kept in chronological order.
+//   - Playmates numbering their IDs independently may give different
+//     discussions or wonders the same ID. An entry whose ID matches but whose
+//     start time or timestamp differs is added under a new ID instead.
+//   - Mood and curiosity are averaged; everything else keeps this playmate's value.
+//
+// Discussion and wonder totals grow by the number of newly added entries.
+func (p *Playmate) Merge(other *Playmate) error {
+	if other == p {
+		return ErrSelfMerge
+	}
+
+	// Copy the other side first so the two locks are never held together
+	other.mu.RLock()
+	interests := make([]*Interest, 0, len(other.Interests))
+	for _, interest := range other.Interests {
+		interests = append(interests, interest.clone())
+	}
+	skills := make([]*Skill, 0, len(other.Skills))
+	for _, skill := range other.Skills {
+		skills = append(skills, skill.clone())
+	}
+	discussions := make([]*Discussion, 0, len(other.Discussions))
+	for _, discussion := range other.Discussions {
+		discussions = append(discussions, discussion.clone())
+	}
+	wonders := make([]*WonderEvent, 0, len(other.Wonders))
+	for _, wonder := range other.Wonders {
+		w := *wonder
+		wonders = append(wonders, &w)
+	}
+	name, mood, curiosity := other.Name, other.Mood, other.Curiosity
+	other.mu.RUnlock()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// IDs issued from here on must not collide with either side's
+	for _, discussion := range discussions {
+		reserveIDs(p.ids, discussion.ID)
+		for _, msg := range discussion.Messages {
+			reserveIDs(p.ids, msg.ID)
+		}
+	}
+	for _, wonder := range wonders {
+		reserveIDs(p.ids, wonder.ID)
+	}
+
+	for _, interest := range interests {
+		existing, ok := p.Interests[interest.ID]
+		if !ok {
+			p.Interests[interest.ID] = interest
+			continue
+		}
+		existing.EngageCount += interest.EngageCount
+		existing.Strength = max(existing.Strength, interest.Strength)
+		existing.Curiosity = max(existing.Curiosity, interest.Curiosity)
+		existing.Engagement = max(existing.Engagement, interest.Engagement)
+		existing.Keywords = mergeKeywords(existing.Keywords, interest.Keywords)
+		existing.Insights = mergeKeywords(existing.Insights, interest.Insights)
+		if interest.LastEngaged.After(existing.LastEngaged) {
+			existing.LastEngaged = interest.LastEngaged
+		}
+	}
+
+	for _, skill := range skills {
+		existing, ok := p.Skills[skill.ID]
+		if !ok {
+			p.Skills[skill.ID] = skill
+			continue
+		}
+		existing.PracticeCount += skill.PracticeCount
+		existing.Proficiency = max(existing.Proficiency, skill.Proficiency)
+		existing.Milestones = mergeKeywords(existing.Milestones, skill.Milestones)
+		if skill.LastPracticed.After(existing.LastPracticed) {
+			existing.LastPracticed = skill.LastPracticed
+		}
+	}
+
+	for _, discussion := range discussions {
+		existing, ok := p.Discussions[discussion.ID]
+		if ok && !existing.StartedAt.Equal(discussion.StartedAt) {
+			discussion.ID = p.ids.NewID("disc")
+			ok = false
+		}
+		if !ok {
+			p.Discussions[discussion.ID] = discussion
+			p.TotalDiscussions++
+			continue
+		}
+		if len(discussion.Messages) > len(existing.Messages) {
+			p.Discussions[discussion.ID] = discussion
+		}
+	}
+
+	seen := make(map[string]*WonderEvent, len(p.Wonders))
+	for _, wonder := range p.Wonders {
+		seen[wonder.ID] = wonder
+	}
+	added := 0
+	for _, wonder := range wonders {
+		if existing, ok := seen[wonder.ID]; ok {
+			if existing.Timestamp.Equal(wonder.Timestamp) {
+				continue
+			}
+			wonder.ID = p.ids.NewID("wonder")
+		}
+		seen[wonder.ID] = wonder
+		p.Wonders = append(p.Wonders, wonder)
+		added++
+	}
+	if added > 0 {
+		sort.SliceStable(p.Wonders, func(i, j int) bool {
+			return p.Wonders[i].Timestamp.Before(p.Wonders[j].Timestamp)
+		})
+		p.TotalWonders += added
+	}
+
+	p.Mood = clamp((p.Mood+mood)/2, -1.0, 1.0)
+	p.Curiosity = clamp((p.Curiosity+curiosity)/2, 0.0, 1.0)
+
+	p.dirty = true
+	p.logger.Info("merged playmate state", "from", name, "interests", len(interests), "skills", len(skills))
+	return nil
+}
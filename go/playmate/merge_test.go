@@ -0,0 +1,148 @@
+package playmate
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestMergeUnionsLearnedState(t *testing.T) {
+	a, _ := newTestPlaymate(t, func(c *PlaymateConfig) { c.CuriosityLevel = 0.9 })
+	b, clockB := newTestPlaymate(t, func(c *PlaymateConfig) { c.CuriosityLevel = 0.3 })
+
+	// a: tides learned twice, one practice, a short discussion, two wonders
+	a.LearnInterest(InterestExploration, "tides", []string{"moon"})
+	tides := a.LearnInterest(InterestExploration, "tides", []string{"sea"})
+	a.PracticeSkill("juggling", "three balls")
+	shared := mustStartDiscussion(t, a, "rivers", "ana")
+	if err := a.AddMessage(shared.ID, "ana", "ok"); err != nil {
+		t.Fatal(err)
+	}
+	a.RecordWonder("rain on the river", "rivers", 0.5)
+	clash := a.RecordWonder("fog at dawn", "rivers", 0.4)
+
+	// b: the same discussion progressed further, more practice, a new interest,
+	// and a later wonder that happens to reuse one of a's wonder IDs
+	b.LearnInterest(InterestExploration, "tides", []string{"stars"})
+	bread := b.LearnInterest(InterestCreativity, "bread", []string{"flour"})
+	for i := 0; i < 3; i++ {
+		b.PracticeSkill("juggling", "three balls")
+	}
+	longer := mustStartDiscussion(t, b, "rivers", "ana")
+	for _, content := range []string{"ok", "fine"} {
+		if err := b.AddMessage(longer.ID, "ana", content); err != nil {
+			t.Fatal(err)
+		}
+	}
+	clockB.Advance(time.Hour)
+	late := b.RecordWonder("stars over the river", "rivers", 0.9)
+	if late.ID != clash.ID {
+		t.Fatalf("setup: wonder IDs %s and %s, want a clash", late.ID, clash.ID)
+	}
+
+	a.mu.RLock()
+	moodA, curiosityA := a.Mood, a.Curiosity
+	a.mu.RUnlock()
+	b.mu.RLock()
+	moodB, curiosityB := b.Mood, b.Curiosity
+	b.mu.RUnlock()
+
+	if err := a.Merge(b); err != nil {
+		t.Fatal(err)
+	}
+
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	gotTides := a.Interests[tides.ID]
+	if gotTides.EngageCount != 3 || gotTides.Strength != tides.Strength {
+		t.Errorf("tides engage count %d strength %v, want 3 and the higher %v", gotTides.EngageCount, gotTides.Strength, tides.Strength)
+	}
+	keywords := append([]string(nil), gotTides.Keywords...)
+	sort.Strings(keywords)
+	if want := []string{"moon", "sea", "stars"}; !reflect.DeepEqual(keywords, want) {
+		t.Errorf("tides keywords = %v, want %v", keywords, want)
+	}
+	if a.Interests[bread.ID] == nil {
+		t.Error("interest only b had was not added")
+	}
+
+	juggling := a.Skills["skill_juggling"]
+	if juggling.PracticeCount != 4 || math.Abs(juggling.Proficiency-0.2) > 1e-9 {
+		t.Errorf("juggling practiced %d times at %v, want 4 at the higher 0.2", juggling.PracticeCount, juggling.Proficiency)
+	}
+
+	if len(a.Discussions) != 1 || len(a.Discussions[shared.ID].Messages) != 2 {
+		t.Errorf("discussions = %d, shared one has %d messages; want 1 with b's 2 messages",
+			len(a.Discussions), len(a.Discussions[shared.ID].Messages))
+	}
+	if a.TotalDiscussions != 1 {
+		t.Errorf("TotalDiscussions = %d, want 1 since the discussion was already known", a.TotalDiscussions)
+	}
+
+	descriptions := make([]string, len(a.Wonders))
+	ids := make(map[string]bool)
+	for i, w := range a.Wonders {
+		descriptions[i] = w.Description
+		ids[w.ID] = true
+	}
+	if want := []string{"rain on the river", "fog at dawn", "stars over the river"}; !reflect.DeepEqual(descriptions, want) {
+		t.Errorf("wonders = %v, want %v in time order", descriptions, want)
+	}
+	if len(ids) != 3 {
+		t.Errorf("wonder IDs %v are not unique", ids)
+	}
+	if a.TotalWonders != 3 {
+		t.Errorf("TotalWonders = %d, want 3", a.TotalWonders)
+	}
+
+	if want := (moodA + moodB) / 2; math.Abs(a.Mood-want) > 1e-9 {
+		t.Errorf("mood = %v, want the average %v", a.Mood, want)
+	}
+	if want := (curiosityA + curiosityB) / 2; math.Abs(a.Curiosity-want) > 1e-9 {
+		t.Errorf("curiosity = %v, want the average %v", a.Curiosity, want)
+	}
+}
+
+func TestMergeLeavesOtherUntouchedAndIsStableForEntries(t *testing.T) {
+	a, _ := newTestPlaymate(t, nil)
+	b, clockB := newTestPlaymate(t, nil)
+	clockB.Advance(time.Hour)
+	d := mustStartDiscussion(t, b, "tides", "ana")
+	b.RecordWonder("the moon pulls the sea", "tides", 0.8)
+
+	for i := 0; i < 2; i++ {
+		if err := a.Merge(b); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	a.mu.RLock()
+	discussions, wonders := len(a.Discussions), len(a.Wonders)
+	a.mu.RUnlock()
+	if discussions != 1 || wonders != 1 {
+		t.Errorf("after merging twice: %d discussions and %d wonders, want 1 of each", discussions, wonders)
+	}
+
+	// New IDs issued by a don't collide with merged ones
+	next := mustStartDiscussion(t, a, "stars", "ben")
+	if next.ID == d.ID {
+		t.Errorf("new discussion reused merged ID %s", d.ID)
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if len(b.Discussions) != 1 || len(b.Wonders) != 1 {
+		t.Error("merge modified the other playmate")
+	}
+}
+
+func TestMergeIntoSelf(t *testing.T) {
+	p, _ := newTestPlaymate(t, nil)
+	if err := p.Merge(p); !errors.Is(err, ErrSelfMerge) {
+		t.Errorf("Merge(self) = %v, want %v", err, ErrSelfMerge)
+	}
+}
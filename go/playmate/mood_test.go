@@ -0,0 +1,101 @@
+package playmate
+
+import "testing"
+
+func TestMessageSentimentMovesMood(t *testing.T) {
+	p, _ := newTestPlaymate(t, nil)
+	d := mustStartDiscussion(t, p, "weekend", "sam")
+
+	before := p.Mood
+	if err := p.AddMessage(d.ID, "sam", "What a wonderful, amazing day, I love it"); err != nil {
+		t.Fatal(err)
+	}
+	if p.Mood <= before {
+		t.Errorf("positive message: mood %.3f, want above %.3f", p.Mood, before)
+	}
+
+	before = p.Mood
+	if err := p.AddMessage(d.ID, "sam", "I feel sad and lonely and frustrated"); err != nil {
+		t.Fatal(err)
+	}
+	if p.Mood >= before {
+		t.Errorf("negative message: mood %.3f, want below %.3f", p.Mood, before)
+	}
+
+	before = p.Mood
+	if err := p.AddMessage(d.ID, "sam", "The train leaves at noon"); err != nil {
+		t.Fatal(err)
+	}
+	if p.Mood != before {
+		t.Errorf("neutral message: mood %.3f, want unchanged %.3f", p.Mood, before)
+	}
+}
+
+func TestIntenseWonderBoostsMood(t *testing.T) {
+	p, _ := newTestPlaymate(t, nil)
+
+	before := p.Mood
+	p.RecordWonder("A faint hum", "noise", 0.3)
+	if p.Mood != before {
+		t.Errorf("mild wonder changed mood from %.3f to %.3f", before, p.Mood)
+	}
+
+	p.RecordWonder("The night sky", "stars", 0.9)
+	if p.Mood <= before {
+		t.Errorf("intense wonder: mood %.3f, want above %.3f", p.Mood, before)
+	}
+}
+
+func TestMoodDecaysTowardBaseline(t *testing.T) {
+	p, _ := newTestPlaymate(t, func(c *PlaymateConfig) {
+		c.MoodBaseline = 0.2
+		c.MoodDecayRate = 0.25
+	})
+
+	p.RecordWonder("A total eclipse", "sky", 1.0)
+	distance := p.Mood - 0.2
+	if distance <= 0 {
+		t.Fatalf("mood %.3f not above baseline after wonder", p.Mood)
+	}
+	for i := 0; i < 20; i++ {
+		p.moodDecay()
+		next := p.Mood - 0.2
+		if next < 0 || next >= distance {
+			t.Fatalf("tick %d: distance to baseline %.4f, want in [0, %.4f)", i, next, distance)
+		}
+		distance = next
+	}
+	if distance > 0.01 {
+		t.Errorf("mood %.3f still far from baseline after decay", p.Mood)
+	}
+}
+
+func TestMoodHistoryRecordsCauses(t *testing.T) {
+	p, _ := newTestPlaymate(t, nil)
+	d := mustStartDiscussion(t, p, "music", "kim")
+	if err := p.AddMessage(d.ID, "kim", "this is great fun"); err != nil {
+		t.Fatal(err)
+	}
+	p.RecordWonder("A new chord", "music", 0.8)
+	p.moodDecay()
+
+	history := p.GetMoodHistory()
+	var causes []string
+	for _, sample := range history {
+		causes = append(causes, sample.Cause)
+	}
+	want := []string{"message", "wonder", "decay"}
+	if len(causes) != len(want) {
+		t.Fatalf("causes = %v, want %v", causes, want)
+	}
+	for i := range want {
+		if causes[i] != want[i] {
+			t.Fatalf("causes = %v, want %v", causes, want)
+		}
+	}
+
+	history[0].Mood = -1
+	if p.GetMoodHistory()[0].Mood == -1 {
+		t.Error("GetMoodHistory returned internal storage")
+	}
+}
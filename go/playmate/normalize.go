@@ -0,0 +1,73 @@
+package playmate
+
+import "sort"
+
+// InterestNormalization selects how interest strengths are rescaled so that
+// heavily engaged interests stay distinguishable instead of all reaching 1.0
+type InterestNormalization string
+
+const (
+	// NormalizeNone leaves strengths to saturate at 1.0
+	NormalizeNone InterestNormalization = ""
+	// NormalizeRank spreads strengths evenly by rank, from 1.0 for the most
+	// engaged interest down to minNormalizedStrength for the least
+	NormalizeRank InterestNormalization = "rank"
+)
+
+// minNormalizedStrength is the strength rank normalization gives the weakest interest
+const minNormalizedStrength = 0.1
+
+// valid reports whether the normalization is one of the known modes
+func (n InterestNormalization) valid() bool {
+	switch n {
+	case NormalizeNone, NormalizeRank:
+		return true
+	default:
+		return false
+	}
+}
+
+// NormalizeInterests rescales interest strengths using the configured
+// InterestNormalization. It runs on every maintenance pass, and may be called
+// directly to normalize sooner.
+func (p *Playmate) NormalizeInterests() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.normalizeInterests()
+}
+
+// normalizeInterests ranks interests by engagement count, then strength, and
+// assigns evenly spaced strengths by rank; interests tied on both share a
+// strength (must hold lock)
+func (p *Playmate) normalizeInterests() {
+	if p.Config.InterestNormalization != NormalizeRank || len(p.Interests) < 2 {
+		return
+	}
+
+	ranked := make([]*Interest, 0, len(p.Interests))
+	for _, interest := range p.Interests {
+		ranked = append(ranked, interest)
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].EngageCount != ranked[j].EngageCount {
+			return ranked[i].EngageCount > ranked[j].EngageCount
+		}
+		if ranked[i].Strength != ranked[j].Strength {
+			return ranked[i].Strength > ranked[j].Strength
+		}
+		return ranked[i].ID < ranked[j].ID
+	})
+
+	step := (1.0 - minNormalizedStrength) / float64(len(ranked)-1)
+	strengths := make([]float64, len(ranked))
+	for i, interest := range ranked {
+		strengths[i] = 1.0 - step*float64(i)
+		if i > 0 && interest.Strength == ranked[i-1].Strength && interest.EngageCount == ranked[i-1].EngageCount {
+			strengths[i] = strengths[i-1]
+		}
+	}
+	for i, interest := range ranked {
+		interest.Strength = strengths[i]
+	}
+	p.dirty = true
+}
@@ -0,0 +1,94 @@
+package playmate
+
+import (
+	"math"
+	"testing"
+)
+
+// engageUnequally learns each topic the given number of times
+func engageUnequally(p *Playmate, engagements map[string]int) {
+	for topic, n := range engagements {
+		for i := 0; i < n; i++ {
+			p.LearnInterest(InterestKnowledge, topic, nil)
+		}
+	}
+}
+
+func interestStrengths(p *Playmate) map[string]float64 {
+	strengths := make(map[string]float64)
+	for _, interest := range p.ListInterests() {
+		strengths[interest.Topic] = interest.Strength
+	}
+	return strengths
+}
+
+func checkStrengths(t *testing.T, got, want map[string]float64) {
+	t.Helper()
+	for topic, w := range want {
+		if math.Abs(got[topic]-w) > 1e-9 {
+			t.Errorf("%s strength = %v, want %v", topic, got[topic], w)
+		}
+	}
+}
+
+var unequalEngagement = map[string]int{"stars": 12, "tides": 8, "moss": 4, "clouds": 1}
+
+func TestNormalizeInterestsNoneSaturates(t *testing.T) {
+	p, _ := newTestPlaymate(t, nil)
+	engageUnequally(p, unequalEngagement)
+	p.NormalizeInterests()
+
+	got := interestStrengths(p)
+	if got["stars"] != 1.0 || got["tides"] != 1.0 {
+		t.Errorf("without normalization stars %v and tides %v should both saturate at 1.0", got["stars"], got["tides"])
+	}
+}
+
+func TestNormalizeInterestsRankKeepsSeparation(t *testing.T) {
+	p, _ := newTestPlaymate(t, func(c *PlaymateConfig) {
+		c.InterestNormalization = NormalizeRank
+	})
+	engageUnequally(p, unequalEngagement)
+	p.NormalizeInterests()
+
+	checkStrengths(t, interestStrengths(p), map[string]float64{
+		"stars":  1.0,
+		"tides":  0.7,
+		"moss":   0.4,
+		"clouds": minNormalizedStrength,
+	})
+}
+
+func TestNormalizeInterestsRankTies(t *testing.T) {
+	p, _ := newTestPlaymate(t, func(c *PlaymateConfig) {
+		c.InterestNormalization = NormalizeRank
+	})
+	engageUnequally(p, map[string]int{"stars": 5, "tides": 2, "moss": 2})
+	p.NormalizeInterests()
+
+	got := interestStrengths(p)
+	if got["tides"] != got["moss"] {
+		t.Errorf("equally engaged tides %v and moss %v should share a strength", got["tides"], got["moss"])
+	}
+	if got["stars"] != 1.0 || got["tides"] >= got["stars"] {
+		t.Errorf("strengths = %v, want stars alone at 1.0", got)
+	}
+}
+
+func TestRunMaintenanceNormalizesInterests(t *testing.T) {
+	p, _ := newTestPlaymate(t, func(c *PlaymateConfig) {
+		c.InterestNormalization = NormalizeRank
+	})
+	engageUnequally(p, map[string]int{"stars": 9, "tides": 6})
+	p.RunMaintenance()
+
+	checkStrengths(t, interestStrengths(p), map[string]float64{"stars": 1.0, "tides": minNormalizedStrength})
+}
+
+func TestUnknownInterestNormalization(t *testing.T) {
+	config := DefaultPlaymateConfig()
+	config.InterestNormalization = "softmax"
+	if _, err := NewPlaymate(config); err == nil {
+		t.Error("NewPlaymate accepted an unknown interest normalization")
+	}
+}
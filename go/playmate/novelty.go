@@ -0,0 +1,45 @@
+package playmate
+
+// maxThoughtAttempts caps how many thoughts generateThought tries before
+// giving up on finding a novel one
+const maxThoughtAttempts = 5
+
+// isNovelThought reports whether a thought differs enough from the last
+// NoveltyWindow thoughts to be worth adding to the stream (must hold lock)
+func (p *Playmate) isNovelThought(thought string) bool {
+	if p.noveltyWindow == 0 {
+		return true
+	}
+
+	recent := p.getRecentThoughts(p.noveltyWindow)
+	for _, prev := range recent {
+		if prev == thought || thoughtSimilarity(prev, thought) >= p.noveltyThreshold {
+			return false
+		}
+	}
+	return true
+}
+
+// thoughtSimilarity is the Jaccard similarity of two thoughts' word sets
+func thoughtSimilarity(a, b string) float64 {
+	setA := make(map[string]bool)
+	for _, w := range tokenize(a) {
+		setA[w] = true
+	}
+	setB := make(map[string]bool)
+	for _, w := range tokenize(b) {
+		setB[w] = true
+	}
+
+	shared := 0
+	for w := range setA {
+		if setB[w] {
+			shared++
+		}
+	}
+	union := len(setA) + len(setB) - shared
+	if union == 0 {
+		return 0
+	}
+	return float64(shared) / float64(union)
+}
@@ -0,0 +1,93 @@
+package playmate
+
+import (
+	"context"
+	"testing"
+)
+
+func TestThoughtSimilarity(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want float64
+	}{
+		{"What makes a moment meaningful?", "what makes a moment meaningful", 1},
+		{"rivers flow", "seeds grow", 0},
+		{"", "", 0},
+	}
+	for _, tt := range tests {
+		if got := thoughtSimilarity(tt.a, tt.b); got != tt.want {
+			t.Errorf("thoughtSimilarity(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestIsNovelThought(t *testing.T) {
+	p, _ := newTestPlaymate(t, func(c *PlaymateConfig) {
+		c.NoveltyWindow = 2
+		c.NoveltyThreshold = 0.5
+	})
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.StreamOfThoughts = []string{
+		"the tide follows the moon",
+		"kettles whistle when water boils",
+		"birds gather before a storm",
+	}
+
+	tests := []struct {
+		thought string
+		want    bool
+	}{
+		{"birds gather before a storm", false},       // Exact repeat
+		{"birds gather before the storm", false},     // Near repeat
+		{"birds scatter after the rain", true},       // Little overlap
+		{"the tide follows the moon", true},          // Outside the window
+		{"kettles whistle when the water is", false}, // Over the threshold
+	}
+	for _, tt := range tests {
+		if got := p.isNovelThought(tt.thought); got != tt.want {
+			t.Errorf("isNovelThought(%q) = %v, want %v", tt.thought, got, tt.want)
+		}
+	}
+}
+
+func TestGeneratedThoughtsAvoidRepeats(t *testing.T) {
+	const window = 4
+	p, _ := newTestPlaymate(t, func(c *PlaymateConfig) { c.NoveltyWindow = window })
+	for i := 0; i < 40; i++ {
+		p.generateThought(context.Background())
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	stream := p.StreamOfThoughts
+	distinct := make(map[string]bool)
+	for i, thought := range stream {
+		distinct[thought] = true
+		for j := i - window; j < i; j++ {
+			if j < 0 {
+				continue
+			}
+			if stream[j] == thought {
+				t.Fatalf("thought %d %q repeats thought %d within the window", i, thought, j)
+			}
+		}
+	}
+	if len(distinct) <= window {
+		t.Errorf("stream of %d thoughts has only %d distinct, want variety", len(stream), len(distinct))
+	}
+}
+
+func TestNoveltyCheckDisabled(t *testing.T) {
+	p, _ := newTestPlaymate(t, func(c *PlaymateConfig) { c.NoveltyWindow = 0 })
+	for i := 0; i < 40; i++ {
+		p.generateThought(context.Background())
+	}
+
+	// Without the check every generated thought lands, repeats included
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if len(p.StreamOfThoughts) != 40 {
+		t.Errorf("stream holds %d thoughts, want all 40", len(p.StreamOfThoughts))
+	}
+}
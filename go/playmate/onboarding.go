@@ -0,0 +1,45 @@
+package playmate
+
+import (
+	"fmt"
+	"strings"
+)
+
+// InterestSeed is an interest a new playmate starts out with
+type InterestSeed struct {
+	Category InterestCategory `json:"category"`
+	Topic    string           `json:"topic"`
+	Keywords []string         `json:"keywords,omitempty"`
+}
+
+// onboard greets the world the first time a playmate ever starts: it records
+// a first wonder, learns the configured seed interests, and introduces itself
+// in a thought. Onboarded is persisted, so this happens once per playmate.
+// It reports whether onboarding ran.
+func (p *Playmate) onboard() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.Onboarded {
+		return false
+	}
+
+	p.recordWonder("First Awakening", "Opening my eyes for the very first time", 0.8)
+
+	topics := make([]string, 0, len(p.Config.SeedInterests))
+	for _, seed := range p.Config.SeedInterests {
+		p.learnInterest(seed.Category, seed.Topic, append([]string(nil), seed.Keywords...))
+		topics = append(topics, seed.Topic)
+	}
+
+	if len(topics) > 0 {
+		p.appendThought(fmt.Sprintf("Hello, I'm %s! Everything is new, and I can't wait to explore %s.", p.Name, strings.Join(topics, ", ")))
+	} else {
+		p.appendThought(fmt.Sprintf("Hello, I'm %s! Everything is new, and I can't wait to explore it all.", p.Name))
+	}
+
+	p.Onboarded = true
+	p.dirty = true
+	p.logger.Info("onboarded playmate", "seed_interests", len(topics))
+	return true
+}
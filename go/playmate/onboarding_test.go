@@ -0,0 +1,105 @@
+package playmate
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+var testSeeds = []InterestSeed{
+	{Category: InterestExploration, Topic: "tides", Keywords: []string{"ocean", "moon"}},
+	{Category: InterestCreativity, Topic: "origami"},
+}
+
+func newSeededPlaymate(t *testing.T, store *memoryStore) *Playmate {
+	p, _ := newTestPlaymate(t, func(c *PlaymateConfig) {
+		c.PersistPath = "playmate"
+		c.Store = store
+		c.SeedInterests = testSeeds
+	})
+	return p
+}
+
+func TestOnboardingOnFirstStart(t *testing.T) {
+	p := newSeededPlaymate(t, &memoryStore{})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := p.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	interests := interestsByID(p)
+	if len(interests) != len(testSeeds) {
+		t.Fatalf("onboarding learned %d interests, want %d", len(interests), len(testSeeds))
+	}
+	tides := interests["exploration_tides"]
+	if tides == nil || !hasKeyword(tides.Keywords, "ocean") || !hasKeyword(tides.Keywords, "moon") {
+		t.Errorf("tides interest = %+v, want the seeded keywords", tides)
+	}
+	if interests["creativity_origami"] == nil {
+		t.Error("origami seed was not learned")
+	}
+	if got := len(wondersOf(p)); got != 1 {
+		t.Errorf("onboarding recorded %d wonders, want 1", got)
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if !p.Onboarded {
+		t.Error("playmate not marked onboarded")
+	}
+	if n := len(p.StreamOfThoughts); n == 0 || !strings.Contains(p.StreamOfThoughts[n-1], "tides, origami") {
+		t.Errorf("thoughts = %v, want an introduction naming the seeds", p.StreamOfThoughts)
+	}
+}
+
+func TestOnboardingRunsOnce(t *testing.T) {
+	store := &memoryStore{}
+	p := newSeededPlaymate(t, store)
+	if !p.onboard() {
+		t.Fatal("first onboard did not run")
+	}
+	if p.onboard() {
+		t.Error("second onboard ran again in the same process")
+	}
+	if err := p.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	// A restart loads the flag and must not re-apply the seeds
+	restarted := newSeededPlaymate(t, store)
+	if restarted.onboard() {
+		t.Error("onboarding ran again after restart")
+	}
+	for id, interest := range interestsByID(restarted) {
+		if interest.EngageCount != 1 || interest.Strength != 0.5 {
+			t.Errorf("%s re-applied on restart: engaged %d times, strength %v", id, interest.EngageCount, interest.Strength)
+		}
+	}
+	if got := len(wondersOf(restarted)); got != 1 {
+		t.Errorf("restarted playmate has %d wonders, want the 1 from the first start", got)
+	}
+}
+
+func TestOnboardingSkippedForStateSavedBeforeOnboarding(t *testing.T) {
+	store := &memoryStore{}
+	p, _ := newTestPlaymate(t, func(c *PlaymateConfig) {
+		c.PersistPath = "playmate"
+		c.Store = store
+	})
+	p.LearnInterest(InterestPlay, "tag", nil)
+	p.mu.Lock()
+	p.Onboarded = false // As saved by a version without onboarding
+	p.mu.Unlock()
+	if err := p.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := newSeededPlaymate(t, store)
+	if loaded.onboard() {
+		t.Error("onboarding ran for a playmate that had already woken")
+	}
+	if _, ok := interestsByID(loaded)["exploration_tides"]; ok {
+		t.Error("seeds applied to an existing playmate")
+	}
+}
@@ -0,0 +1,25 @@
+package playmate
+
+import "sync/atomic"
+
+// Pause suspends the playmate's background loops, e.g. during maintenance,
+// without stopping them: autonomous thoughts, wake/rest changes, and
+// maintenance passes are skipped, and queued thoughts wait, until Resume.
+// Direct calls still work.
+func (p *Playmate) Pause() {
+	if atomic.CompareAndSwapInt32(&p.paused, 0, 1) {
+		p.logger.Info("paused autonomous activity")
+	}
+}
+
+// Resume restarts background activity suspended by Pause
+func (p *Playmate) Resume() {
+	if atomic.CompareAndSwapInt32(&p.paused, 1, 0) {
+		p.logger.Info("resumed autonomous activity")
+	}
+}
+
+// IsPaused reports whether background activity is paused
+func (p *Playmate) IsPaused() bool {
+	return atomic.LoadInt32(&p.paused) == 1
+}
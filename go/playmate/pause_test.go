@@ -0,0 +1,98 @@
+package playmate
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func thoughtCount(p *Playmate) int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return len(p.StreamOfThoughts)
+}
+
+func TestPauseSkipsThoughtsUntilResume(t *testing.T) {
+	p, _ := newTestPlaymate(t, func(c *PlaymateConfig) {
+		c.ThoughtInterval = time.Millisecond
+	})
+	p.LearnInterest(InterestExploration, "tides", nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	p.Pause()
+	if !p.IsPaused() {
+		t.Fatal("IsPaused false after Pause")
+	}
+	if err := p.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	// Onboarding runs on Start even while paused; later ticks are skipped
+	paused := thoughtCount(p)
+	time.Sleep(50 * time.Millisecond)
+	if got := thoughtCount(p); got != paused {
+		t.Fatalf("%d thoughts generated while paused", got-paused)
+	}
+
+	p.Resume()
+	if p.IsPaused() {
+		t.Fatal("IsPaused true after Resume")
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for thoughtCount(p) == paused {
+		if time.Now().After(deadline) {
+			t.Fatal("no thoughts generated after Resume")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestPauseResumeIdempotent(t *testing.T) {
+	logger := &recordingLogger{}
+	p, _ := newTestPlaymate(t, func(c *PlaymateConfig) {
+		c.Logger = logger
+	})
+
+	p.Resume() // Not paused; nothing to do
+	p.Pause()
+	p.Pause()
+	p.Resume()
+	p.Resume()
+
+	if n := len(logger.find("paused autonomous activity")); n != 1 {
+		t.Errorf("logged pause %d times, want 1", n)
+	}
+	if n := len(logger.find("resumed autonomous activity")); n != 1 {
+		t.Errorf("logged resume %d times, want 1", n)
+	}
+	if p.IsPaused() {
+		t.Error("IsPaused true after Resume")
+	}
+}
+
+func TestPauseHoldsQueuedThoughts(t *testing.T) {
+	p, _ := newTestPlaymate(t, func(c *PlaymateConfig) {
+		c.ThoughtInterval = time.Millisecond
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	p.Pause()
+	if err := p.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	p.thoughtChan <- "what lies beyond the tide?"
+	time.Sleep(50 * time.Millisecond)
+	if n := len(p.thoughtChan); n != 1 {
+		t.Fatalf("%d thoughts queued while paused, want the sent thought to wait", n)
+	}
+
+	p.Resume()
+	deadline := time.Now().Add(5 * time.Second)
+	for len(p.thoughtChan) != 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("queued thought not processed after Resume")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,154 @@
+package playmate
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/o9nn/un9n/go/persist"
+)
+
+func TestPlaymateSaveLoadRoundTrip(t *testing.T) {
+	for _, format := range []persist.Format{persist.FormatJSON, persist.FormatGob} {
+		t.Run(string(format), func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "playmate")
+			configure := func(c *PlaymateConfig) {
+				c.PersistPath = path
+				c.Format = format
+				c.Compress = format == persist.FormatGob
+			}
+
+			p, _ := newTestPlaymate(t, configure)
+			interest := p.LearnInterest(InterestExploration, "tides", []string{"moon", "sea"})
+			skill := p.PracticeSkill("juggling", "keeping three balls up")
+			d := mustStartDiscussion(t, p, "tides", "ana")
+			if err := p.AddMessage(d.ID, "ana", "why does the tide follow the moon?"); err != nil {
+				t.Fatal(err)
+			}
+			if err := p.Save(); err != nil {
+				t.Fatal(err)
+			}
+
+			loaded, _ := newTestPlaymate(t, configure)
+			loaded.mu.RLock()
+			defer loaded.mu.RUnlock()
+			if got := loaded.Interests[interest.ID]; got == nil || got.Topic != "tides" || len(got.Keywords) != 2 {
+				t.Errorf("loaded interest = %+v, want tides with its keywords", got)
+			}
+			if got := loaded.Skills[skill.ID]; got == nil || got.Proficiency != skill.Proficiency {
+				t.Errorf("loaded skill = %+v, want proficiency %v", got, skill.Proficiency)
+			}
+			if got := loaded.Discussions[d.ID]; got == nil || len(got.Messages) != 1 || !got.StartedAt.Equal(d.StartedAt) {
+				t.Errorf("loaded discussion = %+v, want one message started at %v", got, d.StartedAt)
+			}
+			if loaded.TotalDiscussions != 1 {
+				t.Errorf("total discussions = %d, want 1", loaded.TotalDiscussions)
+			}
+		})
+	}
+}
+
+func TestWisdomSaveLoadRoundTrip(t *testing.T) {
+	for _, format := range []persist.Format{persist.FormatJSON, persist.FormatGob} {
+		t.Run(string(format), func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "wisdom")
+
+			wc, _ := newTestCultivator(t, &WisdomConfig{PersistPath: path, Format: format})
+			principle := wc.AddPrinciple("Listen before answering", []WisdomDimension{DimensionCompassion}, "test")
+			wc.AddInsight(context.Background(), "patience opens people up", "conversation", 0.6)
+			if err := wc.Save(); err != nil {
+				t.Fatal(err)
+			}
+			want := wc.GetMetrics()
+
+			loaded, _ := newTestCultivator(t, &WisdomConfig{PersistPath: path, Format: format})
+			got := loaded.GetMetrics()
+			if got.Understanding != want.Understanding || got.Compassion != want.Compassion {
+				t.Errorf("loaded metrics = %+v, want %+v", got, want)
+			}
+			if p := loaded.Principles[principle.ID]; p == nil || p.Statement != principle.Statement {
+				t.Errorf("loaded principle = %+v, want %q", p, principle.Statement)
+			}
+			if n := len(loaded.GetRecentInsights(10)); n != 1 {
+				t.Errorf("loaded %d insights, want 1", n)
+			}
+			if n := len(loaded.GrowthHistory); n != len(wc.GrowthHistory) {
+				t.Errorf("loaded %d growth events, want %d", n, len(wc.GrowthHistory))
+			}
+		})
+	}
+}
+
+// isGzip reports whether data starts with the gzip magic header
+func isGzip(data []byte) bool {
+	return len(data) > 2 && data[0] == 0x1f && data[1] == 0x8b
+}
+
+func TestPlaymateCompressedSave(t *testing.T) {
+	sizes := make(map[bool]int)
+	for _, compress := range []bool{false, true} {
+		store := &memoryStore{}
+		p, _ := newTestPlaymate(t, func(c *PlaymateConfig) {
+			c.PersistPath = "playmate"
+			c.Store = store
+			c.Compress = compress
+		})
+		d := mustStartDiscussion(t, p, "tides", "ana")
+		for i := 0; i < 20; i++ {
+			if err := p.AddMessage(d.ID, "ana", "the tide rises and falls with the moon"); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := p.Save(); err != nil {
+			t.Fatal(err)
+		}
+		data := store.data["playmate"]
+		sizes[compress] = len(data)
+		if isGzip(data) != compress {
+			t.Errorf("Compress=%v saved gzip=%v", compress, isGzip(data))
+		}
+
+		// Load detects compression whatever the loading config says
+		loaded, _ := newTestPlaymate(t, func(c *PlaymateConfig) {
+			c.PersistPath = "playmate"
+			c.Store = store
+			c.Compress = !compress
+		})
+		loaded.mu.RLock()
+		got := loaded.Discussions[d.ID]
+		loaded.mu.RUnlock()
+		if got == nil || len(got.Messages) != 20 {
+			t.Errorf("Compress=%v: loaded discussion = %+v, want 20 messages", compress, got)
+		}
+	}
+	if sizes[true] >= sizes[false] {
+		t.Errorf("compressed state is %d bytes, uncompressed %d; want smaller", sizes[true], sizes[false])
+	}
+}
+
+func TestWisdomCompressedSave(t *testing.T) {
+	sizes := make(map[bool]int)
+	for _, compress := range []bool{false, true} {
+		store := &memoryStore{}
+		wc, _ := newTestCultivator(t, &WisdomConfig{PersistPath: "wisdom", Store: store, Compress: compress})
+		for i := 0; i < 10; i++ {
+			wc.AddInsight(context.Background(), "patience opens people up", "conversation", 0.6)
+		}
+		if err := wc.Save(); err != nil {
+			t.Fatal(err)
+		}
+		data := store.data["wisdom"]
+		sizes[compress] = len(data)
+		if isGzip(data) != compress {
+			t.Errorf("Compress=%v saved gzip=%v", compress, isGzip(data))
+		}
+
+		loaded, _ := newTestCultivator(t, &WisdomConfig{PersistPath: "wisdom", Store: store, Compress: !compress})
+		if got, want := len(loaded.Insights), len(wc.Insights); got != want {
+			t.Errorf("Compress=%v: loaded %d insights, want %d", compress, got, want)
+		}
+	}
+	if sizes[true] >= sizes[false] {
+		t.Errorf("compressed state is %d bytes, uncompressed %d; want smaller", sizes[true], sizes[false])
+	}
+}
@@ -5,13 +5,27 @@ package playmate
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"math/rand"
 	"os"
-	"path/filepath"
+	"sort"
+	"strings"
 	"sync"
 	"time"
+	"unicode"
+
+	"github.com/o9nn/un9n/go/events"
+	"github.com/o9nn/un9n/go/persist"
+)
+
+var (
+	// ErrDiscussionNotFound is returned when a discussion ID is unknown
+	ErrDiscussionNotFound = errors.New("discussion not found")
+	// ErrDiscussionInactive is returned when messaging a discussion that has ended
+	ErrDiscussionInactive = errors.New("discussion is not active")
+	// ErrInvalidTransition is returned when a state change isn't allowed from the current state
+	ErrInvalidTransition = errors.New("invalid state transition")
 )
 
 // PlaymateState represents the current state of the playmate
@@ -99,16 +113,124 @@ type WonderEvent struct {
 	Reflection  string    `json:"reflection"`
 }
 
+// MoodSample records the playmate's mood at a point in time
+type MoodSample struct {
+	Timestamp time.Time `json:"timestamp"`
+	Mood      float64   `json:"mood"`
+	Cause     string    `json:"cause"`
+}
+
+// Clock provides the current time; inject a fake clock to control time in tests
+type Clock interface {
+	Now() time.Time
+}
+
+// realClock is the system clock
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() }
+
 // PlaymateConfig holds configuration for the playmate
 type PlaymateConfig struct {
 	Name              string
 	PersistPath       string
+	Format            persist.Format // Encoding used by Save; Load detects it automatically
+	Compress          bool // Gzip saved state; Load detects it automatically
 	WakeHour          int // Hour to wake (0-23)
 	RestHour          int // Hour to rest (0-23)
 	CuriosityLevel    float64
 	PlayfulnessLevel  float64
 	WisdomAffinity    float64
 	SocialAffinity    float64
+	MoodBaseline      float64 // Mood the playmate relaxes toward (-1.0 to 1.0)
+	MoodDecayRate     float64 // Fraction of the distance to baseline recovered per tick
+	Logger            Logger  // Optional; defaults to a no-op logger
+
+	// MaintenanceInterval controls how often neglected interests and skills
+	// are revisited (0 disables the maintenance loop)
+	MaintenanceInterval time.Duration
+	// AutoPractice practices a neglected skill instead of only suggesting it
+	AutoPractice bool
+	// Rand is the source of randomness; seed it for reproducible behavior
+	Rand *rand.Rand
+	// Clock provides the current time (defaults to the system clock)
+	Clock Clock
+	// Location is the timezone WakeHour and RestHour are interpreted in (defaults to Local)
+	Location *time.Location
+	// IDGenerator creates discussion, message, and wonder IDs (defaults to sequential IDs)
+	IDGenerator IDGenerator
+
+	// ThoughtInterval is how often the autonomous loop generates a thought
+	ThoughtInterval time.Duration
+	// ThoughtEnergyCost is the energy spent on each spontaneous thought
+	ThoughtEnergyCost float64
+	// MaxThoughts caps the stream of thoughts; when exceeded, the oldest half is
+	// rolled into a digest
+	MaxThoughts int
+	// NoveltyWindow is how many recent thoughts a new thought must differ from (0 disables the check)
+	NoveltyWindow int
+	// NoveltyThreshold is the word-overlap similarity (0.0 to 1.0) at which a
+	// thought counts as a repeat of a recent one
+	NoveltyThreshold float64
+
+	// GameFunc generates games for ProposeGame (defaults to built-in templates)
+	GameFunc GameFunc
+	// QuestionFunc phrases questions for GenerateQuestion (defaults to built-in templates)
+	QuestionFunc QuestionFunc
+
+	// Events, if set, receives a KindWonder event for each recorded wonder
+	Events *events.Bus
+
+	// DepthScorer decides how much each message deepens a discussion, which
+	// EndDiscussion uses to extract insights (defaults to one per message)
+	DepthScorer DepthScorer
+
+	// Store holds saved state under the key PersistPath (defaults to the local filesystem)
+	Store persist.Store
+
+	// AutoLearnInterests learns interests from keywords that recur in a
+	// discussion's messages
+	AutoLearnInterests bool
+	// AutoLearnThreshold is how many times a keyword must appear in a
+	// discussion before it is learned as an interest (defaults to 3)
+	AutoLearnThreshold int
+	// CategoryClassifier infers the category of an auto-learned interest
+	// (defaults to a built-in keyword table)
+	CategoryClassifier CategoryClassifier
+
+	// ThoughtSummarizer writes the summary of each thought digest, which rolls
+	// up the stream of thoughts at the end of every wake cycle (defaults to
+	// naming the most recurring words)
+	ThoughtSummarizer ThoughtSummarizer
+	// MaxDigests caps the thought digests kept; when exceeded, the oldest are
+	// dropped (defaults to 365)
+	MaxDigests int
+	// DigestArchiveSize is how many of its newest raw thoughts each digest
+	// archives alongside its summary (defaults to 100; negative keeps only
+	// the summary)
+	DigestArchiveSize int
+
+	// IdleTimeout is how long the playmate may go without discussions or
+	// messages before it turns to learning on its own (0 disables idle detection)
+	IdleTimeout time.Duration
+
+	// WonderTriggers decide when thoughts and messages inspire wonder, in
+	// order (defaults to occasional wonder at thoughts while highly curious)
+	WonderTriggers []WonderTrigger
+
+	// SeedInterests are learned during onboarding, which runs the first time a
+	// playmate is ever started
+	SeedInterests []InterestSeed
+
+	// InterestNormalization rescales interest strengths on each maintenance
+	// pass so heavily engaged interests keep their separation instead of all
+	// saturating at 1.0 (defaults to NormalizeNone)
+	InterestNormalization InterestNormalization
+
+	// EngagementScorer rates each ended discussion; interests matching its
+	// topic move their Engagement toward the score (defaults to weighing depth
+	// and message sentiment)
+	EngagementScorer EngagementScorer
 }
 
 // DefaultPlaymateConfig returns default configuration
@@ -116,12 +238,24 @@ func DefaultPlaymateConfig() *PlaymateConfig {
 	return &PlaymateConfig{
 		Name:             "Echo",
 		PersistPath:      "",
+		Format:           persist.FormatJSON,
 		WakeHour:         6,
 		RestHour:         22,
 		CuriosityLevel:   0.8,
 		PlayfulnessLevel: 0.7,
 		WisdomAffinity:   0.9,
 		SocialAffinity:   0.6,
+		MoodBaseline:     0.5,
+		MoodDecayRate:    0.05,
+
+		MaintenanceInterval: 30 * time.Minute,
+		ThoughtInterval:     5 * time.Second,
+		ThoughtEnergyCost:   0.01,
+		MaxThoughts:         1000,
+		NoveltyWindow:       10,
+		NoveltyThreshold:    0.8,
+		MaxDigests:          365,
+		DigestArchiveSize:   100,
 	}
 }
 
@@ -150,18 +284,70 @@ type Playmate struct {
 	TotalDiscussions    int
 	TotalInsights       int
 	TotalWonders        int
-	WisdomScore         float64
+	TotalGames          int
+	WisdomScore         float64 // Overall score of the attached WisdomCultivator (see AttachWisdom)
 	StreamOfThoughts    []string
 	LastThought         time.Time
+	MoodHistory         []MoodSample
+	StateHistory        []StateChange
+	Journal             []JournalEntry
+	Digests             []DailyDigest
+	Onboarded           bool // Whether the first-start onboarding has run
 
 	// Persistence
 	persistPath string
+	store       persist.Store
 	dirty       bool
+	logger      Logger
+	rand        *rand.Rand
+	clock       Clock
+	location    *time.Location
+	ids         IDGenerator
+
+	// Autonomous thought tuning
+	thoughtInterval   time.Duration
+	thoughtEnergyCost float64
+	maxThoughts       int
+	noveltyWindow     int
+	noveltyThreshold  float64
+
+	gameFunc     GameFunc
+	questionFunc QuestionFunc
+	depthScorer  DepthScorer
+	bus          *events.Bus
+
+	// quiet suppresses autonomous thoughts and wonders
+	quiet bool
+
+	// paused, set atomically, makes the background loops skip their ticks
+	paused int32
+
+	// wisdom, if attached, is the source of WisdomScore
+	wisdom *WisdomCultivator
+
+	// Interest auto-learning
+	autoLearnThreshold int
+	classifyCategory   CategoryClassifier
+
+	// Thought digests
+	summarizeThoughts ThoughtSummarizer
+	maxDigests        int
+	digestArchiveSize int
+
+	// Idle detection
+	idleTimeout     time.Duration
+	lastInteraction time.Time
+
+	wonderTriggers []WonderTrigger
+
+	scoreEngagement EngagementScorer
 
 	// Channels for autonomous operation
 	thoughtChan   chan string
 	discussionChan chan *Discussion
 	stopChan      chan struct{}
+	stopOnce      sync.Once
+	closeOnce     sync.Once
 }
 
 // NewPlaymate creates a new playmate instance
@@ -169,6 +355,30 @@ func NewPlaymate(config *PlaymateConfig) (*Playmate, error) {
 	if config == nil {
 		config = DefaultPlaymateConfig()
 	}
+	if config.ThoughtInterval < 0 {
+		return nil, fmt.Errorf("invalid thought interval: %v", config.ThoughtInterval)
+	}
+	if config.ThoughtEnergyCost < 0 || config.ThoughtEnergyCost > 1 {
+		return nil, fmt.Errorf("invalid thought energy cost: %v", config.ThoughtEnergyCost)
+	}
+	if config.MaxThoughts < 0 {
+		return nil, fmt.Errorf("invalid max thoughts: %d", config.MaxThoughts)
+	}
+	if config.MaxDigests < 0 {
+		return nil, fmt.Errorf("invalid max digests: %d", config.MaxDigests)
+	}
+	if config.NoveltyWindow < 0 {
+		return nil, fmt.Errorf("invalid novelty window: %d", config.NoveltyWindow)
+	}
+	if config.NoveltyThreshold < 0 || config.NoveltyThreshold > 1 {
+		return nil, fmt.Errorf("invalid novelty threshold: %v", config.NoveltyThreshold)
+	}
+	if config.IdleTimeout < 0 {
+		return nil, fmt.Errorf("invalid idle timeout: %v", config.IdleTimeout)
+	}
+	if !config.InterestNormalization.valid() {
+		return nil, fmt.Errorf("unknown interest normalization: %s", config.InterestNormalization)
+	}
 
 	p := &Playmate{
 		Name:           config.Name,
@@ -183,50 +393,183 @@ func NewPlaymate(config *PlaymateConfig) (*Playmate, error) {
 		Discussions:    make(map[string]*Discussion),
 		Wonders:        make([]*WonderEvent, 0),
 		StreamOfThoughts: make([]string, 0),
+		MoodHistory:    make([]MoodSample, 0),
 		persistPath:    config.PersistPath,
 		thoughtChan:    make(chan string, 100),
 		discussionChan: make(chan *Discussion, 10),
 		stopChan:       make(chan struct{}),
+		logger:         config.Logger,
+		rand:           config.Rand,
+		clock:          config.Clock,
+		location:       config.Location,
+		ids:            config.IDGenerator,
+
+		thoughtInterval:   config.ThoughtInterval,
+		thoughtEnergyCost: config.ThoughtEnergyCost,
+		maxThoughts:       config.MaxThoughts,
+		noveltyWindow:     config.NoveltyWindow,
+		noveltyThreshold:  config.NoveltyThreshold,
+
+		gameFunc:     config.GameFunc,
+		questionFunc: config.QuestionFunc,
+		depthScorer:  config.DepthScorer,
+		bus:          config.Events,
+
+		store: config.Store,
+
+		classifyCategory: config.CategoryClassifier,
+
+		summarizeThoughts: config.ThoughtSummarizer,
+		maxDigests:        config.MaxDigests,
+		digestArchiveSize: config.DigestArchiveSize,
+
+		idleTimeout: config.IdleTimeout,
+
+		wonderTriggers: append([]WonderTrigger(nil), config.WonderTriggers...),
+
+		scoreEngagement: config.EngagementScorer,
+	}
+
+	if p.logger == nil {
+		p.logger = nopLogger{}
+	}
+	if p.rand == nil {
+		p.rand = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	if p.clock == nil {
+		p.clock = realClock{}
+	}
+	if p.location == nil {
+		p.location = time.Local
+	}
+	if p.ids == nil {
+		p.ids = NewSequentialIDGenerator()
+	}
+	if p.thoughtInterval == 0 {
+		p.thoughtInterval = 5 * time.Second
+	}
+	if p.maxThoughts == 0 {
+		p.maxThoughts = 1000
+	}
+	if p.noveltyThreshold == 0 {
+		p.noveltyThreshold = 0.8
+	}
+	if p.gameFunc == nil {
+		p.gameFunc = defaultGameFunc
 	}
+	if p.questionFunc == nil {
+		p.questionFunc = defaultQuestionFunc
+	}
+	if p.depthScorer == nil {
+		p.depthScorer = defaultDepthScorer
+	}
+	if p.store == nil {
+		p.store = persist.FileStore{}
+	}
+	if config.AutoLearnInterests {
+		p.autoLearnThreshold = config.AutoLearnThreshold
+		if p.autoLearnThreshold <= 0 {
+			p.autoLearnThreshold = 3
+		}
+	}
+	if p.classifyCategory == nil {
+		p.classifyCategory = defaultCategoryClassifier
+	}
+	if p.summarizeThoughts == nil {
+		p.summarizeThoughts = defaultThoughtSummarizer
+	}
+	if p.maxDigests == 0 {
+		p.maxDigests = 365
+	}
+	if p.digestArchiveSize == 0 {
+		p.digestArchiveSize = 100
+	}
+	if p.scoreEngagement == nil {
+		p.scoreEngagement = defaultEngagementScorer
+	}
+	if config.WonderTriggers == nil {
+		p.wonderTriggers = []WonderTrigger{WonderTriggerFunc(curiosityWonderTrigger)}
+	}
+	p.lastInteraction = p.clock.Now()
 
 	// Load from persistence
 	if config.PersistPath != "" {
-		if err := p.Load(); err != nil && !os.IsNotExist(err) {
+		if err := p.Load(); err != nil && !errors.Is(err, os.ErrNotExist) {
 			return nil, fmt.Errorf("failed to load playmate state: %w", err)
 		}
 	}
 
+	// Mark the start of this run in the state history
+	p.recordStateChange(p.State)
+
 	return p, nil
 }
 
-// Start begins autonomous operation
+// Start begins autonomous operation, onboarding the playmate first if it has
+// never been started before
 func (p *Playmate) Start(ctx context.Context) error {
+	p.onboard()
 	go p.autonomousLoop(ctx)
 	go p.wakeRestCycle(ctx)
+	if p.Config.MaintenanceInterval > 0 {
+		go p.maintenanceLoop(ctx)
+	}
 	return nil
 }
 
 // Stop stops autonomous operation
 func (p *Playmate) Stop() {
-	close(p.stopChan)
+	p.stopOnce.Do(func() {
+		close(p.stopChan)
+	})
+}
+
+// Close stops autonomous operation and saves any unsaved changes.
+// It is safe to call more than once; later calls do nothing.
+func (p *Playmate) Close() error {
+	var err error
+	p.closeOnce.Do(func() {
+		p.Stop()
+
+		p.mu.RLock()
+		dirty := p.dirty
+		p.mu.RUnlock()
+
+		if dirty {
+			err = p.Save()
+		}
+	})
+	return err
 }
 
 // autonomousLoop runs the continuous stream-of-consciousness
 func (p *Playmate) autonomousLoop(ctx context.Context) {
-	ticker := time.NewTicker(5 * time.Second)
+	ticker := time.NewTicker(p.thoughtInterval)
 	defer ticker.Stop()
 
 	for {
+		// Queued thoughts wait while paused and are processed after Resume
+		thoughts := p.thoughtChan
+		if p.IsPaused() {
+			thoughts = nil
+		}
+
 		select {
 		case <-ctx.Done():
 			return
 		case <-p.stopChan:
 			return
 		case <-ticker.C:
+			if p.IsPaused() {
+				continue
+			}
+			p.moodDecay()
+			p.refreshWisdomScore()
+			p.checkIdle()
 			if p.State == StateAwake || p.State == StateReflecting {
 				p.generateThought(ctx)
 			}
-		case thought := <-p.thoughtChan:
+		case thought := <-thoughts:
 			p.processThought(ctx, thought)
 		}
 	}
@@ -244,43 +587,124 @@ func (p *Playmate) wakeRestCycle(ctx context.Context) {
 		case <-p.stopChan:
 			return
 		case <-ticker.C:
-			hour := time.Now().Hour()
-			
-			p.mu.Lock()
-			if hour >= p.Config.WakeHour && hour < p.Config.RestHour {
-				if p.State == StateResting || p.State == StateDreaming {
-					p.State = StateAwake
-					p.Energy = 1.0
-					p.recordWonder("Awakening", "The dawn of a new cycle of awareness", 0.6)
-				}
-			} else {
-				if p.State == StateAwake || p.State == StateEngaged {
-					p.State = StateDreaming
-					p.Energy = 0.3
-				}
+			if p.IsPaused() {
+				continue
+			}
+			p.updateWakeRest()
+		}
+	}
+}
+
+// updateWakeRest wakes or rests the playmate according to the configured hours
+func (p *Playmate) updateWakeRest() {
+	hour := p.clock.Now().In(p.location).Hour()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.isWakingHour(hour) {
+		if p.State == StateResting || p.State == StateDreaming {
+			if err := p.transition(StateAwake); err != nil {
+				return
+			}
+			p.Energy = 1.0
+			if !p.quiet {
+				p.recordWonder("Awakening", "The dawn of a new cycle of awareness", 0.6)
+			}
+		}
+	} else {
+		if p.State == StateAwake || p.State == StateEngaged || p.State == StatePlaying || p.State == StateLearning {
+			if err := p.transition(StateDreaming); err != nil {
+				return
+			}
+			p.Energy = 0.3
+			// Roll the day's thoughts into a digest at the end of the wake cycle
+			if len(p.StreamOfThoughts) > 0 {
+				p.digestThoughts(len(p.StreamOfThoughts))
 			}
-			p.mu.Unlock()
 		}
 	}
 }
 
+// isWakingHour reports whether hour falls in the wake window, which may wrap past midnight
+func (p *Playmate) isWakingHour(hour int) bool {
+	wake, rest := p.Config.WakeHour, p.Config.RestHour
+	if wake <= rest {
+		return hour >= wake && hour < rest
+	}
+	return hour >= wake || hour < rest
+}
+
+// legalTransitions lists the states reachable from each state
+var legalTransitions = map[PlaymateState][]PlaymateState{
+	StateAwake:      {StateEngaged, StateReflecting, StateLearning, StatePlaying, StateDreaming, StateResting},
+	StateEngaged:    {StateAwake, StateReflecting, StatePlaying, StateDreaming},
+	StateReflecting: {StateAwake, StateEngaged, StateLearning, StatePlaying, StateDreaming, StateResting},
+	StateLearning:   {StateAwake, StateEngaged, StateReflecting, StatePlaying, StateDreaming, StateResting},
+	StatePlaying:    {StateAwake, StateEngaged, StateReflecting, StateDreaming},
+	StateDreaming:   {StateAwake, StateResting},
+	StateResting:    {StateAwake, StateDreaming},
+}
+
+// canTransition reports whether the state machine allows moving from one state to another
+func canTransition(from, to PlaymateState) bool {
+	for _, next := range legalTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// transition changes the playmate state if the legal-transitions table allows
+// it, logging the change. Illegal transitions leave the state unchanged, are
+// logged, and return ErrInvalidTransition. (must hold lock)
+func (p *Playmate) transition(to PlaymateState) error {
+	if p.State == to {
+		return nil
+	}
+	if !canTransition(p.State, to) {
+		p.logger.Warn("rejected state transition", "from", p.State, "to", to)
+		return fmt.Errorf("%w: %s to %s", ErrInvalidTransition, p.State, to)
+	}
+	p.logger.Info("state transition", "from", p.State, "to", to)
+	p.State = to
+	p.recordStateChange(to)
+	return nil
+}
+
 // generateThought generates a spontaneous thought
 func (p *Playmate) generateThought(ctx context.Context) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
+	if p.quiet {
+		return
+	}
+
 	// Reduce energy slightly
-	p.Energy = max(0, p.Energy-0.01)
+	p.Energy = max(0, p.Energy-p.thoughtEnergyCost)
+
+	// Generate thought based on current state and interests, retrying a few
+	// times if it repeats a recent one
+	for attempt := 0; attempt < maxThoughtAttempts; attempt++ {
+		thought := p.createSpontaneousThought()
+		if p.isNovelThought(thought) {
+			p.appendThought(thought)
+			return
+		}
+	}
+	p.logger.Debug("suppressed repetitive thought")
+}
 
-	// Generate thought based on current state and interests
-	thought := p.createSpontaneousThought()
-	
+// appendThought adds a thought to the stream, trimming old ones (must hold lock)
+func (p *Playmate) appendThought(thought string) {
 	p.StreamOfThoughts = append(p.StreamOfThoughts, thought)
-	if len(p.StreamOfThoughts) > 1000 {
-		p.StreamOfThoughts = p.StreamOfThoughts[500:]
+	if len(p.StreamOfThoughts) > p.maxThoughts {
+		p.digestThoughts(len(p.StreamOfThoughts) - p.maxThoughts/2)
 	}
-	
-	p.LastThought = time.Now()
+
+	p.LastThought = p.clock.Now()
 	p.dirty = true
 }
 
@@ -313,7 +737,7 @@ func (p *Playmate) createSpontaneousThought() string {
 		}
 	}
 
-	return thoughts[rand.Intn(len(thoughts))]
+	return thoughts[p.rand.Intn(len(thoughts))]
 }
 
 // processThought processes an incoming thought
@@ -322,25 +746,64 @@ func (p *Playmate) processThought(ctx context.Context, thought string) {
 	defer p.mu.Unlock()
 
 	// Check if thought triggers wonder
-	if p.Curiosity > 0.7 && rand.Float64() < 0.1 {
-		p.recordWonder("Spontaneous Insight", thought, p.Curiosity)
-	}
+	p.evaluateWonderTriggers(ThoughtEvent{Thought: thought})
 
 	// Update mood based on thought
-	p.Mood = clamp(p.Mood+rand.Float64()*0.1-0.05, -1, 1)
+	p.adjustMood(p.rand.Float64()*0.1-0.05, "thought")
+}
+
+// moodDecay relaxes mood toward the configured baseline
+func (p *Playmate) moodDecay() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	rate := clamp(p.Config.MoodDecayRate, 0, 1)
+	if rate == 0 || p.Mood == p.Config.MoodBaseline {
+		return
+	}
+
+	p.adjustMood((p.Config.MoodBaseline-p.Mood)*rate, "decay")
+}
+
+// adjustMood shifts mood by delta and records a sample (must hold lock)
+func (p *Playmate) adjustMood(delta float64, cause string) {
+	p.Mood = clamp(p.Mood+delta, -1, 1)
+
+	p.MoodHistory = append(p.MoodHistory, MoodSample{
+		Timestamp: p.clock.Now(),
+		Mood:      p.Mood,
+		Cause:     cause,
+	})
+	if len(p.MoodHistory) > 1000 {
+		p.MoodHistory = p.MoodHistory[500:]
+	}
+	p.dirty = true
+}
+
+// GetMoodHistory returns a copy of the recorded mood samples
+func (p *Playmate) GetMoodHistory() []MoodSample {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	history := make([]MoodSample, len(p.MoodHistory))
+	copy(history, p.MoodHistory)
+	return history
 }
 
-// LearnInterest adds or strengthens an interest
+// LearnInterest adds or strengthens an interest, returning a copy of it
 func (p *Playmate) LearnInterest(category InterestCategory, topic string, keywords []string) *Interest {
 	p.mu.Lock()
 	defer p.mu.Unlock()
+	return p.learnInterest(category, topic, keywords).clone()
+}
 
+func (p *Playmate) learnInterest(category InterestCategory, topic string, keywords []string) *Interest {
 	id := fmt.Sprintf("%s_%s", category, topic)
 	
 	if existing, ok := p.Interests[id]; ok {
 		existing.Strength = min(1.0, existing.Strength+0.1)
 		existing.EngageCount++
-		existing.LastEngaged = time.Now()
+		existing.LastEngaged = p.clock.Now()
 		existing.Keywords = mergeKeywords(existing.Keywords, keywords)
 		return existing
 	}
@@ -353,7 +816,7 @@ func (p *Playmate) LearnInterest(category InterestCategory, topic string, keywor
 		Strength:    0.5,
 		Curiosity:   p.Curiosity,
 		Engagement:  0.5,
-		LastEngaged: time.Now(),
+		LastEngaged: p.clock.Now(),
 		EngageCount: 1,
 		Insights:    make([]string, 0),
 	}
@@ -363,18 +826,24 @@ func (p *Playmate) LearnInterest(category InterestCategory, topic string, keywor
 	return interest
 }
 
-// StartDiscussion initiates a new discussion
-func (p *Playmate) StartDiscussion(topic string, participant string) *Discussion {
+// StartDiscussion initiates a new discussion, returning a copy of it. It returns ErrInvalidTransition,
+// starting nothing, when the playmate can't engage from its current state,
+// such as while resting.
+func (p *Playmate) StartDiscussion(topic string, participant string) (*Discussion, error) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	id := fmt.Sprintf("disc_%d", time.Now().UnixNano())
+	if err := p.transition(StateEngaged); err != nil {
+		return nil, err
+	}
+
+	id := p.ids.NewID("disc")
 	
 	discussion := &Discussion{
 		ID:           id,
 		Participants: []string{p.Name, participant},
 		Topic:        topic,
-		StartedAt:    time.Now(),
+		StartedAt:    p.clock.Now(),
 		Messages:     make([]DiscussionMessage, 0),
 		Mood:         "curious",
 		Depth:        0,
@@ -383,11 +852,11 @@ func (p *Playmate) StartDiscussion(topic string, participant string) *Discussion
 	}
 
 	p.Discussions[id] = discussion
-	p.State = StateEngaged
+	p.noteInteraction()
 	p.TotalDiscussions++
 	p.dirty = true
 
-	return discussion
+	return discussion.clone(), nil
 }
 
 // AddMessage adds a message to a discussion
@@ -397,35 +866,40 @@ func (p *Playmate) AddMessage(discussionID, from, content string) error {
 
 	discussion, ok := p.Discussions[discussionID]
 	if !ok {
-		return fmt.Errorf("discussion not found: %s", discussionID)
+		return fmt.Errorf("%w: %s", ErrDiscussionNotFound, discussionID)
 	}
 
 	msg := DiscussionMessage{
-		ID:        fmt.Sprintf("msg_%d", time.Now().UnixNano()),
+		ID:        p.ids.NewID("msg"),
 		From:      from,
 		Content:   content,
-		Timestamp: time.Now(),
-		Sentiment: 0.0, // Would be calculated by sentiment analysis
+		Timestamp: p.clock.Now(),
+		Sentiment: estimateSentiment(content),
 	}
 
+	discussion.Depth += p.depthScorer(msg, discussion.Messages)
 	discussion.Messages = append(discussion.Messages, msg)
-	discussion.Depth++
+	p.autoLearnInterests(discussion, msg)
+	p.adjustMood(msg.Sentiment*0.2, "message")
+	p.evaluateWonderTriggers(MessageEvent{DiscussionID: discussionID, Message: msg})
+	p.noteInteraction()
 	p.dirty = true
 
 	return nil
 }
 
-// EndDiscussion ends a discussion and extracts insights
+// EndDiscussion ends a discussion, extracts insights, and updates the
+// engagement of interests matching its topic
 func (p *Playmate) EndDiscussion(discussionID string) error {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
 	discussion, ok := p.Discussions[discussionID]
 	if !ok {
-		return fmt.Errorf("discussion not found: %s", discussionID)
+		return fmt.Errorf("%w: %s", ErrDiscussionNotFound, discussionID)
 	}
 
-	now := time.Now()
+	now := p.clock.Now()
 	discussion.EndedAt = &now
 	discussion.Active = false
 
@@ -435,24 +909,34 @@ func (p *Playmate) EndDiscussion(discussionID string) error {
 		discussion.Insights = append(discussion.Insights, insight)
 		p.TotalInsights++
 	}
+	p.updateInterestEngagement(discussion)
 
-	p.State = StateReflecting
+	// The discussion ends even if the playmate can't reflect now, e.g. because
+	// it has gone to rest since the discussion started
+	if err := p.transition(StateReflecting); err != nil {
+		p.logger.Debug("ended discussion without reflecting", "id", discussionID, "error", err)
+	}
+	p.noteInteraction()
 	p.dirty = true
 
 	return nil
 }
 
-// PracticeSkill practices a skill
+// PracticeSkill practices a skill, returning a copy of it
 func (p *Playmate) PracticeSkill(name, description string) *Skill {
 	p.mu.Lock()
 	defer p.mu.Unlock()
+	return p.practiceSkill(name, description).clone()
+}
 
+func (p *Playmate) practiceSkill(name, description string) *Skill {
 	id := fmt.Sprintf("skill_%s", name)
 
 	if existing, ok := p.Skills[id]; ok {
 		existing.PracticeCount++
 		existing.Proficiency = min(1.0, existing.Proficiency+0.05)
-		existing.LastPracticed = time.Now()
+		existing.LastPracticed = p.clock.Now()
+		p.dirty = true
 		return existing
 	}
 
@@ -462,7 +946,7 @@ func (p *Playmate) PracticeSkill(name, description string) *Skill {
 		Description:   description,
 		Proficiency:   0.1,
 		PracticeCount: 1,
-		LastPracticed: time.Now(),
+		LastPracticed: p.clock.Now(),
 		Milestones:    make([]string, 0),
 	}
 
@@ -480,11 +964,11 @@ func (p *Playmate) RecordWonder(description, trigger string, intensity float64)
 
 func (p *Playmate) recordWonder(description, trigger string, intensity float64) *WonderEvent {
 	wonder := &WonderEvent{
-		ID:          fmt.Sprintf("wonder_%d", time.Now().UnixNano()),
+		ID:          p.ids.NewID("wonder"),
 		Description: description,
 		Trigger:     trigger,
 		Intensity:   intensity,
-		Timestamp:   time.Now(),
+		Timestamp:   p.clock.Now(),
 		Reflection:  "",
 	}
 
@@ -492,14 +976,32 @@ func (p *Playmate) recordWonder(description, trigger string, intensity float64)
 	p.TotalWonders++
 	p.dirty = true
 
+	p.bus.Publish(events.Event{
+		Kind:      events.KindWonder,
+		Source:    "playmate",
+		Timestamp: wonder.Timestamp,
+		Data: map[string]interface{}{
+			"id":          wonder.ID,
+			"description": description,
+			"intensity":   intensity,
+		},
+	})
+
 	// Increase curiosity when experiencing wonder
 	p.Curiosity = min(1.0, p.Curiosity+intensity*0.1)
 
+	// Intense wonder lifts mood; moodDecay brings it back to baseline
+	if intensity > 0.5 {
+		p.adjustMood(intensity*0.2, "wonder")
+	}
+
 	return wonder
 }
 
 // GetState returns the current playmate state
 func (p *Playmate) GetState() map[string]interface{} {
+	wisdomScore := p.wisdomScore()
+
 	p.mu.RLock()
 	defer p.mu.RUnlock()
 
@@ -515,7 +1017,8 @@ func (p *Playmate) GetState() map[string]interface{} {
 		"total_discussions": p.TotalDiscussions,
 		"total_insights":    p.TotalInsights,
 		"total_wonders":     p.TotalWonders,
-		"wisdom_score":      p.WisdomScore,
+		"total_games":       p.TotalGames,
+		"wisdom_score":      wisdomScore,
 		"last_thought":      p.LastThought,
 		"recent_thoughts":   p.getRecentThoughts(5),
 	}
@@ -528,87 +1031,154 @@ func (p *Playmate) getRecentThoughts(n int) []string {
 	return p.StreamOfThoughts[len(p.StreamOfThoughts)-n:]
 }
 
+// playmateState is the persisted form of a Playmate
+type playmateState struct {
+	Name             string                 `json:"name"`
+	State            PlaymateState          `json:"state"`
+	Mood             float64                `json:"mood"`
+	Energy           float64                `json:"energy"`
+	Curiosity        float64                `json:"curiosity"`
+	Playfulness      float64                `json:"playfulness"`
+	Interests        map[string]*Interest   `json:"interests"`
+	Skills           map[string]*Skill      `json:"skills"`
+	Discussions      map[string]*Discussion `json:"discussions"`
+	Wonders          []*WonderEvent         `json:"wonders"`
+	TotalDiscussions int                    `json:"total_discussions"`
+	TotalInsights    int                    `json:"total_insights"`
+	TotalWonders     int                    `json:"total_wonders"`
+	TotalGames       int                    `json:"total_games,omitempty"`
+	WisdomScore      float64                `json:"wisdom_score"`
+	StreamOfThoughts []string               `json:"stream_of_thoughts"`
+	MoodHistory      []MoodSample           `json:"mood_history"`
+	StateHistory     []StateChange          `json:"state_history,omitempty"`
+	Journal          []JournalEntry         `json:"journal,omitempty"`
+	Digests          []DailyDigest          `json:"digests,omitempty"`
+	Onboarded        bool                   `json:"onboarded,omitempty"`
+}
+
 // Save persists the playmate state
 func (p *Playmate) Save() error {
 	if p.persistPath == "" {
 		return nil
 	}
 
+	wisdomScore := p.wisdomScore()
+
 	p.mu.RLock()
 	defer p.mu.RUnlock()
 
-	dir := filepath.Dir(p.persistPath)
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return fmt.Errorf("failed to create directory: %w", err)
-	}
-
-	state := map[string]interface{}{
-		"name":             p.Name,
-		"state":            p.State,
-		"mood":             p.Mood,
-		"energy":           p.Energy,
-		"curiosity":        p.Curiosity,
-		"playfulness":      p.Playfulness,
-		"interests":        p.Interests,
-		"skills":           p.Skills,
-		"discussions":      p.Discussions,
-		"wonders":          p.Wonders,
-		"total_discussions": p.TotalDiscussions,
-		"total_insights":   p.TotalInsights,
-		"total_wonders":    p.TotalWonders,
-		"wisdom_score":     p.WisdomScore,
-		"stream_of_thoughts": p.StreamOfThoughts,
+	state := playmateState{
+		Name:             p.Name,
+		State:            p.State,
+		Mood:             p.Mood,
+		Energy:           p.Energy,
+		Curiosity:        p.Curiosity,
+		Playfulness:      p.Playfulness,
+		Interests:        p.Interests,
+		Skills:           p.Skills,
+		Discussions:      p.Discussions,
+		Wonders:          p.Wonders,
+		TotalDiscussions: p.TotalDiscussions,
+		TotalInsights:    p.TotalInsights,
+		TotalWonders:     p.TotalWonders,
+		TotalGames:       p.TotalGames,
+		WisdomScore:      wisdomScore,
+		StreamOfThoughts: p.StreamOfThoughts,
+		MoodHistory:      p.MoodHistory,
+		StateHistory:     p.StateHistory,
+		Journal:          p.Journal,
+		Digests:          p.Digests,
+		Onboarded:        p.Onboarded,
 	}
 
-	data, err := json.MarshalIndent(state, "", "  ")
+	data, err := persist.Encode(state, p.Config.Format)
 	if err != nil {
 		return fmt.Errorf("failed to marshal state: %w", err)
 	}
 
-	if err := os.WriteFile(p.persistPath, data, 0644); err != nil {
-		return fmt.Errorf("failed to write file: %w", err)
+	if p.Config.Compress {
+		if data, err = persist.Compress(data); err != nil {
+			return fmt.Errorf("failed to compress state: %w", err)
+		}
+	}
+
+	if err := p.store.Write(p.persistPath, data); err != nil {
+		p.logger.Error("failed to save playmate state", "path", p.persistPath, "error", err)
+		return fmt.Errorf("failed to write state: %w", err)
 	}
 
 	p.dirty = false
+	p.logger.Info("saved playmate state", "path", p.persistPath)
 	return nil
 }
 
-// Load loads the playmate state from disk
+// Load loads the playmate state from its store
 func (p *Playmate) Load() error {
 	if p.persistPath == "" {
 		return nil
 	}
 
-	data, err := os.ReadFile(p.persistPath)
+	data, err := p.store.Read(p.persistPath)
 	if err != nil {
 		return err
 	}
 
-	var state map[string]interface{}
-	if err := json.Unmarshal(data, &state); err != nil {
+	var state playmateState
+	if err := persist.Decode(data, &state); err != nil {
 		return fmt.Errorf("failed to unmarshal state: %w", err)
 	}
 
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	// Restore state (simplified - would need proper type assertions)
-	if name, ok := state["name"].(string); ok {
-		p.Name = name
+	if state.Name != "" {
+		p.Name = state.Name
 	}
-	if mood, ok := state["mood"].(float64); ok {
-		p.Mood = mood
+	if state.State != "" {
+		p.State = state.State
 	}
-	if energy, ok := state["energy"].(float64); ok {
-		p.Energy = energy
+	p.Mood = state.Mood
+	p.Energy = state.Energy
+	p.Curiosity = state.Curiosity
+	p.Playfulness = state.Playfulness
+	if state.Interests != nil {
+		p.Interests = state.Interests
 	}
-	if curiosity, ok := state["curiosity"].(float64); ok {
-		p.Curiosity = curiosity
+	if state.Skills != nil {
+		p.Skills = state.Skills
 	}
-	if playfulness, ok := state["playfulness"].(float64); ok {
-		p.Playfulness = playfulness
+	if state.Discussions != nil {
+		p.Discussions = state.Discussions
 	}
+	if state.Wonders != nil {
+		p.Wonders = state.Wonders
+	}
+	p.TotalDiscussions = state.TotalDiscussions
+	p.TotalInsights = state.TotalInsights
+	p.TotalWonders = state.TotalWonders
+	p.TotalGames = state.TotalGames
+	p.WisdomScore = state.WisdomScore
+	if state.StreamOfThoughts != nil {
+		p.StreamOfThoughts = state.StreamOfThoughts
+	}
+	if state.MoodHistory != nil {
+		p.MoodHistory = state.MoodHistory
+	}
+	if state.StateHistory != nil {
+		p.StateHistory = state.StateHistory
+	}
+	if state.Journal != nil {
+		p.Journal = state.Journal
+	}
+	if state.Digests != nil {
+		p.Digests = state.Digests
+	}
+	// State saved before onboarding existed belongs to a playmate that has
+	// already woken, which always leaves a wonder or an interest behind
+	p.Onboarded = state.Onboarded || len(state.Wonders) > 0 || len(state.Interests) > 0
+	p.reserveLoadedIDs()
 
+	p.logger.Info("loaded playmate state", "path", p.persistPath)
 	return nil
 }
 
@@ -638,6 +1208,42 @@ func max(a, b float64) float64 {
 	return b
 }
 
+var (
+	positiveWords = map[string]bool{
+		"good": true, "great": true, "love": true, "happy": true, "wonderful": true,
+		"amazing": true, "beautiful": true, "joy": true, "glad": true, "thanks": true,
+		"thank": true, "excellent": true, "fun": true, "delightful": true, "fascinating": true,
+		"like": true, "enjoy": true, "awesome": true, "kind": true, "hope": true,
+	}
+	negativeWords = map[string]bool{
+		"bad": true, "sad": true, "hate": true, "angry": true, "terrible": true,
+		"awful": true, "upset": true, "annoyed": true, "boring": true, "hurt": true,
+		"worried": true, "afraid": true, "lonely": true, "tired": true, "frustrated": true,
+		"wrong": true, "horrible": true, "miserable": true, "fear": true, "disappointed": true,
+	}
+)
+
+// estimateSentiment scores text from -1.0 to 1.0 using a small word lexicon
+func estimateSentiment(text string) float64 {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '\''
+	})
+
+	positive, negative := 0, 0
+	for _, w := range words {
+		if positiveWords[w] {
+			positive++
+		} else if negativeWords[w] {
+			negative++
+		}
+	}
+
+	if positive+negative == 0 {
+		return 0
+	}
+	return float64(positive-negative) / float64(positive+negative)
+}
+
 func mergeKeywords(existing, new []string) []string {
 	seen := make(map[string]bool)
 	for _, k := range existing {
@@ -653,7 +1259,7 @@ func mergeKeywords(existing, new []string) []string {
 }
 
 
-// LearnSkill adds or improves a skill
+// LearnSkill adds or improves a skill, returning a copy of it
 func (p *Playmate) LearnSkill(name, description string) *Skill {
 	p.mu.Lock()
 	defer p.mu.Unlock()
@@ -662,9 +1268,9 @@ func (p *Playmate) LearnSkill(name, description string) *Skill {
 	if existing, ok := p.Skills[id]; ok {
 		existing.Proficiency = min(1.0, existing.Proficiency+0.05)
 		existing.PracticeCount++
-		existing.LastPracticed = time.Now()
+		existing.LastPracticed = p.clock.Now()
 		p.dirty = true
-		return existing
+		return existing.clone()
 	}
 
 	newSkill := &Skill{
@@ -673,12 +1279,12 @@ func (p *Playmate) LearnSkill(name, description string) *Skill {
 		Description: description,
 		Proficiency: 0.1,
 		PracticeCount: 1,
-		LastPracticed: time.Now(),
+		LastPracticed: p.clock.Now(),
 		Milestones:  make([]string, 0),
 	}
 	p.Skills[id] = newSkill
 	p.dirty = true
-	return newSkill
+	return newSkill.clone()
 }
 
 // SendMessage adds a message to a discussion
@@ -688,29 +1294,34 @@ func (p *Playmate) SendMessage(discussionID, from, content string) (*DiscussionM
 
 	discussion, ok := p.Discussions[discussionID]
 	if !ok {
-		return nil, fmt.Errorf("discussion not found: %s", discussionID)
+		return nil, fmt.Errorf("%w: %s", ErrDiscussionNotFound, discussionID)
 	}
 
 	if !discussion.Active {
-		return nil, fmt.Errorf("discussion is not active: %s", discussionID)
+		return nil, fmt.Errorf("%w: %s", ErrDiscussionInactive, discussionID)
 	}
 
 	msg := DiscussionMessage{
-		ID:        fmt.Sprintf("msg_%d", time.Now().UnixNano()),
+		ID:        p.ids.NewID("msg"),
 		From:      from,
 		Content:   content,
-		Timestamp: time.Now(),
-		Sentiment: 0, // Placeholder for sentiment analysis
+		Timestamp: p.clock.Now(),
+		Sentiment: estimateSentiment(content),
 	}
 
+	discussion.Depth += p.depthScorer(msg, discussion.Messages)
 	discussion.Messages = append(discussion.Messages, msg)
-	discussion.LastEngaged = time.Now()
+	discussion.LastEngaged = p.clock.Now()
+	p.autoLearnInterests(discussion, msg)
+	p.adjustMood(msg.Sentiment*0.2, "message")
+	p.evaluateWonderTriggers(MessageEvent{DiscussionID: discussionID, Message: msg})
+	p.noteInteraction()
 	p.dirty = true
 
 	return &msg, nil
 }
 
-// GetActiveDiscussions returns all active discussions
+// GetActiveDiscussions returns copies of all active discussions
 func (p *Playmate) GetActiveDiscussions() []*Discussion {
 	p.mu.RLock()
 	defer p.mu.RUnlock()
@@ -718,8 +1329,78 @@ func (p *Playmate) GetActiveDiscussions() []*Discussion {
 	active := make([]*Discussion, 0)
 	for _, d := range p.Discussions {
 		if d.Active {
-			active = append(active, d)
+			active = append(active, d.clone())
 		}
 	}
 	return active
 }
+
+// GetDiscussion returns a copy of the discussion with the given ID
+func (p *Playmate) GetDiscussion(id string) (*Discussion, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	discussion, ok := p.Discussions[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrDiscussionNotFound, id)
+	}
+	return discussion.clone(), nil
+}
+
+// ListInterests returns copies of all interests, ordered by ID
+func (p *Playmate) ListInterests() []*Interest {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	interests := make([]*Interest, 0, len(p.Interests))
+	for _, interest := range p.Interests {
+		interests = append(interests, interest.clone())
+	}
+	sort.Slice(interests, func(i, j int) bool {
+		return interests[i].ID < interests[j].ID
+	})
+	return interests
+}
+
+// ListSkills returns copies of all skills, ordered by ID
+func (p *Playmate) ListSkills() []*Skill {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	skills := make([]*Skill, 0, len(p.Skills))
+	for _, skill := range p.Skills {
+		skills = append(skills, skill.clone())
+	}
+	sort.Slice(skills, func(i, j int) bool {
+		return skills[i].ID < skills[j].ID
+	})
+	return skills
+}
+
+// clone returns a deep copy of the interest
+func (i *Interest) clone() *Interest {
+	c := *i
+	c.Keywords = append([]string(nil), i.Keywords...)
+	c.Insights = append([]string(nil), i.Insights...)
+	return &c
+}
+
+// clone returns a deep copy of the skill
+func (s *Skill) clone() *Skill {
+	c := *s
+	c.Milestones = append([]string(nil), s.Milestones...)
+	return &c
+}
+
+// clone returns a deep copy of the discussion
+func (d *Discussion) clone() *Discussion {
+	c := *d
+	c.Participants = append([]string(nil), d.Participants...)
+	c.Messages = append([]DiscussionMessage(nil), d.Messages...)
+	c.Insights = append([]string(nil), d.Insights...)
+	if d.EndedAt != nil {
+		endedAt := *d.EndedAt
+		c.EndedAt = &endedAt
+	}
+	return &c
+}
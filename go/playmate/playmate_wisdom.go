@@ -0,0 +1,69 @@
+package playmate
+
+// AttachWisdom links a wisdom cultivator as the source of the playmate's
+// WisdomScore, which then tracks the cultivator's overall score. The score is
+// read live by GetWisdomScore, GetState, and Save, and the WisdomScore field is
+// refreshed on attach and on every autonomous tick. Pass nil to detach, which
+// keeps the last synced score.
+//
+// The cultivator's metrics are always read before taking the playmate's lock,
+// so the two locks are never held together.
+func (p *Playmate) AttachWisdom(wc *WisdomCultivator) {
+	var score float64
+	if wc != nil {
+		score = wc.GetMetrics().OverallScore
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.wisdom = wc
+	if wc != nil {
+		p.setWisdomScore(score)
+	}
+}
+
+// GetWisdomScore returns the playmate's wisdom score, taken from the attached
+// cultivator if there is one
+func (p *Playmate) GetWisdomScore() float64 {
+	return p.wisdomScore()
+}
+
+// refreshWisdomScore syncs WisdomScore with the attached cultivator
+func (p *Playmate) refreshWisdomScore() {
+	p.mu.RLock()
+	wc := p.wisdom
+	p.mu.RUnlock()
+	if wc == nil {
+		return
+	}
+	score := wc.GetMetrics().OverallScore
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	// Skip the update if the cultivator was swapped while reading its metrics
+	if p.wisdom == wc {
+		p.setWisdomScore(score)
+	}
+}
+
+// wisdomScore returns the attached cultivator's overall score, or the stored
+// score when none is attached (must not hold lock)
+func (p *Playmate) wisdomScore() float64 {
+	p.mu.RLock()
+	wc, score := p.wisdom, p.WisdomScore
+	p.mu.RUnlock()
+
+	if wc == nil {
+		return score
+	}
+	return wc.GetMetrics().OverallScore
+}
+
+// setWisdomScore stores a synced wisdom score (must hold write lock)
+func (p *Playmate) setWisdomScore(score float64) {
+	if score != p.WisdomScore {
+		p.WisdomScore = score
+		p.dirty = true
+	}
+}
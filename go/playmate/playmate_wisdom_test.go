@@ -0,0 +1,105 @@
+package playmate
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestAttachWisdomTracksOverallScore(t *testing.T) {
+	p, _ := newTestPlaymate(t, nil)
+	wc, _ := newTestCultivator(t, &WisdomConfig{InitialMetrics: uniformMetrics(0.3)})
+
+	if got := p.GetWisdomScore(); got != 0 {
+		t.Fatalf("wisdom score before attaching = %v, want 0", got)
+	}
+	p.AttachWisdom(wc)
+	initial := wc.GetMetrics().OverallScore
+	if initial == 0 {
+		t.Fatal("cultivator has no overall score")
+	}
+	if got := p.GetWisdomScore(); got != initial {
+		t.Errorf("wisdom score after attaching = %v, want %v", got, initial)
+	}
+
+	// Growth shows up immediately, and in the field on the next tick
+	wc.AddInsight(context.Background(), "patience opens people up", "conversation", 1.0)
+	grown := wc.GetMetrics().OverallScore
+	if grown <= initial {
+		t.Fatalf("overall score did not grow: %v to %v", initial, grown)
+	}
+	if got := p.GetWisdomScore(); got != grown {
+		t.Errorf("wisdom score after growth = %v, want %v", got, grown)
+	}
+	if got := p.GetState()["wisdom_score"]; got != grown {
+		t.Errorf("state wisdom_score = %v, want %v", got, grown)
+	}
+	p.refreshWisdomScore()
+	p.mu.RLock()
+	field := p.WisdomScore
+	p.mu.RUnlock()
+	if field != grown {
+		t.Errorf("WisdomScore after refresh = %v, want %v", field, grown)
+	}
+
+	// Detaching keeps the last synced score
+	p.AttachWisdom(nil)
+	wc.AddInsight(context.Background(), "rest before deciding", "reflection", 1.0)
+	if got := p.GetWisdomScore(); got != grown {
+		t.Errorf("wisdom score after detaching = %v, want %v", got, grown)
+	}
+}
+
+func TestAttachedWisdomScoreSaved(t *testing.T) {
+	store := &memoryStore{}
+	configure := func(c *PlaymateConfig) {
+		c.PersistPath = "playmate"
+		c.Store = store
+	}
+	p, _ := newTestPlaymate(t, configure)
+	wc, _ := newTestCultivator(t, &WisdomConfig{InitialMetrics: uniformMetrics(0.3)})
+	p.AttachWisdom(wc)
+
+	// Save reads the live score, without waiting for a tick
+	wc.AddInsight(context.Background(), "patience opens people up", "conversation", 1.0)
+	want := wc.GetMetrics().OverallScore
+	if err := p.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, _ := newTestPlaymate(t, configure)
+	if got := loaded.GetWisdomScore(); got != want {
+		t.Errorf("loaded wisdom score = %v, want %v", got, want)
+	}
+}
+
+func TestWisdomScoreReadOutsidePlaymateLock(t *testing.T) {
+	p, _ := newTestPlaymate(t, nil)
+	wc, _ := newTestCultivator(t, nil)
+	p.AttachWisdom(wc)
+
+	// While the cultivator is busy, reading the score waits on it, but must
+	// not hold the playmate's lock and stall everything else
+	wc.mu.Lock()
+	read := make(chan struct{})
+	go func() {
+		p.GetState()
+		close(read)
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	written := make(chan struct{})
+	go func() {
+		p.SetQuietMode(true)
+		close(written)
+	}()
+	select {
+	case <-written:
+	case <-time.After(2 * time.Second):
+		t.Error("playmate lock held while waiting on the cultivator")
+	}
+
+	wc.mu.Unlock()
+	<-read
+	<-written
+}
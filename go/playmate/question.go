@@ -0,0 +1,104 @@
+package playmate
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrNotCurious is returned when the playmate isn't curious enough to ask a question
+	ErrNotCurious = errors.New("not curious enough to ask")
+	// ErrNoInterests is returned when the playmate has no interests to ask about
+	ErrNoInterests = errors.New("no interests to ask about")
+)
+
+const (
+	// minQuestionCuriosity is the curiosity below which GenerateQuestion declines
+	minQuestionCuriosity = 0.2
+	// strongInterestThreshold is the strength at which an interest counts as strong
+	strongInterestThreshold = 0.5
+)
+
+// QuestionFunc phrases a question about an interest; curiosity (0.0 to 1.0)
+// indicates how probing the question should be
+type QuestionFunc func(interest *Interest, curiosity float64) (string, error)
+
+// defaultQuestionFunc builds questions from simple templates
+func defaultQuestionFunc(interest *Interest, curiosity float64) (string, error) {
+	switch {
+	case curiosity >= 0.7:
+		return fmt.Sprintf("What is the most surprising thing about %s that most people never notice?", interest.Topic), nil
+	case curiosity >= 0.4:
+		return fmt.Sprintf("How does %s connect to the other things we've talked about?", interest.Topic), nil
+	default:
+		return fmt.Sprintf("What else is there to know about %s?", interest.Topic), nil
+	}
+}
+
+// GenerateQuestion asks about the least-explored of the playmate's strong
+// interests (or of all interests if none are strong yet). Asking raises the
+// interest's engagement. It declines with ErrNotCurious when curiosity is low
+// and ErrNoInterests when there is nothing to ask about.
+func (p *Playmate) GenerateQuestion() (string, error) {
+	p.mu.Lock()
+	if p.Curiosity < minQuestionCuriosity {
+		p.mu.Unlock()
+		return "", ErrNotCurious
+	}
+	interest := p.leastExploredInterest()
+	if interest == nil {
+		p.mu.Unlock()
+		return "", ErrNoInterests
+	}
+	snapshot, curiosity := interest.clone(), p.Curiosity
+	p.mu.Unlock()
+
+	// Generate outside the lock; a custom question func may be slow
+	question, err := p.questionFunc(snapshot, curiosity)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate question: %w", err)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if current, ok := p.Interests[snapshot.ID]; ok {
+		current.EngageCount++
+		current.Engagement = min(1.0, current.Engagement+0.02)
+		current.LastEngaged = p.clock.Now()
+		p.dirty = true
+	}
+
+	return question, nil
+}
+
+// leastExploredInterest returns the strong interest with the fewest engagements,
+// preferring stronger interests on ties, or nil if there are none (must hold lock)
+func (p *Playmate) leastExploredInterest() *Interest {
+	var best *Interest
+	for _, strongOnly := range []bool{true, false} {
+		for _, interest := range p.Interests {
+			if strongOnly && interest.Strength < strongInterestThreshold {
+				continue
+			}
+			if best == nil || lessExplored(interest, best) {
+				best = interest
+			}
+		}
+		if best != nil {
+			return best
+		}
+	}
+	return nil
+}
+
+// lessExplored orders interests by engagement count, then strength, then ID
+func lessExplored(a, b *Interest) bool {
+	if a.EngageCount != b.EngageCount {
+		return a.EngageCount < b.EngageCount
+	}
+	if a.Strength != b.Strength {
+		return a.Strength > b.Strength
+	}
+	return a.ID < b.ID
+}
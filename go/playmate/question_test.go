@@ -0,0 +1,121 @@
+package playmate
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// setInterest adjusts an interest's strength and engagement count
+func setInterest(p *Playmate, id string, strength float64, engageCount int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.Interests[id].Strength = strength
+	p.Interests[id].EngageCount = engageCount
+}
+
+func TestGenerateQuestionAsksAboutLeastExploredStrongInterest(t *testing.T) {
+	var asked []string
+	var askedCuriosity float64
+	p, clock := newTestPlaymate(t, func(c *PlaymateConfig) {
+		c.CuriosityLevel = 0.8
+		c.QuestionFunc = func(interest *Interest, curiosity float64) (string, error) {
+			asked = append(asked, interest.Topic)
+			askedCuriosity = curiosity
+			return "why " + interest.Topic + "?", nil
+		}
+	})
+	tides := p.LearnInterest(InterestExploration, "tides", nil)
+	stars := p.LearnInterest(InterestExploration, "stars", nil)
+	bread := p.LearnInterest(InterestCreativity, "bread", nil)
+	setInterest(p, tides.ID, 0.9, 5)
+	setInterest(p, stars.ID, 0.6, 1)
+	setInterest(p, bread.ID, 0.2, 0) // Less explored, but weak
+	p.mu.RLock()
+	engagement := p.Interests[stars.ID].Engagement
+	p.mu.RUnlock()
+
+	clock.Advance(time.Hour)
+	question, err := p.GenerateQuestion()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if question != "why stars?" || askedCuriosity != 0.8 {
+		t.Errorf("asked %q with curiosity %v, want the stars question at 0.8", question, askedCuriosity)
+	}
+
+	p.mu.RLock()
+	got := *p.Interests[stars.ID]
+	p.mu.RUnlock()
+	if got.EngageCount != 2 {
+		t.Errorf("EngageCount = %d, want 2", got.EngageCount)
+	}
+	if got.Engagement <= engagement {
+		t.Errorf("Engagement = %v, want above %v", got.Engagement, engagement)
+	}
+	if !got.LastEngaged.Equal(clock.Now()) {
+		t.Errorf("LastEngaged = %v, want %v", got.LastEngaged, clock.Now())
+	}
+
+	// Asking keeps to the least explored until it catches up
+	for i := 0; i < 4; i++ {
+		if _, err := p.GenerateQuestion(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want := []string{"stars", "stars", "stars", "stars", "tides"}
+	for i := range want {
+		if i >= len(asked) || asked[i] != want[i] {
+			t.Fatalf("asked about %v, want %v", asked, want)
+		}
+	}
+}
+
+func TestGenerateQuestionFallsBackToWeakInterests(t *testing.T) {
+	p, _ := newTestPlaymate(t, nil)
+	tides := p.LearnInterest(InterestExploration, "tides", nil)
+	bread := p.LearnInterest(InterestCreativity, "bread", nil)
+	setInterest(p, tides.ID, 0.3, 2)
+	setInterest(p, bread.ID, 0.2, 1)
+
+	if _, err := p.GenerateQuestion(); err != nil {
+		t.Fatal(err)
+	}
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if got := p.Interests[bread.ID].EngageCount; got != 2 {
+		t.Errorf("bread EngageCount = %d, want 2 as the least explored weak interest", got)
+	}
+}
+
+func TestGenerateQuestionDeclines(t *testing.T) {
+	t.Run("not curious", func(t *testing.T) {
+		p, _ := newTestPlaymate(t, func(c *PlaymateConfig) { c.CuriosityLevel = minQuestionCuriosity / 2 })
+		p.LearnInterest(InterestExploration, "tides", nil)
+		if _, err := p.GenerateQuestion(); !errors.Is(err, ErrNotCurious) {
+			t.Errorf("error = %v, want %v", err, ErrNotCurious)
+		}
+	})
+	t.Run("no interests", func(t *testing.T) {
+		p, _ := newTestPlaymate(t, nil)
+		if _, err := p.GenerateQuestion(); !errors.Is(err, ErrNoInterests) {
+			t.Errorf("error = %v, want %v", err, ErrNoInterests)
+		}
+	})
+	t.Run("question func fails", func(t *testing.T) {
+		failure := errors.New("no words")
+		p, _ := newTestPlaymate(t, func(c *PlaymateConfig) {
+			c.QuestionFunc = func(*Interest, float64) (string, error) { return "", failure }
+		})
+		tides := p.LearnInterest(InterestExploration, "tides", nil)
+		before := tides.EngageCount
+		if _, err := p.GenerateQuestion(); !errors.Is(err, failure) {
+			t.Errorf("error = %v, want %v", err, failure)
+		}
+		p.mu.RLock()
+		defer p.mu.RUnlock()
+		if got := p.Interests[tides.ID].EngageCount; got != before {
+			t.Errorf("EngageCount = %d after a failed question, want %d", got, before)
+		}
+	})
+}
@@ -0,0 +1,21 @@
+package playmate
+
+// SetQuietMode silences or restores the playmate's autonomous activity. While
+// quiet it generates no spontaneous thoughts or wonders, but still responds to
+// discussions and direct calls.
+func (p *Playmate) SetQuietMode(quiet bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.quiet != quiet {
+		p.logger.Info("quiet mode changed", "quiet", quiet)
+	}
+	p.quiet = quiet
+}
+
+// IsQuiet reports whether quiet mode is on
+func (p *Playmate) IsQuiet() bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.quiet
+}
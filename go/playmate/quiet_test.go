@@ -0,0 +1,129 @@
+package playmate
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestQuietModeSuppressesThoughts(t *testing.T) {
+	p, _ := newTestPlaymate(t, nil)
+	ctx := context.Background()
+
+	p.SetQuietMode(true)
+	if !p.IsQuiet() {
+		t.Fatal("IsQuiet = false after SetQuietMode(true)")
+	}
+	p.mu.Lock()
+	p.Energy = 1.0
+	p.mu.Unlock()
+
+	for i := 0; i < 5; i++ {
+		p.generateThought(ctx)
+	}
+	p.mu.RLock()
+	thoughts, energy := len(p.StreamOfThoughts), p.Energy
+	p.mu.RUnlock()
+	if thoughts != 0 {
+		t.Errorf("quiet playmate generated %d thoughts", thoughts)
+	}
+	if energy != 1.0 {
+		t.Errorf("quiet playmate spent energy: %v", energy)
+	}
+
+	p.SetQuietMode(false)
+	p.generateThought(ctx)
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if len(p.StreamOfThoughts) != 1 {
+		t.Errorf("thoughts after leaving quiet mode = %d, want 1", len(p.StreamOfThoughts))
+	}
+}
+
+func TestQuietModeStillHandlesMessages(t *testing.T) {
+	p, _ := newTestPlaymate(t, nil)
+	p.RegisterWonderTrigger(WonderTriggerFunc(func(p *Playmate, event interface{}) (*WonderEvent, bool) {
+		if _, ok := event.(MessageEvent); !ok {
+			return nil, false
+		}
+		return &WonderEvent{Description: "A message", Trigger: "message", Intensity: 0.5}, true
+	}))
+	wonders := func() int {
+		p.mu.RLock()
+		defer p.mu.RUnlock()
+		return len(p.Wonders)
+	}
+
+	d := mustStartDiscussion(t, p, "music", "alice")
+	p.SetQuietMode(true)
+	if err := p.AddMessage(d.ID, "alice", "ok"); err != nil {
+		t.Fatalf("AddMessage while quiet: %v", err)
+	}
+	if _, err := p.SendMessage(d.ID, "alice", "fine"); err != nil {
+		t.Fatalf("SendMessage while quiet: %v", err)
+	}
+	got, err := p.GetDiscussion(d.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Messages) != 2 {
+		t.Errorf("messages while quiet = %d, want 2", len(got.Messages))
+	}
+	if n := wonders(); n != 0 {
+		t.Errorf("quiet playmate recorded %d wonders", n)
+	}
+
+	// Wonders the caller records directly are not autonomous
+	p.RecordWonder("Asked to wonder", "request", 0.5)
+	if n := wonders(); n != 1 {
+		t.Errorf("wonders after RecordWonder while quiet = %d, want 1", n)
+	}
+
+	p.SetQuietMode(false)
+	if err := p.AddMessage(d.ID, "alice", "ok"); err != nil {
+		t.Fatal(err)
+	}
+	if n := wonders(); n != 2 {
+		t.Errorf("wonders after leaving quiet mode = %d, want 2", n)
+	}
+}
+
+func TestQuietModeSkipsIdleLearning(t *testing.T) {
+	p, clock := newTestPlaymate(t, func(c *PlaymateConfig) { c.IdleTimeout = time.Hour })
+	p.PracticeSkill("chess", "the royal game")
+
+	p.SetQuietMode(true)
+	clock.Advance(2 * time.Hour)
+	if item := p.checkIdle(); item != nil {
+		t.Errorf("quiet playmate revisited %v while idle", item.Name)
+	}
+
+	p.SetQuietMode(false)
+	if item := p.checkIdle(); item == nil {
+		t.Error("idle playmate revisited nothing after leaving quiet mode")
+	}
+}
+
+func TestQuietModeSkipsMaintenance(t *testing.T) {
+	p, _ := newTestPlaymate(t, nil)
+	p.LearnInterest(InterestExploration, "tides", nil)
+	p.mu.RLock()
+	before := len(p.StreamOfThoughts)
+	p.mu.RUnlock()
+
+	p.SetQuietMode(true)
+	if item := p.RunMaintenance(); item != nil {
+		t.Errorf("quiet playmate revisited %v during maintenance", item.Name)
+	}
+	p.mu.RLock()
+	after := len(p.StreamOfThoughts)
+	p.mu.RUnlock()
+	if after != before {
+		t.Errorf("quiet maintenance added %d thoughts", after-before)
+	}
+
+	p.SetQuietMode(false)
+	if item := p.RunMaintenance(); item == nil {
+		t.Error("maintenance revisited nothing after leaving quiet mode")
+	}
+}
@@ -0,0 +1,103 @@
+package playmate
+
+import (
+	"errors"
+	"testing"
+)
+
+var allStates = []PlaymateState{
+	StateAwake, StateDreaming, StateResting, StateEngaged, StateReflecting, StateLearning, StatePlaying,
+}
+
+// setState forces the playmate into a state, bypassing the state machine
+func setState(p *Playmate, state PlaymateState) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.State = state
+}
+
+func TestTransitionFollowsTable(t *testing.T) {
+	p, _ := newTestPlaymate(t, nil)
+	for _, from := range allStates {
+		for _, to := range allStates {
+			setState(p, from)
+			p.mu.Lock()
+			err := p.transition(to)
+			got := p.State
+			p.mu.Unlock()
+
+			legal := from == to || canTransition(from, to)
+			switch {
+			case legal && err != nil:
+				t.Errorf("%s -> %s: unexpected error %v", from, to, err)
+			case legal && got != to:
+				t.Errorf("%s -> %s: state = %s", from, to, got)
+			case !legal && !errors.Is(err, ErrInvalidTransition):
+				t.Errorf("%s -> %s: error = %v, want %v", from, to, err, ErrInvalidTransition)
+			case !legal && got != from:
+				t.Errorf("%s -> %s: rejected transition changed state to %s", from, to, got)
+			}
+		}
+	}
+}
+
+func TestEveryStateCanReachAwake(t *testing.T) {
+	for _, from := range allStates {
+		if from != StateAwake && !canTransition(from, StateAwake) {
+			t.Errorf("%s cannot return to awake", from)
+		}
+	}
+}
+
+func TestStartDiscussionWhileResting(t *testing.T) {
+	logger := &recordingLogger{}
+	p, _ := newTestPlaymate(t, func(c *PlaymateConfig) { c.Logger = logger })
+	setState(p, StateResting)
+
+	d, err := p.StartDiscussion("tides", "ana")
+	if !errors.Is(err, ErrInvalidTransition) || d != nil {
+		t.Fatalf("StartDiscussion = %v, %v; want nil, %v", d, err, ErrInvalidTransition)
+	}
+	if state := currentState(p); state != StateResting {
+		t.Errorf("state = %s, want resting", state)
+	}
+	p.mu.RLock()
+	discussions := len(p.Discussions)
+	p.mu.RUnlock()
+	if discussions != 0 {
+		t.Errorf("%d discussions created by a rejected start, want 0", discussions)
+	}
+
+	rejected := logger.find("rejected state transition")
+	if len(rejected) != 1 {
+		t.Fatalf("got %d rejection logs, want 1", len(rejected))
+	}
+	if from, _ := rejected[0].value("from"); from != StateResting {
+		t.Errorf("rejection logged from %v, want resting", from)
+	}
+}
+
+func TestEndDiscussionAfterRestingStillEnds(t *testing.T) {
+	p, _ := newTestPlaymate(t, nil)
+	d := mustStartDiscussion(t, p, "tides", "ana")
+	p.mu.Lock()
+	if err := p.transition(StateDreaming); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.transition(StateResting); err != nil {
+		t.Fatal(err)
+	}
+	p.mu.Unlock()
+
+	if err := p.EndDiscussion(d.ID); err != nil {
+		t.Fatal(err)
+	}
+	if state := currentState(p); state != StateResting {
+		t.Errorf("state = %s, want resting since reflecting is not reachable", state)
+	}
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.Discussions[d.ID].Active {
+		t.Error("discussion still active")
+	}
+}
@@ -0,0 +1,103 @@
+package playmate
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/o9nn/un9n/go/vectormem"
+)
+
+func TestSharedStoreRoundTripWithoutDisk(t *testing.T) {
+	// Keys look like paths, but nothing should be written there
+	dir := t.TempDir()
+	store := &memoryStore{}
+	playmateConfig := func(c *PlaymateConfig) {
+		c.PersistPath = filepath.Join(dir, "playmate")
+		c.Store = store
+	}
+	wisdomConfig := func() *WisdomConfig {
+		return &WisdomConfig{PersistPath: filepath.Join(dir, "wisdom"), Store: store}
+	}
+	memoryConfig := func() *vectormem.HypergraphConfig {
+		config := vectormem.DefaultConfig()
+		config.PersistPath = filepath.Join(dir, "memories")
+		config.Store = store
+		return config
+	}
+
+	p, _ := newTestPlaymate(t, playmateConfig)
+	skill := p.PracticeSkill("juggling", "keeping three balls up")
+	wc, _ := newTestCultivator(t, wisdomConfig())
+	principle := wc.AddPrinciple("Listen before answering", []WisdomDimension{DimensionCompassion}, "test")
+	mem, err := vectormem.NewHypergraphMemory(memoryConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mem.Stop()
+	added, err := mem.Add(context.Background(), vectormem.EpisodicMemory, "juggled at the fair", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, save := range []func() error{p.Save, wc.Save, mem.Save} {
+		if err := save(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(store.data) != 3 {
+		t.Errorf("store holds %d keys, want one each for playmate, wisdom and memory", len(store.data))
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("saving wrote %d files to disk", len(entries))
+	}
+
+	loaded, _ := newTestPlaymate(t, playmateConfig)
+	loaded.mu.RLock()
+	gotSkill := loaded.Skills[skill.ID]
+	loaded.mu.RUnlock()
+	if gotSkill == nil || gotSkill.Proficiency != skill.Proficiency {
+		t.Errorf("loaded skill = %+v, want proficiency %v", gotSkill, skill.Proficiency)
+	}
+
+	loadedWisdom, _ := newTestCultivator(t, wisdomConfig())
+	if got := loadedWisdom.Principles[principle.ID]; got == nil || got.Statement != principle.Statement {
+		t.Errorf("loaded principle = %+v, want %q", got, principle.Statement)
+	}
+
+	loadedMem, err := vectormem.NewHypergraphMemory(memoryConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer loadedMem.Stop()
+	if got := loadedMem.Recent("", -1); len(got) != 1 || got[0].ID != added.ID {
+		t.Errorf("loaded memories = %+v, want %s", got, added.ID)
+	}
+}
+
+// failingStore is a persist.Store whose writes always fail
+type failingStore struct{ memoryStore }
+
+func (s *failingStore) Write(key string, data []byte) error {
+	return errors.New("store unavailable")
+}
+
+func TestStoreErrors(t *testing.T) {
+	// A missing key means a first run, not a failure
+	p, _ := newTestPlaymate(t, func(c *PlaymateConfig) {
+		c.PersistPath = "playmate"
+		c.Store = &failingStore{}
+	})
+	p.PracticeSkill("juggling", "keeping three balls up")
+	if err := p.Save(); err == nil {
+		t.Error("Save to a failing store succeeded")
+	}
+
+	wc, _ := newTestCultivator(t, &WisdomConfig{PersistPath: "wisdom", Store: &failingStore{}})
+	wc.AddPrinciple("Listen before answering", []WisdomDimension{DimensionCompassion}, "test")
+	if err := wc.Save(); err == nil {
+		t.Error("wisdom Save to a failing store succeeded")
+	}
+}
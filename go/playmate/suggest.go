@@ -0,0 +1,126 @@
+package playmate
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/o9nn/un9n/go/vectormem"
+)
+
+// InterestCandidate is a potential new interest discovered from stored memories
+type InterestCandidate struct {
+	Topic     string           `json:"topic"`
+	Keywords  []string         `json:"keywords"`
+	Category  InterestCategory `json:"category"`
+	Support   int              `json:"support"` // Number of memories in the cluster
+	MemoryIDs []string         `json:"memory_ids"`
+}
+
+// SuggestInterests proposes up to n new interests by clustering recent episodic
+// memories. Clusters are the connected components of the memory graph among the
+// recent memories; each cluster's most salient keyword becomes its topic.
+// Topics already covered by an existing interest are skipped, and when clusters
+// share a topic only the largest proposes it.
+func (p *Playmate) SuggestInterests(mem *vectormem.HypergraphMemory, n int) []InterestCandidate {
+	candidates := make([]InterestCandidate, 0)
+	if mem == nil || n <= 0 {
+		return candidates
+	}
+
+	recent := mem.Recent(vectormem.EpisodicMemory, 200)
+	clusters := connectedClusters(recent)
+
+	// Larger clusters claim a shared topic before smaller ones
+	sort.SliceStable(clusters, func(i, j int) bool {
+		return len(clusters[i]) > len(clusters[j])
+	})
+
+	p.mu.RLock()
+	known := make(map[string]bool)
+	for _, interest := range p.Interests {
+		known[strings.ToLower(interest.Topic)] = true
+		for _, k := range interest.Keywords {
+			known[strings.ToLower(k)] = true
+		}
+	}
+	p.mu.RUnlock()
+
+	seen := make(map[string]bool)
+	for _, cluster := range clusters {
+		texts := make([]string, len(cluster))
+		ids := make([]string, len(cluster))
+		for i, m := range cluster {
+			texts[i] = m.Content
+			ids[i] = m.ID
+		}
+
+		keywords := extractKeywords(texts, 5)
+		if len(keywords) == 0 {
+			continue
+		}
+
+		topic := keywords[0]
+		if known[topic] || seen[topic] {
+			continue
+		}
+		seen[topic] = true
+
+		sort.Strings(ids)
+		candidates = append(candidates, InterestCandidate{
+			Topic:     topic,
+			Keywords:  keywords,
+			Category:  InterestKnowledge,
+			Support:   len(cluster),
+			MemoryIDs: ids,
+		})
+	}
+
+	// Strongest-supported clusters first
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Support != candidates[j].Support {
+			return candidates[i].Support > candidates[j].Support
+		}
+		return candidates[i].Topic < candidates[j].Topic
+	})
+
+	if len(candidates) > n {
+		candidates = candidates[:n]
+	}
+	return candidates
+}
+
+// connectedClusters groups memories into connected components using only
+// connections between memories in the given set
+func connectedClusters(memories []vectormem.MemoryView) [][]vectormem.MemoryView {
+	byID := make(map[string]vectormem.MemoryView, len(memories))
+	for _, m := range memories {
+		byID[m.ID] = m
+	}
+
+	visited := make(map[string]bool, len(memories))
+	clusters := make([][]vectormem.MemoryView, 0)
+	for _, m := range memories {
+		if visited[m.ID] {
+			continue
+		}
+
+		cluster := make([]vectormem.MemoryView, 0)
+		stack := []vectormem.MemoryView{m}
+		visited[m.ID] = true
+		for len(stack) > 0 {
+			current := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			cluster = append(cluster, current)
+
+			for _, connID := range current.Connections {
+				if next, ok := byID[connID]; ok && !visited[connID] {
+					visited[connID] = true
+					stack = append(stack, next)
+				}
+			}
+		}
+		clusters = append(clusters, cluster)
+	}
+
+	return clusters
+}
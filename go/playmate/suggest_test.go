@@ -0,0 +1,72 @@
+package playmate
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/o9nn/un9n/go/vectormem"
+)
+
+// addCluster adds connected episodic memories, returning their IDs
+func addCluster(t *testing.T, mem *vectormem.HypergraphMemory, contents ...string) []string {
+	t.Helper()
+	ids := make([]string, len(contents))
+	for i, content := range contents {
+		m, err := mem.Add(context.Background(), vectormem.EpisodicMemory, content, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids[i] = m.ID
+		if i > 0 {
+			if err := mem.Connect(ids[0], m.ID); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func TestSuggestInterests(t *testing.T) {
+	p, _ := newTestPlaymate(t, nil)
+	p.LearnInterest(InterestExploration, "tide", nil)
+
+	mem, err := vectormem.NewHypergraphMemory(vectormem.DefaultConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(mem.Stop)
+
+	saturn := addCluster(t, mem, "telescope pointed at saturn", "saturn rings through the telescope", "saturn and its moon titan")
+	sourdough := addCluster(t, mem, "sourdough starter bubbling", "fed the sourdough starter")
+	addCluster(t, mem, "tide pools at low tide", "crabs in the tide pools")
+	// A lone memory whose topic the larger sourdough cluster already claims
+	addCluster(t, mem, "sourdough sourdough crumb")
+
+	got := p.SuggestInterests(mem, 5)
+	var topics []string
+	for _, c := range got {
+		topics = append(topics, c.Topic)
+	}
+	if want := []string{"saturn", "sourdough"}; !reflect.DeepEqual(topics, want) {
+		t.Fatalf("suggested topics %v, want %v", topics, want)
+	}
+	if got[0].Support != 3 || !reflect.DeepEqual(got[0].MemoryIDs, saturn) {
+		t.Errorf("saturn candidate = %+v, want the three saturn memories", got[0])
+	}
+	if got[1].Support != 2 || !reflect.DeepEqual(got[1].MemoryIDs, sourdough) {
+		t.Errorf("sourdough candidate = %+v, want the two connected memories", got[1])
+	}
+	if kw := got[0].Keywords; len(kw) < 2 || kw[1] != "telescope" {
+		t.Errorf("saturn keywords = %v, want telescope second", kw)
+	}
+
+	if limited := p.SuggestInterests(mem, 1); len(limited) != 1 || limited[0].Topic != "saturn" {
+		t.Errorf("SuggestInterests(1) = %+v, want only saturn", limited)
+	}
+	if none := p.SuggestInterests(nil, 5); len(none) != 0 {
+		t.Errorf("SuggestInterests(nil) = %+v, want none", none)
+	}
+}
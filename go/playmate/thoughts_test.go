@@ -0,0 +1,89 @@
+package playmate
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestThoughtCadence(t *testing.T) {
+	p, _ := newTestPlaymate(t, func(c *PlaymateConfig) { c.ThoughtInterval = 5 * time.Millisecond })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := p.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	thoughts := func() int {
+		p.mu.RLock()
+		defer p.mu.RUnlock()
+		return len(p.StreamOfThoughts)
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for thoughts() < 3 {
+		if time.Now().After(deadline) {
+			t.Fatalf("only %d thoughts after 2s at a 5ms interval", thoughts())
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestThoughtEnergyCost(t *testing.T) {
+	p, _ := newTestPlaymate(t, func(c *PlaymateConfig) { c.ThoughtEnergyCost = 0.25 })
+	p.mu.Lock()
+	p.Energy = 1.0
+	p.mu.Unlock()
+
+	for i := 0; i < 5; i++ {
+		p.generateThought(context.Background())
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.Energy != 0 {
+		t.Errorf("energy after five thoughts at 0.25 each = %v, want 0", p.Energy)
+	}
+}
+
+func TestMaxThoughtsTrimsIntoDigest(t *testing.T) {
+	p, _ := newTestPlaymate(t, func(c *PlaymateConfig) { c.MaxThoughts = 10 })
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for i := 0; i < 10; i++ {
+		p.appendThought(fmt.Sprintf("thought %d", i))
+	}
+	if len(p.StreamOfThoughts) != 10 || len(p.Digests) != 0 {
+		t.Fatalf("at the cap: %d thoughts, %d digests; want 10 and none", len(p.StreamOfThoughts), len(p.Digests))
+	}
+
+	// Exceeding the cap keeps the newest half and digests the rest
+	p.appendThought("thought 10")
+	if len(p.StreamOfThoughts) != 5 || p.StreamOfThoughts[0] != "thought 6" {
+		t.Errorf("stream after trimming = %v, want thoughts 6 through 10", p.StreamOfThoughts)
+	}
+	if len(p.Digests) != 1 || p.Digests[0].Count != 6 || p.Digests[0].Thoughts[0] != "thought 0" {
+		t.Errorf("digests = %+v, want one holding thoughts 0 through 5", p.Digests)
+	}
+}
+
+func TestThoughtConfigValidation(t *testing.T) {
+	tests := []struct {
+		name      string
+		configure func(*PlaymateConfig)
+	}{
+		{"negative interval", func(c *PlaymateConfig) { c.ThoughtInterval = -time.Second }},
+		{"negative cost", func(c *PlaymateConfig) { c.ThoughtEnergyCost = -0.1 }},
+		{"cost above one", func(c *PlaymateConfig) { c.ThoughtEnergyCost = 1.5 }},
+		{"negative cap", func(c *PlaymateConfig) { c.MaxThoughts = -1 }},
+	}
+	for _, tt := range tests {
+		config := DefaultPlaymateConfig()
+		tt.configure(config)
+		if _, err := NewPlaymate(config); err == nil {
+			t.Errorf("%s: NewPlaymate succeeded", tt.name)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package playmate
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWakeRestFollowsClock(t *testing.T) {
+	p, clock := newTestPlaymate(t, nil)
+
+	// 09:00 falls within the default 06:00-22:00 wake window
+	p.updateWakeRest()
+	if got := currentState(p); got != StateAwake {
+		t.Fatalf("state at 09:00 = %s, want awake", got)
+	}
+
+	clock.Advance(12*time.Hour + 59*time.Minute)
+	p.updateWakeRest()
+	if got := currentState(p); got != StateAwake {
+		t.Fatalf("state at 21:59 = %s, want awake", got)
+	}
+
+	clock.Advance(time.Minute)
+	p.updateWakeRest()
+	if got := currentState(p); got != StateDreaming {
+		t.Fatalf("state at 22:00 = %s, want dreaming", got)
+	}
+
+	clock.Advance(8 * time.Hour)
+	p.updateWakeRest()
+	if got := currentState(p); got != StateAwake {
+		t.Fatalf("state at 06:00 = %s, want awake", got)
+	}
+	p.mu.RLock()
+	energy := p.Energy
+	p.mu.RUnlock()
+	if energy != 1.0 {
+		t.Errorf("energy after waking = %v, want 1.0", energy)
+	}
+}
+
+func TestWakeRestUsesLocation(t *testing.T) {
+	// 09:00 UTC is 19:00 at UTC+10
+	p, clock := newTestPlaymate(t, func(c *PlaymateConfig) {
+		c.Location = time.FixedZone("UTC+10", 10*60*60)
+	})
+
+	p.updateWakeRest()
+	if got := currentState(p); got != StateAwake {
+		t.Fatalf("state at 19:00 local = %s, want awake", got)
+	}
+
+	clock.Advance(3 * time.Hour)
+	p.updateWakeRest()
+	if got := currentState(p); got != StateDreaming {
+		t.Fatalf("state at 22:00 local = %s, want dreaming", got)
+	}
+}
+
+func TestIsWakingHour(t *testing.T) {
+	tests := []struct {
+		wake, rest, hour int
+		want             bool
+	}{
+		{6, 22, 5, false},
+		{6, 22, 6, true},
+		{6, 22, 21, true},
+		{6, 22, 22, false},
+		// A window that wraps past midnight
+		{20, 4, 19, false},
+		{20, 4, 23, true},
+		{20, 4, 0, true},
+		{20, 4, 4, false},
+	}
+	for _, tt := range tests {
+		p := &Playmate{Config: &PlaymateConfig{WakeHour: tt.wake, RestHour: tt.rest}}
+		if got := p.isWakingHour(tt.hour); got != tt.want {
+			t.Errorf("wake %d rest %d: isWakingHour(%d) = %v, want %v", tt.wake, tt.rest, tt.hour, got, tt.want)
+		}
+	}
+}
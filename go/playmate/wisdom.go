@@ -3,13 +3,19 @@ package playmate
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
+	"math/rand"
 	"os"
-	"path/filepath"
+	"sort"
+	"strings"
 	"sync"
 	"time"
+
+	"github.com/o9nn/un9n/go/events"
+	"github.com/o9nn/un9n/go/persist"
+	"github.com/o9nn/un9n/go/vectormem"
 )
 
 // WisdomDimension represents a dimension of wisdom
@@ -32,6 +38,17 @@ const (
 	DimensionTranscendence WisdomDimension = "transcendence"
 )
 
+// allDimensions lists the seven dimensions in canonical order
+var allDimensions = []WisdomDimension{
+	DimensionUnderstanding,
+	DimensionPerspective,
+	DimensionIntegration,
+	DimensionReflection,
+	DimensionCompassion,
+	DimensionEquanimity,
+	DimensionTranscendence,
+}
+
 // WisdomPrinciple represents an accumulated wisdom principle
 type WisdomPrinciple struct {
 	ID          string            `json:"id"`
@@ -42,6 +59,10 @@ type WisdomPrinciple struct {
 	CreatedAt   time.Time         `json:"created_at"`
 	Validations int               `json:"validations"`
 	Refinements []string          `json:"refinements"`
+	DerivedFrom []string          `json:"derived_from,omitempty"` // Parent principle or insight IDs
+	LinkedTo    []string          `json:"linked_to,omitempty"`    // Related principle IDs
+	Insights    []string          `json:"insights,omitempty"`     // Supporting insight IDs
+	Archived    bool              `json:"archived,omitempty"`     // Hidden from active use but kept for provenance
 }
 
 // WisdomInsight represents a moment of insight
@@ -51,7 +72,8 @@ type WisdomInsight struct {
 	Trigger     string    `json:"trigger"`
 	Depth       float64   `json:"depth"`
 	Timestamp   time.Time `json:"timestamp"`
-	Connections []string  `json:"connections"` // Connected principle IDs
+	Connections []string  `json:"connections"`           // Connected principle IDs
+	Recurrences int       `json:"recurrences,omitempty"` // Times the insight was repeated after being added
 }
 
 // WisdomMetrics tracks the seven dimensions
@@ -64,7 +86,9 @@ type WisdomMetrics struct {
 	Equanimity     float64 `json:"equanimity"`
 	Transcendence  float64 `json:"transcendence"`
 	OverallScore   float64 `json:"overall_score"`
+	ConfidenceWeightedScore float64 `json:"confidence_weighted_score"`
 	GrowthRate     float64 `json:"growth_rate"`
+	Balance        float64 `json:"balance"` // How evenly the dimensions are developed (0.0 to 1.0)
 	LastUpdated    time.Time `json:"last_updated"`
 }
 
@@ -83,9 +107,51 @@ type WisdomCultivator struct {
 
 	// Configuration
 	PersistPath string
+	format      persist.Format
+	compress    bool
+	store       persist.Store
+
+	// Growth model
+	growthCurve GrowthCurve
+
+	// Scoring model, persisted so reloads score the same way
+	weights         map[WisdomDimension]float64
+	customWeights   bool
+	growthCurveName string
+	customCurve     bool
+
+	ids IDGenerator
+
+	// Emotional regulation
+	emotionalWindow   int
+	emotionalValences []float64
+
+	// Level notifications
+	onLevelUp       LevelChangeFunc
+	notifyLevelDown bool
+
+	insightHalfLife time.Duration
+
+	equanimityCoupling float64
+
+	// Insight deduplication; embeddings are cached by insight ID and not persisted
+	insightDedupThreshold float64
+	insightEmbed          vectormem.EmbeddingFunc
+	insightEmbeddings     map[string][]float64
+
+	// principleMemories maps principle IDs to the wisdom memories that hold
+	// them for RelevantPrinciples; it is not persisted
+	principleMemories map[string]string
+
+	// baseline is each dimension's starting value, which RebuildFromHistory replays from
+	baseline map[WisdomDimension]float64
 
 	// State
-	dirty bool
+	dirty     bool
+	logger    Logger
+	clock     Clock
+	closeOnce sync.Once
+	bus       *events.Bus
 }
 
 // GrowthEvent records a growth event
@@ -96,11 +162,96 @@ type GrowthEvent struct {
 	Trigger   string            `json:"trigger"`
 }
 
+// GrowthCurve maps a dimension's current value and a raw growth amount to the
+// growth actually applied, modelling diminishing returns
+type GrowthCurve func(current, amount float64) float64
+
+// LinearGrowthCurve dampens growth linearly, halving it as a dimension nears 1.0
+func LinearGrowthCurve(current, amount float64) float64 {
+	return amount * (1.0 - current*0.5)
+}
+
+// QuadraticGrowthCurve dampens growth more steeply near saturation
+func QuadraticGrowthCurve(current, amount float64) float64 {
+	return amount * (1.0 - current*current)
+}
+
+// DayGrowth is the total growth recorded on a single day
+type DayGrowth struct {
+	Date   string  `json:"date"` // YYYY-MM-DD
+	Growth float64 `json:"growth"`
+}
+
 // WisdomConfig holds configuration
 type WisdomConfig struct {
 	PersistPath string
+	Format      persist.Format // Encoding used by Save; Load detects it automatically
+	Compress    bool           // Gzip saved state; Load detects it automatically
+	Logger      Logger         // Optional; defaults to a no-op logger
+
+	// EmotionalWindow is how many recent emotional events are considered when
+	// regulating equanimity (defaults to 10)
+	EmotionalWindow int
+
+	// GrowthCurve applies diminishing returns to growth (defaults to LinearGrowthCurve)
+	GrowthCurve GrowthCurve
+	// GrowthCurveName identifies the growth curve in saved state. Set alone, it
+	// selects a built-in curve ("linear" or "quadratic").
+	GrowthCurveName string
+
+	// DimensionWeights overrides the weight of dimensions in the overall score;
+	// dimensions left out keep their default weight
+	DimensionWeights map[WisdomDimension]float64
+
+	// IDGenerator creates insight and principle IDs (defaults to sequential IDs)
+	IDGenerator IDGenerator
+
+	// Events, if set, receives a KindWisdomGrowth event for each dimension growth
+	Events *events.Bus
+
+	// OnLevelUp, if set, is called when growth moves a dimension into a higher
+	// level (see GetDimensionReport). It runs with the cultivator locked and
+	// must not call back into it.
+	OnLevelUp LevelChangeFunc
+	// NotifyLevelDown also calls OnLevelUp when a dimension falls to a lower level
+	NotifyLevelDown bool
+
+	// Store holds saved state under the key PersistPath (defaults to the local filesystem)
+	Store persist.Store
+
+	// InsightHalfLife is how long an insight takes to lose half its relevance
+	// in RelevantInsights (defaults to a week)
+	InsightHalfLife time.Duration
+
+	// EquanimityCoupling is how strongly equanimity amplifies (or, when low,
+	// dampens) compassion grown by RecordEmpatheticAct (defaults to 0.5; a
+	// negative value disables the coupling)
+	EquanimityCoupling float64
+
+	// InsightDedupThreshold is the similarity (0.0 to 1.0) at which AddInsight
+	// treats a new insight as a repeat of a recent one and reinforces that one
+	// instead (0, the default, disables deduplication; 0.85 is a reasonable
+	// threshold to opt in with)
+	InsightDedupThreshold float64
+	// InsightEmbed, if set, compares insights by embedding for deduplication
+	// instead of by word overlap
+	InsightEmbed vectormem.EmbeddingFunc
+
+	// InitialMetrics, if set, gives each dimension its starting value (0.0 to
+	// 1.0) instead of 0.1, e.g. for an already-experienced agent. Only the seven
+	// dimensions are used; saved state, including the saved baseline, still
+	// takes precedence when loaded.
+	InitialMetrics *WisdomMetrics
+
+	// Clock provides the current time for timestamps, daily growth, and
+	// windowed reports (defaults to the system clock). A fixed clock makes
+	// RenderReport reproducible.
+	Clock Clock
 }
 
+// LevelChangeFunc is notified when a dimension moves between levels
+type LevelChangeFunc func(dim WisdomDimension, from, to string)
+
 // NewWisdomCultivator creates a new wisdom cultivator
 func NewWisdomCultivator(config *WisdomConfig) (*WisdomCultivator, error) {
 	wc := &WisdomCultivator{
@@ -112,36 +263,105 @@ func NewWisdomCultivator(config *WisdomConfig) (*WisdomCultivator, error) {
 			Compassion:     0.1,
 			Equanimity:     0.1,
 			Transcendence:  0.1,
-			LastUpdated:    time.Now(),
 		},
 		Principles:    make(map[string]*WisdomPrinciple),
 		Insights:      make([]*WisdomInsight, 0),
 		DailyGrowth:   make(map[string]float64),
 		GrowthHistory: make([]GrowthEvent, 0),
+		logger:        nopLogger{},
+		clock:         realClock{},
+		growthCurve:   LinearGrowthCurve,
+		ids:           NewSequentialIDGenerator(),
+		store:         persist.FileStore{},
+
+		insightHalfLife: defaultInsightHalfLife,
+
+		equanimityCoupling: defaultEquanimityCoupling,
+
+		insightEmbeddings: make(map[string][]float64),
+
+		principleMemories: make(map[string]string),
+
+		baseline: defaultBaseline(),
+
+		weights:         copyWeights(dimensionWeights),
+		growthCurveName: "linear",
+
+		emotionalWindow:   10,
+		emotionalValences: make([]float64, 0),
 	}
 
 	if config != nil {
 		wc.PersistPath = config.PersistPath
+		wc.format = config.Format
+		wc.compress = config.Compress
+		wc.bus = config.Events
+		if config.Logger != nil {
+			wc.logger = config.Logger
+		}
+		if config.EmotionalWindow > 0 {
+			wc.emotionalWindow = config.EmotionalWindow
+		}
+		if err := wc.configureScoring(config); err != nil {
+			return nil, err
+		}
+		if config.IDGenerator != nil {
+			wc.ids = config.IDGenerator
+		}
+		wc.onLevelUp = config.OnLevelUp
+		wc.notifyLevelDown = config.NotifyLevelDown
+		if config.Store != nil {
+			wc.store = config.Store
+		}
+		if config.InsightHalfLife > 0 {
+			wc.insightHalfLife = config.InsightHalfLife
+		}
+		if config.EquanimityCoupling < 0 {
+			wc.equanimityCoupling = 0
+		} else if config.EquanimityCoupling > 0 {
+			wc.equanimityCoupling = config.EquanimityCoupling
+		}
+		if config.InsightDedupThreshold < 0 || config.InsightDedupThreshold > 1 {
+			return nil, fmt.Errorf("invalid insight dedup threshold: %v", config.InsightDedupThreshold)
+		}
+		wc.insightDedupThreshold = config.InsightDedupThreshold
+		wc.insightEmbed = config.InsightEmbed
+		if err := wc.applyInitialMetrics(config.InitialMetrics); err != nil {
+			return nil, err
+		}
+		if config.Clock != nil {
+			wc.clock = config.Clock
+		}
 	}
-
-	// Calculate initial overall score
-	wc.updateOverallScore()
-
-	// Seed with foundational principles
-	wc.seedFoundationalPrinciples()
+	wc.Metrics.LastUpdated = wc.clock.Now()
 
 	// Load from persistence
 	if wc.PersistPath != "" {
-		if err := wc.Load(); err != nil && !os.IsNotExist(err) {
+		if err := wc.Load(); err != nil && !errors.Is(err, os.ErrNotExist) {
 			return nil, fmt.Errorf("failed to load wisdom state: %w", err)
 		}
 	}
 
+	// Seed with foundational principles unless they were loaded
+	wc.seedFoundationalPrinciples()
+
+	// Calculate initial overall score
+	wc.updateOverallScore()
+
 	return wc, nil
 }
 
-// seedFoundationalPrinciples adds initial wisdom principles
+// foundationalSource is the Source of the seeded principles, distinguishing them from learned ones
+const foundationalSource = "foundational"
+
+// seedFoundationalPrinciples adds the foundational principles if none are present
 func (wc *WisdomCultivator) seedFoundationalPrinciples() {
+	for _, p := range wc.Principles {
+		if p.Source == foundationalSource {
+			return
+		}
+	}
+
 	foundational := []struct {
 		statement  string
 		dimensions []WisdomDimension
@@ -175,29 +395,45 @@ func (wc *WisdomCultivator) seedFoundationalPrinciples() {
 			Statement:   f.statement,
 			Dimensions:  f.dimensions,
 			Confidence:  0.7,
-			Source:      "foundational",
-			CreatedAt:   time.Now(),
+			Source:      foundationalSource,
+			CreatedAt:   wc.clock.Now(),
 			Validations: 1,
 			Refinements: make([]string, 0),
 		}
 	}
 }
 
-// AddInsight records a new insight
+// AddInsight records a new insight and returns a copy of it. When content
+// closely repeats a recent insight (see InsightDedupThreshold), that insight is
+// reinforced and a copy of it returned instead: its recurrence count grows and
+// it keeps the greater depth.
 func (wc *WisdomCultivator) AddInsight(ctx context.Context, content, trigger string, depth float64) *WisdomInsight {
+	// Embed before locking; the embedding function may be slow
+	embedding := wc.embedInsightContent(ctx, content)
+
 	wc.mu.Lock()
 	defer wc.mu.Unlock()
 
+	if existing := wc.findDuplicateInsight(content, embedding); existing != nil {
+		existing.Recurrences++
+		existing.Depth = max(existing.Depth, depth)
+		wc.dirty = true
+		return existing.clone()
+	}
+
 	insight := &WisdomInsight{
-		ID:          fmt.Sprintf("insight_%d", time.Now().UnixNano()),
+		ID:          wc.ids.NewID("insight"),
 		Content:     content,
 		Trigger:     trigger,
 		Depth:       depth,
-		Timestamp:   time.Now(),
+		Timestamp:   wc.clock.Now(),
 		Connections: make([]string, 0),
 	}
 
 	wc.Insights = append(wc.Insights, insight)
+	if embedding != nil {
+		wc.insightEmbeddings[insight.ID] = embedding
+	}
 
 	// Grow relevant dimensions based on depth
 	growthAmount := depth * 0.01
@@ -205,31 +441,325 @@ func (wc *WisdomCultivator) AddInsight(ctx context.Context, content, trigger str
 	wc.growDimension(DimensionReflection, growthAmount*0.5, "insight")
 
 	wc.dirty = true
-	return insight
+	return insight.clone()
+}
+
+// RecordEmpatheticAct records an act of care toward someone and grows compassion.
+// Growth is weighted by how distressed the recipient appeared to be, and
+// compassion grows more when met with equanimity (see EquanimityCoupling).
+func (wc *WisdomCultivator) RecordEmpatheticAct(ctx context.Context, description string, recipientState string) *WisdomInsight {
+	wc.mu.Lock()
+	defer wc.mu.Unlock()
+
+	distress := distressScore(recipientState)
+
+	insight := &WisdomInsight{
+		ID:          wc.ids.NewID("insight"),
+		Content:     description,
+		Trigger:     "empathetic_act",
+		Depth:       distress,
+		Timestamp:   wc.clock.Now(),
+		Connections: make([]string, 0),
+	}
+
+	wc.Insights = append(wc.Insights, insight)
+
+	// Meeting greater distress with care teaches more
+	growthAmount := 0.02 * (1.0 + distress*2.0)
+	wc.growDimension(DimensionCompassion, growthAmount*wc.equanimityFactor(), "empathetic_act")
+	wc.growDimension(DimensionReflection, growthAmount*0.3, "empathetic_act")
+
+	wc.dirty = true
+	return insight.clone()
+}
+
+// distressWords maps words describing a person's state to a distress weight
+var distressWords = map[string]float64{
+	"suicidal":    1.0,
+	"crisis":      0.9,
+	"desperate":   0.9,
+	"panic":       0.8,
+	"panicking":   0.8,
+	"grieving":    0.8,
+	"devastated":  0.8,
+	"hopeless":    0.8,
+	"distressed":  0.7,
+	"overwhelmed": 0.6,
+	"afraid":      0.6,
+	"scared":      0.6,
+	"crying":      0.6,
+	"depressed":   0.6,
+	"anxious":     0.5,
+	"hurt":        0.5,
+	"lonely":      0.5,
+	"sad":         0.4,
+	"upset":       0.4,
+	"stressed":    0.4,
+	"worried":     0.3,
+	"frustrated":  0.3,
+	"tired":       0.2,
+	"confused":    0.2,
+}
+
+// distressScore estimates distress from 0.0 to 1.0 from a free-text state description
+func distressScore(state string) float64 {
+	words := strings.FieldsFunc(strings.ToLower(state), func(r rune) bool {
+		return (r < 'a' || r > 'z') && r != '-'
+	})
+
+	score := 0.0
+	for _, w := range words {
+		if v, ok := distressWords[w]; ok {
+			// Each additional cue closes part of the remaining gap
+			score += (1.0 - score) * v
+		}
+	}
+
+	// Intensifiers push an already distressed reading higher
+	for _, w := range words {
+		if w == "very" || w == "extremely" || w == "severely" {
+			score = math.Min(1.0, score*1.2)
+		}
+	}
+
+	return score
+}
+
+// RecordEmotionalEvent feeds an emotional valence (-1.0 to 1.0) into a rolling
+// window. A steady emotional life grows equanimity; high volatility erodes it.
+func (wc *WisdomCultivator) RecordEmotionalEvent(valence float64) {
+	wc.mu.Lock()
+	defer wc.mu.Unlock()
+
+	wc.emotionalValences = append(wc.emotionalValences, math.Max(-1, math.Min(1, valence)))
+	if len(wc.emotionalValences) > wc.emotionalWindow {
+		wc.emotionalValences = wc.emotionalValences[len(wc.emotionalValences)-wc.emotionalWindow:]
+	}
+
+	// Too few samples to judge stability
+	if len(wc.emotionalValences) < 3 {
+		return
+	}
+
+	volatility := stdDev(wc.emotionalValences)
+
+	const calm, turbulent = 0.25, 0.5
+	switch {
+	case volatility < calm:
+		wc.growDimension(DimensionEquanimity, 0.01*(1.0-volatility/calm), "emotional_stability")
+		wc.dirty = true
+	case volatility > turbulent:
+		wc.growDimension(DimensionEquanimity, -0.005*math.Min(1.0, (volatility-turbulent)/turbulent), "emotional_volatility")
+		wc.dirty = true
+	}
+}
+
+// stdDev returns the population standard deviation of values
+func stdDev(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	mean := 0.0
+	for _, v := range values {
+		mean += v
+	}
+	mean /= float64(len(values))
+
+	variance := 0.0
+	for _, v := range values {
+		variance += (v - mean) * (v - mean)
+	}
+	return math.Sqrt(variance / float64(len(values)))
 }
 
 // AddPrinciple adds a new wisdom principle
 func (wc *WisdomCultivator) AddPrinciple(statement string, dimensions []WisdomDimension, source string) *WisdomPrinciple {
 	wc.mu.Lock()
 	defer wc.mu.Unlock()
+	return wc.addPrinciple(statement, dimensions, source)
+}
+
+// DerivePrinciple adds a principle derived from existing principles or insights,
+// recording its lineage so compounding wisdom can be traced back to its roots
+func (wc *WisdomCultivator) DerivePrinciple(parentIDs []string, statement string, dimensions []WisdomDimension, source string) (*WisdomPrinciple, error) {
+	wc.mu.Lock()
+	defer wc.mu.Unlock()
+
+	if len(parentIDs) == 0 {
+		return nil, fmt.Errorf("derived principle requires at least one parent")
+	}
+	for _, parentID := range parentIDs {
+		if _, ok := wc.Principles[parentID]; ok {
+			continue
+		}
+		if wc.findInsight(parentID) == nil {
+			return nil, fmt.Errorf("parent not found: %s", parentID)
+		}
+	}
+
+	principle := wc.addPrinciple(statement, dimensions, source)
+	principle.DerivedFrom = append([]string(nil), parentIDs...)
+
+	// Deriving from principles in other dimensions integrates them
+	spanned := [][]WisdomDimension{dimensions}
+	for _, parentID := range parentIDs {
+		if parent, ok := wc.Principles[parentID]; ok {
+			spanned = append(spanned, parent.Dimensions)
+		}
+	}
+	wc.growFromIntegration(spanned, "cross_domain_derivation")
+
+	return principle, nil
+}
+
+// LinkPrinciples connects two related principles. Links that bring together
+// principles spanning several distinct dimensions grow transcendence and integration.
+func (wc *WisdomCultivator) LinkPrinciples(id1, id2 string) error {
+	wc.mu.Lock()
+	defer wc.mu.Unlock()
+
+	if id1 == id2 {
+		return fmt.Errorf("cannot link principle to itself: %s", id1)
+	}
+	p1, ok := wc.Principles[id1]
+	if !ok {
+		return fmt.Errorf("principle not found: %s", id1)
+	}
+	p2, ok := wc.Principles[id2]
+	if !ok {
+		return fmt.Errorf("principle not found: %s", id2)
+	}
+
+	for _, linked := range p1.LinkedTo {
+		if linked == id2 {
+			return nil
+		}
+	}
+	p1.LinkedTo = append(p1.LinkedTo, id2)
+	p2.LinkedTo = append(p2.LinkedTo, id1)
+
+	wc.growFromIntegration([][]WisdomDimension{p1.Dimensions, p2.Dimensions}, "cross_domain_link")
+
+	wc.dirty = true
+	return nil
+}
+
+// LinkInsightToPrinciple records that an insight supports a principle,
+// connecting both sides. Linking an already connected pair is a no-op.
+func (wc *WisdomCultivator) LinkInsightToPrinciple(insightID, principleID string) error {
+	wc.mu.Lock()
+	defer wc.mu.Unlock()
+
+	insight := wc.findInsight(insightID)
+	if insight == nil {
+		return fmt.Errorf("insight not found: %s", insightID)
+	}
+	principle, ok := wc.Principles[principleID]
+	if !ok {
+		return fmt.Errorf("principle not found: %s", principleID)
+	}
+
+	for _, connected := range insight.Connections {
+		if connected == principleID {
+			return nil
+		}
+	}
+	insight.Connections = append(insight.Connections, principleID)
+	principle.Insights = append(principle.Insights, insightID)
+
+	wc.dirty = true
+	return nil
+}
+
+// minIntegrationSpan is the number of distinct dimensions a connection must
+// span before it counts as cross-domain integration
+const minIntegrationSpan = 3
+
+// growFromIntegration grows transcendence and integration in proportion to how
+// many distinct dimensions a set of connected principles spans (must hold lock)
+func (wc *WisdomCultivator) growFromIntegration(spanned [][]WisdomDimension, trigger string) {
+	distinct := make(map[WisdomDimension]bool)
+	for _, dims := range spanned {
+		for _, dim := range dims {
+			distinct[dim] = true
+		}
+	}
+	if len(distinct) < minIntegrationSpan {
+		return
+	}
+
+	diversity := float64(len(distinct)) / float64(len(allDimensions))
+	wc.growDimension(DimensionTranscendence, 0.05*diversity, trigger)
+	wc.growDimension(DimensionIntegration, 0.03*diversity, trigger)
+}
+
+// GetLineage returns the ancestor principles of a principle, nearest first.
+// Insight parents end a branch of the walk since they have no further provenance.
+func (wc *WisdomCultivator) GetLineage(id string) []*WisdomPrinciple {
+	wc.mu.RLock()
+	defer wc.mu.RUnlock()
+
+	lineage := make([]*WisdomPrinciple, 0)
+	start, ok := wc.Principles[id]
+	if !ok {
+		return lineage
+	}
+
+	visited := map[string]bool{id: true}
+	queue := append([]string(nil), start.DerivedFrom...)
+	for len(queue) > 0 {
+		parentID := queue[0]
+		queue = queue[1:]
+		if visited[parentID] {
+			continue
+		}
+		visited[parentID] = true
+
+		if parent, ok := wc.Principles[parentID]; ok {
+			lineage = append(lineage, parent)
+			queue = append(queue, parent.DerivedFrom...)
+		}
+	}
 
-	id := fmt.Sprintf("principle_%d", time.Now().UnixNano())
+	return lineage
+}
+
+// findInsight returns the insight with the given ID, or nil (must hold lock)
+func (wc *WisdomCultivator) findInsight(id string) *WisdomInsight {
+	for _, insight := range wc.Insights {
+		if insight.ID == id {
+			return insight
+		}
+	}
+	return nil
+}
+
+// addPrinciple stores a new principle and grows integration (must hold lock)
+func (wc *WisdomCultivator) addPrinciple(statement string, dimensions []WisdomDimension, source string) *WisdomPrinciple {
+	principle := wc.insertPrinciple(statement, dimensions, source)
+
+	// Grow integration dimension
+	wc.growDimension(DimensionIntegration, 0.02, "new_principle")
+
+	return principle
+}
+
+// insertPrinciple stores a new principle without any growth (must hold lock)
+func (wc *WisdomCultivator) insertPrinciple(statement string, dimensions []WisdomDimension, source string) *WisdomPrinciple {
+	id := wc.ids.NewID("principle")
 	principle := &WisdomPrinciple{
 		ID:          id,
 		Statement:   statement,
 		Dimensions:  dimensions,
 		Confidence:  0.5,
 		Source:      source,
-		CreatedAt:   time.Now(),
+		CreatedAt:   wc.clock.Now(),
 		Validations: 0,
 		Refinements: make([]string, 0),
 	}
 
 	wc.Principles[id] = principle
-
-	// Grow integration dimension
-	wc.growDimension(DimensionIntegration, 0.02, "new_principle")
-
 	wc.dirty = true
 	return principle
 }
@@ -285,47 +815,153 @@ func (wc *WisdomCultivator) GrowDimension(dimension WisdomDimension, amount floa
 // growDimension internal growth function (must hold lock)
 func (wc *WisdomCultivator) growDimension(dimension WisdomDimension, amount float64, trigger string) {
 	// Apply diminishing returns
+	wc.changeDimension(dimension, wc.growthCurve(wc.getDimensionValue(dimension), amount), trigger)
+}
+
+// changeDimension moves a dimension by delta, which may be negative, and
+// records the change as a growth event (must hold lock)
+func (wc *WisdomCultivator) changeDimension(dimension WisdomDimension, delta float64, trigger string) {
 	currentValue := wc.getDimensionValue(dimension)
-	effectiveGrowth := amount * (1.0 - currentValue*0.5)
+	effectiveGrowth := delta
+
+	// Keep the dimension within [0, 1] so the recorded delta matches the real change
+	newValue := math.Max(0, math.Min(1.0, currentValue+effectiveGrowth))
+	effectiveGrowth = newValue - currentValue
 
 	// Update dimension
-	wc.setDimensionValue(dimension, math.Min(1.0, currentValue+effectiveGrowth))
+	wc.setDimensionValue(dimension, newValue)
+	wc.notifyLevelChange(dimension, currentValue, newValue)
 
 	// Record growth event
 	event := GrowthEvent{
-		Timestamp: time.Now(),
+		Timestamp: wc.clock.Now(),
 		Dimension: dimension,
 		Delta:     effectiveGrowth,
 		Trigger:   trigger,
 	}
 	wc.GrowthHistory = append(wc.GrowthHistory, event)
 
+	wc.bus.Publish(events.Event{
+		Kind:      events.KindWisdomGrowth,
+		Source:    "wisdom",
+		Timestamp: event.Timestamp,
+		Data: map[string]interface{}{
+			"dimension": string(dimension),
+			"delta":     effectiveGrowth,
+			"trigger":   trigger,
+		},
+	})
+
 	// Update daily growth
-	today := time.Now().Format("2006-01-02")
+	today := wc.clock.Now().Format("2006-01-02")
 	wc.DailyGrowth[today] += effectiveGrowth
 
 	// Update overall score
 	wc.updateOverallScore()
-	wc.Metrics.LastUpdated = time.Now()
+	wc.Metrics.LastUpdated = wc.clock.Now()
+}
+
+// notifyLevelChange calls OnLevelUp if a dimension's change from one value to
+// another crossed a level boundary. A change spanning several levels is
+// reported once, from the old level to the new. (must hold lock)
+func (wc *WisdomCultivator) notifyLevelChange(dim WisdomDimension, from, to float64) {
+	if wc.onLevelUp == nil || (to < from && !wc.notifyLevelDown) {
+		return
+	}
+	fromLevel, toLevel := wc.getDimensionLevel(from), wc.getDimensionLevel(to)
+	if fromLevel != toLevel {
+		wc.onLevelUp(dim, fromLevel, toLevel)
+	}
+}
+
+// dimensionWeights sets each dimension's default contribution to the overall score
+var dimensionWeights = map[WisdomDimension]float64{
+	DimensionUnderstanding: 1.5,
+	DimensionPerspective:   1.2,
+	DimensionIntegration:   1.3,
+	DimensionReflection:    1.4,
+	DimensionCompassion:    1.1,
+	DimensionEquanimity:    1.0,
+	DimensionTranscendence: 1.2,
+}
+
+// weightedGeometricMean combines per-dimension values using weights, skipping
+// non-positive values; it returns 0 if every value is non-positive
+func weightedGeometricMean(value func(WisdomDimension) float64, weights map[WisdomDimension]float64) float64 {
+	totalWeight := 0.0
+	logSum := 0.0
+
+	for _, dim := range allDimensions {
+		if v := value(dim); v > 0 {
+			weight := weights[dim]
+			logSum += weight * math.Log(v)
+			totalWeight += weight
+		}
+	}
+
+	if totalWeight == 0 {
+		return 0
+	}
+	return math.Exp(logSum / totalWeight)
+}
+
+// unsupportedConfidence is the confidence assumed for a dimension no principle supports
+const unsupportedConfidence = 0.5
+
+// ConfidenceWeightedScore returns the overall score with each dimension discounted
+// by the average confidence of the principles supporting it, so growth backed by
+// well-validated principles counts for more than growth backed by untested ones
+func (wc *WisdomCultivator) ConfidenceWeightedScore() float64 {
+	wc.mu.RLock()
+	defer wc.mu.RUnlock()
+	return wc.confidenceWeightedScore()
+}
+
+// confidenceWeightedScore computes ConfidenceWeightedScore (must hold lock)
+func (wc *WisdomCultivator) confidenceWeightedScore() float64 {
+	sums := make(map[WisdomDimension]float64)
+	counts := make(map[WisdomDimension]int)
+	for _, p := range wc.Principles {
+		if p.Archived {
+			continue
+		}
+		for _, dim := range p.Dimensions {
+			sums[dim] += p.Confidence
+			counts[dim]++
+		}
+	}
+
+	return weightedGeometricMean(func(dim WisdomDimension) float64 {
+		confidence := unsupportedConfidence
+		if counts[dim] > 0 {
+			confidence = sums[dim] / float64(counts[dim])
+		}
+		return wc.getDimensionValue(dim) * confidence
+	}, wc.weights)
 }
 
 // getDimensionValue gets the current value of a dimension
 func (wc *WisdomCultivator) getDimensionValue(dim WisdomDimension) float64 {
+	return wc.Metrics.dimensionValue(dim)
+}
+
+// dimensionValue returns the value of a dimension in these metrics
+func (m *WisdomMetrics) dimensionValue(dim WisdomDimension) float64 {
 	switch dim {
 	case DimensionUnderstanding:
-		return wc.Metrics.Understanding
+		return m.Understanding
 	case DimensionPerspective:
-		return wc.Metrics.Perspective
+		return m.Perspective
 	case DimensionIntegration:
-		return wc.Metrics.Integration
+		return m.Integration
 	case DimensionReflection:
-		return wc.Metrics.Reflection
+		return m.Reflection
 	case DimensionCompassion:
-		return wc.Metrics.Compassion
+		return m.Compassion
 	case DimensionEquanimity:
-		return wc.Metrics.Equanimity
+		return m.Equanimity
 	case DimensionTranscendence:
-		return wc.Metrics.Transcendence
+		return m.Transcendence
 	default:
 		return 0
 	}
@@ -354,30 +990,12 @@ func (wc *WisdomCultivator) setDimensionValue(dim WisdomDimension, value float64
 // updateOverallScore calculates the overall wisdom score
 func (wc *WisdomCultivator) updateOverallScore() {
 	// Weighted geometric mean of all dimensions
-	weights := map[WisdomDimension]float64{
-		DimensionUnderstanding:  1.5,
-		DimensionPerspective:    1.2,
-		DimensionIntegration:    1.3,
-		DimensionReflection:     1.4,
-		DimensionCompassion:     1.1,
-		DimensionEquanimity:     1.0,
-		DimensionTranscendence:  1.2,
+	if score := weightedGeometricMean(wc.getDimensionValue, wc.weights); score > 0 {
+		wc.Metrics.OverallScore = score
 	}
+	wc.Metrics.ConfidenceWeightedScore = wc.confidenceWeightedScore()
 
-	totalWeight := 0.0
-	logSum := 0.0
-
-	for dim, weight := range weights {
-		value := wc.getDimensionValue(dim)
-		if value > 0 {
-			logSum += weight * math.Log(value)
-			totalWeight += weight
-		}
-	}
-
-	if totalWeight > 0 {
-		wc.Metrics.OverallScore = math.Exp(logSum / totalWeight)
-	}
+	wc.Metrics.Balance = wc.balanceScore()
 
 	// Calculate growth rate from recent history
 	wc.calculateGrowthRate()
@@ -391,7 +1009,7 @@ func (wc *WisdomCultivator) calculateGrowthRate() {
 	}
 
 	// Look at last 7 days
-	cutoff := time.Now().AddDate(0, 0, -7)
+	cutoff := wc.clock.Now().AddDate(0, 0, -7)
 	totalGrowth := 0.0
 	count := 0
 
@@ -422,16 +1040,59 @@ func (wc *WisdomCultivator) GetMetrics() *WisdomMetrics {
 		Equanimity:     wc.Metrics.Equanimity,
 		Transcendence:  wc.Metrics.Transcendence,
 		OverallScore:   wc.Metrics.OverallScore,
+		ConfidenceWeightedScore: wc.Metrics.ConfidenceWeightedScore,
 		GrowthRate:     wc.Metrics.GrowthRate,
+		Balance:        wc.Metrics.Balance,
 		LastUpdated:    wc.Metrics.LastUpdated,
 	}
 }
 
-// GetPrinciples returns all principles
+// BalanceScore returns how evenly the seven dimensions are developed, from 1.0
+// when all are equal down to 0.0 when all growth sits in a single dimension.
+// A high overall score with a low balance flags lopsided cultivation.
+func (wc *WisdomCultivator) BalanceScore() float64 {
+	wc.mu.RLock()
+	defer wc.mu.RUnlock()
+	return wc.balanceScore()
+}
+
+// balanceScore is one minus the coefficient of variation of the dimensions,
+// normalized by its maximum for this many dimensions (must hold lock)
+func (wc *WisdomCultivator) balanceScore() float64 {
+	values := make([]float64, len(allDimensions))
+	mean := 0.0
+	for i, dim := range allDimensions {
+		values[i] = wc.getDimensionValue(dim)
+		mean += values[i]
+	}
+	mean /= float64(len(values))
+	if mean == 0 {
+		return 1.0
+	}
+
+	maxVariation := math.Sqrt(float64(len(values) - 1))
+	return clamp(1-stdDev(values)/mean/maxVariation, 0, 1)
+}
+
+// GetPrinciples returns all active (unarchived) principles
 func (wc *WisdomCultivator) GetPrinciples() []*WisdomPrinciple {
 	wc.mu.RLock()
 	defer wc.mu.RUnlock()
 
+	principles := make([]*WisdomPrinciple, 0, len(wc.Principles))
+	for _, p := range wc.Principles {
+		if !p.Archived {
+			principles = append(principles, p)
+		}
+	}
+	return principles
+}
+
+// GetAllPrinciples returns all principles, including archived ones
+func (wc *WisdomCultivator) GetAllPrinciples() []*WisdomPrinciple {
+	wc.mu.RLock()
+	defer wc.mu.RUnlock()
+
 	principles := make([]*WisdomPrinciple, 0, len(wc.Principles))
 	for _, p := range wc.Principles {
 		principles = append(principles, p)
@@ -439,7 +1100,94 @@ func (wc *WisdomCultivator) GetPrinciples() []*WisdomPrinciple {
 	return principles
 }
 
-// GetRecentInsights returns recent insights
+// CountBySource returns the number of principles from each source
+func (wc *WisdomCultivator) CountBySource() map[string]int {
+	wc.mu.RLock()
+	defer wc.mu.RUnlock()
+
+	counts := make(map[string]int)
+	for _, p := range wc.Principles {
+		counts[p.Source]++
+	}
+	return counts
+}
+
+// dimensionSampleBias is the extra weight a principle gains when all of the
+// requested dimensions are among its own in SamplePrinciples
+const dimensionSampleBias = 2.0
+
+// SamplePrinciples draws up to n distinct principles at random, weighted by
+// confidence and, when dims is given, biased toward principles sharing those
+// dimensions. Pass a seeded r for reproducible samples; nil uses a time-seeded source.
+func (wc *WisdomCultivator) SamplePrinciples(n int, dims []WisdomDimension, r *rand.Rand) []*WisdomPrinciple {
+	if r == nil {
+		r = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+
+	wc.mu.RLock()
+	candidates := make([]*WisdomPrinciple, 0, len(wc.Principles))
+	for _, p := range wc.Principles {
+		if !p.Archived {
+			candidates = append(candidates, p)
+		}
+	}
+	wc.mu.RUnlock()
+
+	// Stable order so a seeded source yields the same sample
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].ID < candidates[j].ID })
+
+	weights := make([]float64, len(candidates))
+	total := 0.0
+	for i, p := range candidates {
+		weights[i] = p.Confidence * (1 + dimensionSampleBias*dimensionOverlap(p.Dimensions, dims))
+		total += weights[i]
+	}
+
+	sampled := make([]*WisdomPrinciple, 0, n)
+	for len(sampled) < n && total > 0 {
+		target := r.Float64() * total
+		chosen := -1
+		for i, w := range weights {
+			if w == 0 {
+				continue
+			}
+			chosen = i
+			if target < w {
+				break
+			}
+			target -= w
+		}
+		if chosen < 0 {
+			break // Only zero-weight candidates remain
+		}
+
+		sampled = append(sampled, candidates[chosen])
+		total -= weights[chosen]
+		weights[chosen] = 0
+	}
+
+	return sampled
+}
+
+// dimensionOverlap returns the fraction of wanted dimensions present in have
+func dimensionOverlap(have, wanted []WisdomDimension) float64 {
+	if len(wanted) == 0 {
+		return 0
+	}
+
+	matched := 0
+	for _, w := range wanted {
+		for _, h := range have {
+			if h == w {
+				matched++
+				break
+			}
+		}
+	}
+	return float64(matched) / float64(len(wanted))
+}
+
+// GetRecentInsights returns copies of the n most recent insights
 func (wc *WisdomCultivator) GetRecentInsights(n int) []*WisdomInsight {
 	wc.mu.RLock()
 	defer wc.mu.RUnlock()
@@ -448,7 +1196,11 @@ func (wc *WisdomCultivator) GetRecentInsights(n int) []*WisdomInsight {
 		n = len(wc.Insights)
 	}
 
-	return wc.Insights[len(wc.Insights)-n:]
+	recent := make([]*WisdomInsight, n)
+	for i, insight := range wc.Insights[len(wc.Insights)-n:] {
+		recent[i] = insight.clone()
+	}
+	return recent
 }
 
 // GetDimensionReport generates a report for a specific dimension
@@ -458,33 +1210,82 @@ func (wc *WisdomCultivator) GetDimensionReport(dim WisdomDimension) map[string]i
 
 	value := wc.getDimensionValue(dim)
 
-	// Find related principles
+	// Find related principles and the insights supporting them
 	relatedPrinciples := make([]*WisdomPrinciple, 0)
+	supportingInsights := make(map[string]bool)
 	for _, p := range wc.Principles {
+		if p.Archived {
+			continue
+		}
 		for _, d := range p.Dimensions {
 			if d == dim {
 				relatedPrinciples = append(relatedPrinciples, p)
+				for _, id := range p.Insights {
+					supportingInsights[id] = true
+				}
 				break
 			}
 		}
 	}
 
 	// Calculate recent growth for this dimension
-	recentGrowth := 0.0
-	cutoff := time.Now().AddDate(0, 0, -7)
+	recentGrowth := wc.growthSince(dim, wc.clock.Now().AddDate(0, 0, -7))
+
+	return map[string]interface{}{
+		"dimension":           dim,
+		"value":               value,
+		"recent_growth":       recentGrowth,
+		"related_principles":  len(relatedPrinciples),
+		"supporting_insights": len(supportingInsights),
+		"level":               wc.getDimensionLevel(value),
+	}
+}
+
+// GrowthSummary returns daily growth for the last n days ending today, oldest
+// first. Days without recorded growth are included with zero growth.
+func (wc *WisdomCultivator) GrowthSummary(days int) []DayGrowth {
+	wc.mu.RLock()
+	defer wc.mu.RUnlock()
+
+	if days <= 0 {
+		return []DayGrowth{}
+	}
+
+	today := wc.clock.Now()
+	summary := make([]DayGrowth, days)
+	for i := 0; i < days; i++ {
+		date := today.AddDate(0, 0, i-days+1).Format("2006-01-02")
+		summary[i] = DayGrowth{Date: date, Growth: wc.DailyGrowth[date]}
+	}
+	return summary
+}
+
+// CurrentStreak returns the number of consecutive days, ending today, with positive growth
+func (wc *WisdomCultivator) CurrentStreak() int {
+	wc.mu.RLock()
+	defer wc.mu.RUnlock()
+	return wc.currentStreak()
+}
+
+func (wc *WisdomCultivator) currentStreak() int {
+	streak := 0
+	day := wc.clock.Now()
+	for wc.DailyGrowth[day.Format("2006-01-02")] > 0 {
+		streak++
+		day = day.AddDate(0, 0, -1)
+	}
+	return streak
+}
+
+// growthSince sums the growth of a dimension recorded after cutoff
+func (wc *WisdomCultivator) growthSince(dim WisdomDimension, cutoff time.Time) float64 {
+	total := 0.0
 	for _, event := range wc.GrowthHistory {
 		if event.Dimension == dim && event.Timestamp.After(cutoff) {
-			recentGrowth += event.Delta
+			total += event.Delta
 		}
 	}
-
-	return map[string]interface{}{
-		"dimension":          dim,
-		"value":              value,
-		"recent_growth":      recentGrowth,
-		"related_principles": len(relatedPrinciples),
-		"level":              wc.getDimensionLevel(value),
-	}
+	return total
 }
 
 // getDimensionLevel returns a qualitative level for a dimension value
@@ -503,6 +1304,17 @@ func (wc *WisdomCultivator) getDimensionLevel(value float64) string {
 	}
 }
 
+// wisdomState is the persisted form of a WisdomCultivator
+type wisdomState struct {
+	Metrics       *WisdomMetrics              `json:"metrics"`
+	Principles    map[string]*WisdomPrinciple `json:"principles"`
+	Insights      []*WisdomInsight            `json:"insights"`
+	DailyGrowth   map[string]float64          `json:"daily_growth"`
+	GrowthHistory []GrowthEvent               `json:"growth_history"`
+	Scoring       *scoringConfig              `json:"scoring,omitempty"`
+	Baseline      map[WisdomDimension]float64 `json:"baseline,omitempty"` // Starting values RebuildFromHistory replays from
+}
+
 // Save persists the wisdom state
 func (wc *WisdomCultivator) Save() error {
 	if wc.PersistPath == "" {
@@ -512,76 +1324,99 @@ func (wc *WisdomCultivator) Save() error {
 	wc.mu.RLock()
 	defer wc.mu.RUnlock()
 
-	dir := filepath.Dir(wc.PersistPath)
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return fmt.Errorf("failed to create directory: %w", err)
-	}
-
-	state := map[string]interface{}{
-		"metrics":        wc.Metrics,
-		"principles":     wc.Principles,
-		"insights":       wc.Insights,
-		"daily_growth":   wc.DailyGrowth,
-		"growth_history": wc.GrowthHistory,
+	state := wisdomState{
+		Metrics:       wc.Metrics,
+		Principles:    wc.Principles,
+		Insights:      wc.Insights,
+		DailyGrowth:   wc.DailyGrowth,
+		GrowthHistory: wc.GrowthHistory,
+		Scoring:       wc.scoringConfig(),
+		Baseline:      wc.baseline,
 	}
 
-	data, err := json.MarshalIndent(state, "", "  ")
+	data, err := persist.Encode(state, wc.format)
 	if err != nil {
 		return fmt.Errorf("failed to marshal state: %w", err)
 	}
 
-	if err := os.WriteFile(wc.PersistPath, data, 0644); err != nil {
-		return fmt.Errorf("failed to write file: %w", err)
+	if wc.compress {
+		if data, err = persist.Compress(data); err != nil {
+			return fmt.Errorf("failed to compress state: %w", err)
+		}
+	}
+
+	if err := wc.store.Write(wc.PersistPath, data); err != nil {
+		wc.logger.Error("failed to save wisdom state", "path", wc.PersistPath, "error", err)
+		return fmt.Errorf("failed to write state: %w", err)
 	}
 
 	wc.dirty = false
+	wc.logger.Info("saved wisdom state", "path", wc.PersistPath)
 	return nil
 }
 
-// Load loads the wisdom state from disk
+// Close saves any unsaved changes. It is safe to call more than once;
+// later calls do nothing.
+func (wc *WisdomCultivator) Close() error {
+	var err error
+	wc.closeOnce.Do(func() {
+		wc.mu.RLock()
+		dirty := wc.dirty
+		wc.mu.RUnlock()
+
+		if dirty {
+			err = wc.Save()
+		}
+	})
+	return err
+}
+
+// Load loads the wisdom state from its store
 func (wc *WisdomCultivator) Load() error {
 	if wc.PersistPath == "" {
 		return nil
 	}
 
-	data, err := os.ReadFile(wc.PersistPath)
+	data, err := wc.store.Read(wc.PersistPath)
 	if err != nil {
 		return err
 	}
 
-	var state map[string]interface{}
-	if err := json.Unmarshal(data, &state); err != nil {
+	var state wisdomState
+	if err := persist.Decode(data, &state); err != nil {
 		return fmt.Errorf("failed to unmarshal state: %w", err)
 	}
 
-	// Restore state (simplified)
 	wc.mu.Lock()
 	defer wc.mu.Unlock()
 
-	if metricsData, ok := state["metrics"].(map[string]interface{}); ok {
-		if v, ok := metricsData["understanding"].(float64); ok {
-			wc.Metrics.Understanding = v
-		}
-		if v, ok := metricsData["perspective"].(float64); ok {
-			wc.Metrics.Perspective = v
-		}
-		if v, ok := metricsData["integration"].(float64); ok {
-			wc.Metrics.Integration = v
-		}
-		if v, ok := metricsData["reflection"].(float64); ok {
-			wc.Metrics.Reflection = v
-		}
-		if v, ok := metricsData["compassion"].(float64); ok {
-			wc.Metrics.Compassion = v
-		}
-		if v, ok := metricsData["equanimity"].(float64); ok {
-			wc.Metrics.Equanimity = v
-		}
-		if v, ok := metricsData["transcendence"].(float64); ok {
-			wc.Metrics.Transcendence = v
-		}
+	if m := state.Metrics; m != nil {
+		wc.Metrics.Understanding = m.Understanding
+		wc.Metrics.Perspective = m.Perspective
+		wc.Metrics.Integration = m.Integration
+		wc.Metrics.Reflection = m.Reflection
+		wc.Metrics.Compassion = m.Compassion
+		wc.Metrics.Equanimity = m.Equanimity
+		wc.Metrics.Transcendence = m.Transcendence
+		wc.Metrics.LastUpdated = m.LastUpdated
+	}
+	for id, principle := range state.Principles {
+		wc.Principles[id] = principle
+	}
+	if state.Insights != nil {
+		wc.Insights = state.Insights
+	}
+	if state.DailyGrowth != nil {
+		wc.DailyGrowth = state.DailyGrowth
+	}
+	if state.GrowthHistory != nil {
+		wc.GrowthHistory = state.GrowthHistory
 	}
+	wc.restoreScoring(state.Scoring)
+	wc.restoreBaseline(state.Baseline)
+	wc.reserveLoadedIDs()
 
 	wc.updateOverallScore()
+	wc.logger.Info("loaded wisdom state", "path", wc.PersistPath)
 	return nil
 }
@@ -0,0 +1,84 @@
+package playmate
+
+import (
+	"math"
+	"sort"
+)
+
+// SummaryStats describes the distribution of one metric across a population
+type SummaryStats struct {
+	Mean     float64 `json:"mean"`
+	Median   float64 `json:"median"`
+	Min      float64 `json:"min"`
+	Max      float64 `json:"max"`
+	Variance float64 `json:"variance"` // Population variance
+}
+
+// AggregateReport summarizes the metrics of a population of cultivators
+type AggregateReport struct {
+	Count      int                              `json:"count"`
+	Dimensions map[WisdomDimension]SummaryStats `json:"dimensions"`
+	Overall    SummaryStats                     `json:"overall"`
+}
+
+// AggregateMetrics computes population-level statistics per dimension and for
+// the overall score across a cohort of cultivators. Each cultivator's metrics
+// are read from a snapshot, so none are modified; nil entries are skipped.
+func AggregateMetrics(cultivators []*WisdomCultivator) AggregateReport {
+	snapshots := make([]*WisdomMetrics, 0, len(cultivators))
+	for _, wc := range cultivators {
+		if wc != nil {
+			snapshots = append(snapshots, wc.GetMetrics())
+		}
+	}
+
+	report := AggregateReport{
+		Count:      len(snapshots),
+		Dimensions: make(map[WisdomDimension]SummaryStats, len(allDimensions)),
+	}
+	if len(snapshots) == 0 {
+		return report
+	}
+
+	values := make([]float64, len(snapshots))
+	for _, dim := range allDimensions {
+		for i, m := range snapshots {
+			values[i] = m.dimensionValue(dim)
+		}
+		report.Dimensions[dim] = summarize(values)
+	}
+	for i, m := range snapshots {
+		values[i] = m.OverallScore
+	}
+	report.Overall = summarize(values)
+
+	return report
+}
+
+// summarize computes summary statistics for a non-empty set of values
+func summarize(values []float64) SummaryStats {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	n := len(sorted)
+	stats := SummaryStats{Min: sorted[0], Max: sorted[n-1]}
+
+	sum := 0.0
+	for _, v := range sorted {
+		sum += v
+	}
+	stats.Mean = sum / float64(n)
+
+	if n%2 == 1 {
+		stats.Median = sorted[n/2]
+	} else {
+		stats.Median = (sorted[n/2-1] + sorted[n/2]) / 2
+	}
+
+	for _, v := range sorted {
+		stats.Variance += math.Pow(v-stats.Mean, 2)
+	}
+	stats.Variance /= float64(n)
+
+	return stats
+}
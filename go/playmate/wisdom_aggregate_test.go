@@ -0,0 +1,72 @@
+package playmate
+
+import (
+	"math"
+	"testing"
+)
+
+func approxStats(got, want SummaryStats) bool {
+	const eps = 1e-9
+	return math.Abs(got.Mean-want.Mean) < eps && math.Abs(got.Median-want.Median) < eps &&
+		math.Abs(got.Min-want.Min) < eps && math.Abs(got.Max-want.Max) < eps &&
+		math.Abs(got.Variance-want.Variance) < eps
+}
+
+func TestAggregateMetrics(t *testing.T) {
+	cohort := make([]*WisdomCultivator, 0, 4)
+	for _, v := range []float64{0.2, 0.4, 0.9} {
+		wc, _ := newTestCultivator(t, &WisdomConfig{InitialMetrics: uniformMetrics(v)})
+		cohort = append(cohort, wc)
+	}
+	cohort = append(cohort, nil)
+
+	report := AggregateMetrics(cohort)
+	if report.Count != 3 {
+		t.Errorf("Count = %d, want 3 with the nil cultivator skipped", report.Count)
+	}
+	want := SummaryStats{Mean: 0.5, Median: 0.4, Min: 0.2, Max: 0.9, Variance: (0.09 + 0.01 + 0.16) / 3}
+	if got := report.Dimensions[DimensionCompassion]; !approxStats(got, want) {
+		t.Errorf("compassion stats = %+v, want %+v", got, want)
+	}
+	if len(report.Dimensions) != len(allDimensions) {
+		t.Errorf("report covers %d dimensions, want %d", len(report.Dimensions), len(allDimensions))
+	}
+
+	overall := make([]float64, 0, 3)
+	for _, wc := range cohort[:3] {
+		overall = append(overall, wc.GetMetrics().OverallScore)
+	}
+	if got := report.Overall; !approxStats(got, summarize(overall)) || got.Min != overall[0] || got.Max != overall[2] {
+		t.Errorf("overall stats = %+v for scores %v", got, overall)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		want   SummaryStats
+	}{
+		{"single", []float64{0.3}, SummaryStats{Mean: 0.3, Median: 0.3, Min: 0.3, Max: 0.3}},
+		{"even count", []float64{0.8, 0.2, 0.6, 0.4}, SummaryStats{Mean: 0.5, Median: 0.5, Min: 0.2, Max: 0.8, Variance: 0.05}},
+	}
+	for _, tt := range tests {
+		if got := summarize(tt.values); !approxStats(got, tt.want) {
+			t.Errorf("%s: summarize(%v) = %+v, want %+v", tt.name, tt.values, got, tt.want)
+		}
+	}
+
+	// The input is left in its original order
+	values := []float64{0.8, 0.2}
+	summarize(values)
+	if values[0] != 0.8 {
+		t.Errorf("summarize sorted its input: %v", values)
+	}
+}
+
+func TestAggregateMetricsEmpty(t *testing.T) {
+	report := AggregateMetrics(nil)
+	if report.Count != 0 || len(report.Dimensions) != 0 || report.Overall != (SummaryStats{}) {
+		t.Errorf("empty report = %+v, want zero values", report)
+	}
+}
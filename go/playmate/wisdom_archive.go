@@ -0,0 +1,68 @@
+package playmate
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ArchivePrinciple hides a principle from active use while keeping it, with
+// its lineage and links, for provenance. Archived principles can be revived.
+func (wc *WisdomCultivator) ArchivePrinciple(id string) error {
+	wc.mu.Lock()
+	defer wc.mu.Unlock()
+
+	principle, ok := wc.Principles[id]
+	if !ok {
+		return fmt.Errorf("principle not found: %s", id)
+	}
+	if principle.Archived {
+		return nil
+	}
+
+	principle.Archived = true
+	wc.updateOverallScore()
+	wc.dirty = true
+	return nil
+}
+
+// ArchiveWeakPrinciples archives every active principle whose confidence is
+// below minConfidence and returns their IDs in sorted order
+func (wc *WisdomCultivator) ArchiveWeakPrinciples(minConfidence float64) []string {
+	wc.mu.Lock()
+	defer wc.mu.Unlock()
+
+	archived := make([]string, 0)
+	for id, p := range wc.Principles {
+		if !p.Archived && p.Confidence < minConfidence {
+			p.Archived = true
+			archived = append(archived, id)
+		}
+	}
+	sort.Strings(archived)
+
+	if len(archived) > 0 {
+		wc.updateOverallScore()
+		wc.dirty = true
+		wc.logger.Info("archived weak principles", "count", len(archived), "min_confidence", minConfidence)
+	}
+	return archived
+}
+
+// RevivePrinciple returns an archived principle to active use
+func (wc *WisdomCultivator) RevivePrinciple(id string) error {
+	wc.mu.Lock()
+	defer wc.mu.Unlock()
+
+	principle, ok := wc.Principles[id]
+	if !ok {
+		return fmt.Errorf("principle not found: %s", id)
+	}
+	if !principle.Archived {
+		return nil
+	}
+
+	principle.Archived = false
+	wc.updateOverallScore()
+	wc.dirty = true
+	return nil
+}
@@ -0,0 +1,113 @@
+package playmate
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// principleIDs returns the sorted IDs of principles
+func principleIDs(principles []*WisdomPrinciple) []string {
+	ids := make([]string, len(principles))
+	for i, p := range principles {
+		ids[i] = p.ID
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func TestArchiveWeakPrinciples(t *testing.T) {
+	wc, _ := newTestCultivator(t, nil)
+	setPrinciples(wc,
+		principleFor("strong", 0.9),
+		principleFor("shaky", 0.2),
+		principleFor("faint", 0.05),
+	)
+
+	archived := wc.ArchiveWeakPrinciples(0.3)
+	if want := []string{"faint", "shaky"}; !reflect.DeepEqual(archived, want) {
+		t.Fatalf("ArchiveWeakPrinciples = %v, want %v", archived, want)
+	}
+	if got := principleIDs(wc.GetPrinciples()); !reflect.DeepEqual(got, []string{"strong"}) {
+		t.Errorf("active principles = %v, want [strong]", got)
+	}
+	if got := principleIDs(wc.GetAllPrinciples()); len(got) != 3 {
+		t.Errorf("all principles = %v, want all three kept", got)
+	}
+
+	// Already archived principles are not reported again
+	if again := wc.ArchiveWeakPrinciples(0.3); len(again) != 0 {
+		t.Errorf("second ArchiveWeakPrinciples = %v, want none", again)
+	}
+}
+
+func TestArchivedPrinciplesExcludedFromQueries(t *testing.T) {
+	wc, _ := newTestCultivator(t, nil)
+	setPrinciples(wc,
+		&WisdomPrinciple{ID: "kept", Statement: "Listen before speaking", Confidence: 0.8, Dimensions: []WisdomDimension{DimensionCompassion}},
+		&WisdomPrinciple{ID: "gone", Statement: "Listen to the silence", Confidence: 0.8, Dimensions: []WisdomDimension{DimensionCompassion}},
+	)
+	if err := wc.ArchivePrinciple("gone"); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := principleIDs(wc.SearchPrinciples("listen", -1)); !reflect.DeepEqual(got, []string{"kept"}) {
+		t.Errorf("SearchPrinciples = %v, want only the active principle", got)
+	}
+	if got := principleIDs(wc.SamplePrinciples(5, nil, nil)); !reflect.DeepEqual(got, []string{"kept"}) {
+		t.Errorf("SamplePrinciples = %v, want only the active principle", got)
+	}
+	related := wc.GetDimensionReport(DimensionCompassion)["related_principles"]
+	if related != 1 {
+		t.Errorf("dimension report related principles = %v, want 1", related)
+	}
+}
+
+func TestRevivePrinciple(t *testing.T) {
+	store := &memoryStore{}
+	config := func() *WisdomConfig { return &WisdomConfig{PersistPath: "wisdom", Store: store} }
+
+	wc, _ := newTestCultivator(t, config())
+	p := wc.AddPrinciple("Rest before deciding", []WisdomDimension{DimensionEquanimity}, "reflection")
+	if err := wc.ArchivePrinciple(p.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := wc.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Archival survives a reload
+	loaded, _ := newTestCultivator(t, config())
+	for _, active := range loaded.GetPrinciples() {
+		if active.ID == p.ID {
+			t.Fatal("archived principle active after reload")
+		}
+	}
+
+	if err := loaded.RevivePrinciple(p.ID); err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	for _, active := range loaded.GetPrinciples() {
+		if active.ID == p.ID {
+			found = true
+			if active.Statement != "Rest before deciding" || active.Source != "reflection" {
+				t.Errorf("revived principle lost its history: %+v", active)
+			}
+		}
+	}
+	if !found {
+		t.Error("revived principle not active")
+	}
+
+	// Reviving an active principle is a no-op; unknown IDs are errors
+	if err := loaded.RevivePrinciple(p.ID); err != nil {
+		t.Errorf("reviving an active principle: %v", err)
+	}
+	if err := loaded.RevivePrinciple("missing"); err == nil {
+		t.Error("reviving an unknown principle succeeded")
+	}
+	if err := loaded.ArchivePrinciple("missing"); err == nil {
+		t.Error("archiving an unknown principle succeeded")
+	}
+}
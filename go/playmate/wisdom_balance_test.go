@@ -0,0 +1,61 @@
+package playmate
+
+import (
+	"math"
+	"testing"
+)
+
+// uniformMetrics gives every dimension the same value
+func uniformMetrics(v float64) *WisdomMetrics {
+	return &WisdomMetrics{
+		Understanding: v,
+		Perspective:   v,
+		Integration:   v,
+		Reflection:    v,
+		Compassion:    v,
+		Equanimity:    v,
+		Transcendence: v,
+	}
+}
+
+func TestBalanceScore(t *testing.T) {
+	spiky := uniformMetrics(0.1)
+	spiky.Compassion = 0.9
+	single := uniformMetrics(0)
+	single.Reflection = 1.0
+
+	tests := []struct {
+		name     string
+		metrics  *WisdomMetrics
+		min, max float64
+	}{
+		{"balanced", uniformMetrics(0.6), 0.999, 1},
+		{"undeveloped", uniformMetrics(0), 0.999, 1},
+		{"spiky", spiky, 0, 0.7},
+		{"single dimension", single, 0, 0.001},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wc, _ := newTestCultivator(t, &WisdomConfig{InitialMetrics: tt.metrics})
+			got := wc.BalanceScore()
+			if got < tt.min || got > tt.max {
+				t.Errorf("BalanceScore = %v, want within [%v, %v]", got, tt.min, tt.max)
+			}
+			if metrics := wc.GetMetrics(); math.Abs(metrics.Balance-got) > 1e-9 {
+				t.Errorf("GetMetrics().Balance = %v, want %v", metrics.Balance, got)
+			}
+		})
+	}
+}
+
+func TestBalanceScoreFlagsLopsidedGrowth(t *testing.T) {
+	balanced, _ := newTestCultivator(t, &WisdomConfig{InitialMetrics: uniformMetrics(0.5)})
+	lopsided := uniformMetrics(0.5)
+	lopsided.Transcendence = 0.05
+	lopsided.Understanding = 0.95
+	uneven, _ := newTestCultivator(t, &WisdomConfig{InitialMetrics: lopsided})
+
+	if b, u := balanced.BalanceScore(), uneven.BalanceScore(); u >= b {
+		t.Errorf("lopsided balance %v not below balanced %v", u, b)
+	}
+}
@@ -0,0 +1,77 @@
+package playmate
+
+import (
+	"math"
+	"testing"
+)
+
+// principleFor builds a principle supporting every dimension with the given confidence
+func principleFor(id string, confidence float64, dims ...WisdomDimension) *WisdomPrinciple {
+	if len(dims) == 0 {
+		dims = allDimensions
+	}
+	return &WisdomPrinciple{ID: id, Confidence: confidence, Dimensions: dims}
+}
+
+func TestConfidenceWeightedScore(t *testing.T) {
+	wc, _ := newTestCultivator(t, &WisdomConfig{InitialMetrics: uniformMetrics(0.6)})
+	raw := wc.GetMetrics().OverallScore
+
+	setPrinciples(wc, principleFor("certain", 1.0))
+	if got := wc.ConfidenceWeightedScore(); math.Abs(got-raw) > 1e-9 {
+		t.Errorf("fully confident score = %v, want the raw score %v", got, raw)
+	}
+
+	// Low-confidence support drags the weighted score below the raw one
+	setPrinciples(wc,
+		principleFor("certain", 1.0),
+		principleFor("hunch", 0.1, DimensionCompassion),
+		principleFor("guess", 0.1, DimensionCompassion),
+	)
+	doubtful := wc.ConfidenceWeightedScore()
+	if doubtful >= raw {
+		t.Errorf("score with doubtful compassion = %v, want below raw %v", doubtful, raw)
+	}
+
+	// Archived principles no longer count
+	wc.mu.Lock()
+	wc.Principles["hunch"].Archived = true
+	wc.Principles["guess"].Archived = true
+	wc.mu.Unlock()
+	if got := wc.ConfidenceWeightedScore(); math.Abs(got-raw) > 1e-9 {
+		t.Errorf("score with doubtful principles archived = %v, want %v", got, raw)
+	}
+}
+
+func TestConfidenceWeightedScoreRisesWithValidation(t *testing.T) {
+	wc, _ := newTestCultivator(t, &WisdomConfig{InitialMetrics: uniformMetrics(0.6)})
+	setPrinciples(wc, principleFor("hunch", 0.2))
+	before := wc.ConfidenceWeightedScore()
+
+	for i := 0; i < 5; i++ {
+		if err := wc.ValidatePrinciple("hunch"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	after := wc.ConfidenceWeightedScore()
+	if after <= before {
+		t.Errorf("score after validation = %v, want above %v", after, before)
+	}
+	if metrics := wc.GetMetrics(); math.Abs(metrics.ConfidenceWeightedScore-after) > 1e-9 {
+		t.Errorf("GetMetrics().ConfidenceWeightedScore = %v, want %v", metrics.ConfidenceWeightedScore, after)
+	}
+	if metrics := wc.GetMetrics(); metrics.ConfidenceWeightedScore > metrics.OverallScore {
+		t.Errorf("weighted score %v exceeds the raw score %v", metrics.ConfidenceWeightedScore, metrics.OverallScore)
+	}
+}
+
+func TestConfidenceWeightedScoreAssumesUnsupportedConfidence(t *testing.T) {
+	wc, _ := newTestCultivator(t, &WisdomConfig{InitialMetrics: uniformMetrics(0.6)})
+	raw := wc.GetMetrics().OverallScore
+	setPrinciples(wc)
+
+	// With no principles every dimension is discounted equally
+	if got, want := wc.ConfidenceWeightedScore(), raw*unsupportedConfidence; math.Abs(got-want) > 1e-9 {
+		t.Errorf("unsupported score = %v, want %v", got, want)
+	}
+}
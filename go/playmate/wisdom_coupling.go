@@ -0,0 +1,12 @@
+package playmate
+
+// defaultEquanimityCoupling is how strongly equanimity scales compassion growth
+const defaultEquanimityCoupling = 0.5
+
+// equanimityFactor scales compassion growth by equanimity: staying balanced
+// while meeting someone's distress deepens compassion, while being shaken by it
+// teaches less. Equanimity of 0.5 is neutral; at 1.0 growth is multiplied by
+// 1+coupling and at 0.0 by 1-coupling, never below zero. (must hold lock)
+func (wc *WisdomCultivator) equanimityFactor() float64 {
+	return max(0, 1.0+wc.equanimityCoupling*(wc.Metrics.Equanimity-0.5)*2.0)
+}
@@ -0,0 +1,64 @@
+package playmate
+
+import (
+	"context"
+	"math"
+	"testing"
+)
+
+// compassionGrowth records the same empathetic act at the given equanimity
+// and coupling and returns how much compassion grew
+func compassionGrowth(t *testing.T, equanimity, coupling float64) float64 {
+	t.Helper()
+	metrics := uniformMetrics(0.3)
+	metrics.Equanimity = equanimity
+	wc, _ := newTestCultivator(t, &WisdomConfig{
+		InitialMetrics:     metrics,
+		EquanimityCoupling: coupling,
+		GrowthCurve:        func(current, amount float64) float64 { return amount },
+	})
+	before := wc.GetMetrics().Compassion
+	wc.RecordEmpatheticAct(context.Background(), "sat with a grieving friend", "sad")
+	return wc.GetMetrics().Compassion - before
+}
+
+func TestEquanimityScalesCompassionGrowth(t *testing.T) {
+	low := compassionGrowth(t, 0.1, 0)
+	neutral := compassionGrowth(t, 0.5, 0)
+	high := compassionGrowth(t, 0.9, 0)
+	if !(high > neutral && neutral > low && low > 0) {
+		t.Errorf("compassion growth at low/neutral/high equanimity = %v/%v/%v, want increasing and positive", low, neutral, high)
+	}
+
+	// Balanced equanimity neither helps nor hinders
+	if uncoupled := compassionGrowth(t, 0.5, -1); math.Abs(neutral-uncoupled) > 1e-12 {
+		t.Errorf("growth at neutral equanimity = %v, want the uncoupled %v", neutral, uncoupled)
+	}
+
+	// Default coupling 0.5: equanimity 0.9 gives a factor of 1.4, 0.1 a factor of 0.6
+	if got := high / neutral; math.Abs(got-1.4) > 1e-9 {
+		t.Errorf("high equanimity factor = %v, want 1.4", got)
+	}
+	if got := low / neutral; math.Abs(got-0.6) > 1e-9 {
+		t.Errorf("low equanimity factor = %v, want 0.6", got)
+	}
+}
+
+func TestCouplingCoefficient(t *testing.T) {
+	// Disabling the coupling makes equanimity irrelevant
+	if low, high := compassionGrowth(t, 0.1, -1), compassionGrowth(t, 0.9, -1); math.Abs(low-high) > 1e-12 {
+		t.Errorf("uncoupled growth at low/high equanimity = %v/%v, want equal", low, high)
+	}
+
+	// A stronger coupling widens the gap
+	weakGap := compassionGrowth(t, 0.9, 0.2) - compassionGrowth(t, 0.1, 0.2)
+	strongGap := compassionGrowth(t, 0.9, 0.8) - compassionGrowth(t, 0.1, 0.8)
+	if strongGap <= weakGap {
+		t.Errorf("gap with coupling 0.8 = %v, want wider than %v at 0.2", strongGap, weakGap)
+	}
+
+	// Very low equanimity can stall compassion growth, but never reverse it
+	if got := compassionGrowth(t, 0, 2); got != 0 {
+		t.Errorf("growth with no equanimity and coupling 2 = %v, want 0", got)
+	}
+}
@@ -0,0 +1,72 @@
+package playmate
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+// trajectory grows understanding steps times and returns its value after each step
+func trajectory(t *testing.T, config *WisdomConfig, steps int, amount float64) []float64 {
+	t.Helper()
+	wc, _ := newTestCultivator(t, config)
+	values := make([]float64, steps)
+	for i := range values {
+		wc.GrowDimension(DimensionUnderstanding, amount, "test")
+		values[i] = wc.GetMetrics().Understanding
+	}
+	return values
+}
+
+func TestGrowthCurvesShapeTrajectories(t *testing.T) {
+	linear := trajectory(t, nil, 40, 0.05)
+	quadratic := trajectory(t, &WisdomConfig{GrowthCurveName: "quadratic"}, 40, 0.05)
+
+	// Quadratic dampening is gentler at low values, but it vanishes near 1.0
+	// where linear dampening still lets growth through
+	if quadratic[0] <= linear[0] {
+		t.Errorf("first step: quadratic %v, linear %v; want quadratic ahead", quadratic[0], linear[0])
+	}
+	if last := linear[len(linear)-1]; last != 1.0 {
+		t.Errorf("linear trajectory ends at %v, want saturated at 1.0", last)
+	}
+	if last := quadratic[len(quadratic)-1]; last >= 1.0 {
+		t.Errorf("quadratic trajectory ends at %v, want still below 1.0", last)
+	}
+}
+
+func TestCustomGrowthCurveCannotExceedOne(t *testing.T) {
+	explosive := func(current, amount float64) float64 { return amount * 100 }
+	values := trajectory(t, &WisdomConfig{GrowthCurve: explosive}, 3, 0.1)
+	for i, v := range values {
+		if v > 1.0 {
+			t.Errorf("step %d: understanding = %v, want at most 1.0", i, v)
+		}
+	}
+	if values[len(values)-1] != 1.0 {
+		t.Errorf("understanding = %v, want saturated at 1.0", values[len(values)-1])
+	}
+}
+
+func TestUnknownGrowthCurveName(t *testing.T) {
+	if _, err := NewWisdomCultivator(&WisdomConfig{GrowthCurveName: "cubic"}); err == nil {
+		t.Error("NewWisdomCultivator accepted an unknown growth curve")
+	}
+}
+
+func TestSavedGrowthCurveIsRestored(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "wisdom")
+	wc, _ := newTestCultivator(t, &WisdomConfig{PersistPath: path, GrowthCurveName: "quadratic"})
+	if err := wc.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, _ := newTestCultivator(t, &WisdomConfig{PersistPath: path})
+	if loaded.growthCurveName != "quadratic" {
+		t.Errorf("growth curve after load = %q, want quadratic", loaded.growthCurveName)
+	}
+
+	configured, _ := newTestCultivator(t, &WisdomConfig{PersistPath: path, GrowthCurveName: "linear"})
+	if configured.growthCurveName != "linear" {
+		t.Errorf("configured curve overridden by saved one: %q", configured.growthCurveName)
+	}
+}
@@ -0,0 +1,48 @@
+package playmate
+
+import "context"
+
+// insightDedupWindow is how many of the latest insights a new insight is compared with
+const insightDedupWindow = 20
+
+// embedInsightContent embeds content for deduplication, returning nil when no
+// embedding function is configured or embedding fails
+func (wc *WisdomCultivator) embedInsightContent(ctx context.Context, content string) []float64 {
+	if wc.insightEmbed == nil {
+		return nil
+	}
+	vec, err := embedText(ctx, wc.insightEmbed, content)
+	if err != nil {
+		wc.logger.Warn("failed to embed insight, deduplicating by text", "error", err)
+		return nil
+	}
+	return vec
+}
+
+// findDuplicateInsight returns the recent insight that content repeats, or nil.
+// Insights are compared by embedding when both have one, otherwise by word
+// overlap. (must hold lock)
+func (wc *WisdomCultivator) findDuplicateInsight(content string, embedding []float64) *WisdomInsight {
+	if wc.insightDedupThreshold == 0 {
+		return nil
+	}
+
+	recent := wc.Insights
+	if len(recent) > insightDedupWindow {
+		recent = recent[len(recent)-insightDedupWindow:]
+	}
+	var best *WisdomInsight
+	bestSim := wc.insightDedupThreshold
+	for _, insight := range recent {
+		var sim float64
+		if vec, ok := wc.insightEmbeddings[insight.ID]; ok && embedding != nil && len(vec) == len(embedding) {
+			sim = dot(vec, embedding)
+		} else {
+			sim = thoughtSimilarity(insight.Content, content)
+		}
+		if sim >= bestSim {
+			best, bestSim = insight, sim
+		}
+	}
+	return best
+}
@@ -0,0 +1,107 @@
+package playmate
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+// dedupConfig opts into insight deduplication
+func dedupConfig() *WisdomConfig {
+	return &WisdomConfig{InsightDedupThreshold: 0.85}
+}
+
+func TestAddInsightMergesDuplicates(t *testing.T) {
+	ctx := context.Background()
+	wc, _ := newTestCultivator(t, dedupConfig())
+
+	first := wc.AddInsight(ctx, "patience opens people up", "conversation", 0.4)
+	understanding := wc.GetMetrics().Understanding
+	repeat := wc.AddInsight(ctx, "Patience opens people up.", "reflection", 0.7)
+
+	if repeat.ID != first.ID {
+		t.Fatalf("repeat got new ID %s, want %s", repeat.ID, first.ID)
+	}
+	if repeat.Recurrences != 1 || repeat.Depth != 0.7 {
+		t.Errorf("merged insight = %d recurrences at depth %v, want 1 at the greater depth 0.7", repeat.Recurrences, repeat.Depth)
+	}
+	if got := wc.GetMetrics().Understanding; got != understanding {
+		t.Errorf("understanding grew from %v to %v on a repeated insight", understanding, got)
+	}
+
+	// A shallower repeat keeps the greater depth
+	if again := wc.AddInsight(ctx, "patience opens people up", "conversation", 0.1); again.Recurrences != 2 || again.Depth != 0.7 {
+		t.Errorf("second repeat = %d recurrences at depth %v, want 2 at 0.7", again.Recurrences, again.Depth)
+	}
+
+	distinct := wc.AddInsight(ctx, "rest before deciding", "reflection", 0.5)
+	if distinct.ID == first.ID {
+		t.Error("distinct insight merged into an unrelated one")
+	}
+	if n := len(wc.GetRecentInsights(10)); n != 2 {
+		t.Errorf("got %d insights, want 2", n)
+	}
+}
+
+func TestAddInsightDedupOffByDefault(t *testing.T) {
+	ctx := context.Background()
+	wc, _ := newTestCultivator(t, nil)
+	wc.AddInsight(ctx, "patience opens people up", "conversation", 0.4)
+	wc.AddInsight(ctx, "patience opens people up", "conversation", 0.4)
+	if n := len(wc.GetRecentInsights(10)); n != 2 {
+		t.Errorf("got %d insights with dedup disabled, want 2", n)
+	}
+
+	for _, threshold := range []float64{-0.1, 1.5} {
+		if _, err := NewWisdomCultivator(&WisdomConfig{InsightDedupThreshold: threshold}); err == nil {
+			t.Errorf("NewWisdomCultivator accepted a dedup threshold of %v", threshold)
+		}
+	}
+}
+
+func TestAddInsightDedupByEmbedding(t *testing.T) {
+	ctx := context.Background()
+	vectors := map[string][]float32{
+		"kindness returns":          {1, 0},
+		"being kind comes back":     {0.99, 0.1},
+		"storms pass in their time": {0, 1},
+	}
+	embed := func(ctx context.Context, text string) ([]float32, error) {
+		vec, ok := vectors[text]
+		if !ok {
+			return nil, fmt.Errorf("no embedding for %q", text)
+		}
+		return vec, nil
+	}
+	config := dedupConfig()
+	config.InsightEmbed = embed
+	wc, _ := newTestCultivator(t, config)
+
+	// Paraphrases share no words but embed alike
+	first := wc.AddInsight(ctx, "kindness returns", "conversation", 0.5)
+	if got := wc.AddInsight(ctx, "being kind comes back", "conversation", 0.5); got.ID != first.ID {
+		t.Error("paraphrased insight not merged by embedding")
+	}
+	if got := wc.AddInsight(ctx, "storms pass in their time", "conversation", 0.5); got.ID == first.ID {
+		t.Error("unrelated insight merged by embedding")
+	}
+
+	// Failing to embed falls back to text similarity
+	if got := wc.AddInsight(ctx, "Kindness returns!", "conversation", 0.5); got.ID != first.ID {
+		t.Error("textual repeat not merged when embedding failed")
+	}
+}
+
+func TestAddInsightDedupWindow(t *testing.T) {
+	ctx := context.Background()
+	wc, _ := newTestCultivator(t, dedupConfig())
+	first := wc.AddInsight(ctx, "patience opens people up", "conversation", 0.4)
+	for i := 0; i < insightDedupWindow; i++ {
+		wc.AddInsight(ctx, fmt.Sprintf("lesson number %d", i), "test", 0.1)
+	}
+
+	// Too long ago to count as a repeat
+	if got := wc.AddInsight(ctx, "patience opens people up", "conversation", 0.4); got.ID == first.ID {
+		t.Error("insight outside the dedup window was merged")
+	}
+}
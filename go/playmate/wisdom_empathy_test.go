@@ -0,0 +1,73 @@
+package playmate
+
+import (
+	"context"
+	"testing"
+)
+
+func TestEmpatheticActGrowsCompassionMost(t *testing.T) {
+	wc, _ := newTestCultivator(t, nil)
+	before := *wc.GetMetrics()
+
+	insight := wc.RecordEmpatheticAct(context.Background(), "sat with them while they talked", "anxious and lonely")
+	if insight.Trigger != "empathetic_act" {
+		t.Errorf("insight trigger = %q, want empathetic_act", insight.Trigger)
+	}
+
+	after := *wc.GetMetrics()
+	compassion := after.Compassion - before.Compassion
+	if compassion <= 0 {
+		t.Fatalf("compassion did not grow: %v -> %v", before.Compassion, after.Compassion)
+	}
+	others := map[string]float64{
+		"understanding": after.Understanding - before.Understanding,
+		"perspective":   after.Perspective - before.Perspective,
+		"integration":   after.Integration - before.Integration,
+		"reflection":    after.Reflection - before.Reflection,
+		"equanimity":    after.Equanimity - before.Equanimity,
+		"transcendence": after.Transcendence - before.Transcendence,
+	}
+	for name, growth := range others {
+		if growth >= compassion {
+			t.Errorf("%s grew %v, not less than compassion's %v", name, growth, compassion)
+		}
+	}
+	if others["reflection"] <= 0 {
+		t.Errorf("reflection did not grow")
+	}
+}
+
+func TestEmpatheticActGrowthScalesWithDistress(t *testing.T) {
+	growth := func(state string) float64 {
+		wc, _ := newTestCultivator(t, nil)
+		before := wc.GetMetrics().Compassion
+		wc.RecordEmpatheticAct(context.Background(), "listened", state)
+		return wc.GetMetrics().Compassion - before
+	}
+
+	calm := growth("calm and content")
+	worried := growth("a little worried")
+	crisis := growth("in crisis and very desperate")
+	if !(calm < worried && worried < crisis) {
+		t.Errorf("growth calm=%v worried=%v crisis=%v, want increasing with distress", calm, worried, crisis)
+	}
+}
+
+func TestDistressScore(t *testing.T) {
+	tests := []struct {
+		state string
+		min   float64
+		max   float64
+	}{
+		{"", 0, 0},
+		{"calm", 0, 0},
+		{"Worried.", 0.3, 0.3},
+		{"sad and lonely", 0.6, 0.8},
+		{"very suicidal", 1, 1},
+	}
+	for _, tt := range tests {
+		if got := distressScore(tt.state); got < tt.min || got > tt.max {
+			t.Errorf("distressScore(%q) = %v, want in [%v, %v]", tt.state, got, tt.min, tt.max)
+		}
+	}
+}
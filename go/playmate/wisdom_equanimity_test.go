@@ -0,0 +1,48 @@
+package playmate
+
+import (
+	"math"
+	"testing"
+)
+
+func equanimityAfter(t *testing.T, valences []float64) (before, after float64) {
+	t.Helper()
+	wc, _ := newTestCultivator(t, nil)
+	before = wc.GetMetrics().Equanimity
+	for _, v := range valences {
+		wc.RecordEmotionalEvent(v)
+	}
+	return before, wc.GetMetrics().Equanimity
+}
+
+func TestStableEmotionsGrowEquanimity(t *testing.T) {
+	stable := []float64{0.3, 0.35, 0.3, 0.25, 0.3, 0.3, 0.35, 0.3}
+	before, after := equanimityAfter(t, stable)
+	if after <= before {
+		t.Errorf("equanimity %v -> %v after a stable sequence, want growth", before, after)
+	}
+}
+
+func TestVolatileEmotionsErodeEquanimity(t *testing.T) {
+	volatile := []float64{1, -1, 0.9, -0.9, 1, -1, 0.8, -1}
+	before, after := equanimityAfter(t, volatile)
+	if after >= before {
+		t.Errorf("equanimity %v -> %v after a volatile sequence, want a decline", before, after)
+	}
+}
+
+func TestEmotionalEventsNeedThreeSamples(t *testing.T) {
+	before, after := equanimityAfter(t, []float64{0.2, 0.2})
+	if after != before {
+		t.Errorf("equanimity changed from %v to %v on two samples", before, after)
+	}
+}
+
+func TestStdDev(t *testing.T) {
+	if got := stdDev(nil); got != 0 {
+		t.Errorf("stdDev(nil) = %v, want 0", got)
+	}
+	if got := stdDev([]float64{-1, 1}); math.Abs(got-1) > 1e-12 {
+		t.Errorf("stdDev(-1, 1) = %v, want 1", got)
+	}
+}
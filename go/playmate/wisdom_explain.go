@@ -0,0 +1,70 @@
+package playmate
+
+import "math"
+
+// marginalStep is the dimension increase whose effect ExplainScore reports
+const marginalStep = 0.1
+
+// DimensionContribution is one dimension's share of the overall score
+type DimensionContribution struct {
+	Dimension WisdomDimension `json:"dimension"`
+	Weight    float64         `json:"weight"`
+	Value     float64         `json:"value"`
+	// LogContribution is the dimension's weighted share of log(Score); the
+	// contributions sum to log(Score). Dimensions at zero are left out of the
+	// mean and contribute nothing.
+	LogContribution float64 `json:"log_contribution"`
+	// MarginalEffect is how much Score would rise if the dimension grew by 0.1 (capped at 1.0)
+	MarginalEffect float64 `json:"marginal_effect"`
+}
+
+// ScoreExplanation breaks the overall score down by dimension
+type ScoreExplanation struct {
+	Score      float64                 `json:"score"`
+	Dimensions []DimensionContribution `json:"dimensions"` // In canonical dimension order
+}
+
+// ExplainScore breaks the overall score, a weighted geometric mean of the
+// dimensions, into each dimension's contribution, showing which dimensions drag
+// it down and where growth would help most
+func (wc *WisdomCultivator) ExplainScore() ScoreExplanation {
+	wc.mu.RLock()
+	defer wc.mu.RUnlock()
+
+	score := weightedGeometricMean(wc.getDimensionValue, wc.weights)
+
+	totalWeight := 0.0
+	for _, dim := range allDimensions {
+		if wc.getDimensionValue(dim) > 0 {
+			totalWeight += wc.weights[dim]
+		}
+	}
+
+	explanation := ScoreExplanation{
+		Score:      score,
+		Dimensions: make([]DimensionContribution, 0, len(allDimensions)),
+	}
+	for _, dim := range allDimensions {
+		value := wc.getDimensionValue(dim)
+		contribution := DimensionContribution{
+			Dimension: dim,
+			Weight:    wc.weights[dim],
+			Value:     value,
+		}
+		if value > 0 {
+			contribution.LogContribution = contribution.Weight * math.Log(value) / totalWeight
+		}
+
+		raised := math.Min(1.0, value+marginalStep)
+		contribution.MarginalEffect = weightedGeometricMean(func(d WisdomDimension) float64 {
+			if d == dim {
+				return raised
+			}
+			return wc.getDimensionValue(d)
+		}, wc.weights) - score
+
+		explanation.Dimensions = append(explanation.Dimensions, contribution)
+	}
+
+	return explanation
+}
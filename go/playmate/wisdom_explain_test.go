@@ -0,0 +1,108 @@
+package playmate
+
+import (
+	"math"
+	"sort"
+	"testing"
+)
+
+// spreadMetrics gives each dimension a different value, lowest first in canonical order
+func spreadMetrics() *WisdomMetrics {
+	return &WisdomMetrics{
+		Understanding: 0.1,
+		Perspective:   0.2,
+		Integration:   0.35,
+		Reflection:    0.5,
+		Compassion:    0.65,
+		Equanimity:    0.8,
+		Transcendence: 0.95,
+	}
+}
+
+func TestExplainScoreContributionsSumToScore(t *testing.T) {
+	wc, _ := newTestCultivator(t, &WisdomConfig{InitialMetrics: spreadMetrics()})
+	explanation := wc.ExplainScore()
+
+	if got, want := explanation.Score, wc.GetMetrics().OverallScore; math.Abs(got-want) > 1e-9 {
+		t.Errorf("explained score = %v, overall score = %v", got, want)
+	}
+	if len(explanation.Dimensions) != len(allDimensions) {
+		t.Fatalf("explanation covers %d dimensions, want %d", len(explanation.Dimensions), len(allDimensions))
+	}
+
+	sum := 0.0
+	for i, c := range explanation.Dimensions {
+		if c.Dimension != allDimensions[i] {
+			t.Errorf("dimension %d = %s, want canonical order %s", i, c.Dimension, allDimensions[i])
+		}
+		if c.LogContribution >= 0 {
+			t.Errorf("%s log contribution = %v, want negative for a value below 1", c.Dimension, c.LogContribution)
+		}
+		sum += c.LogContribution
+	}
+	if got := math.Exp(sum); math.Abs(got-explanation.Score) > 1e-9 {
+		t.Errorf("exp(sum of log contributions) = %v, want the score %v", got, explanation.Score)
+	}
+}
+
+func TestExplainScoreMarginalEffects(t *testing.T) {
+	equal := make(map[WisdomDimension]float64, len(allDimensions))
+	for _, dim := range allDimensions {
+		equal[dim] = 1
+	}
+	wc, _ := newTestCultivator(t, &WisdomConfig{InitialMetrics: spreadMetrics(), DimensionWeights: equal})
+	explanation := wc.ExplainScore()
+
+	// With equal weights, growth helps the weakest dimension most
+	contributions := explanation.Dimensions
+	if !sort.SliceIsSorted(contributions, func(i, j int) bool {
+		return contributions[i].MarginalEffect > contributions[j].MarginalEffect
+	}) {
+		t.Errorf("marginal effects not decreasing with value: %+v", contributions)
+	}
+	for _, c := range contributions {
+		if c.MarginalEffect <= 0 {
+			t.Errorf("%s marginal effect = %v, want positive", c.Dimension, c.MarginalEffect)
+		}
+	}
+
+	// A heavier weight makes the same value matter more
+	heavy := make(map[WisdomDimension]float64, len(equal))
+	for dim, w := range equal {
+		heavy[dim] = w
+	}
+	heavy[DimensionReflection] = 3
+	weighted, _ := newTestCultivator(t, &WisdomConfig{InitialMetrics: uniformMetrics(0.5), DimensionWeights: heavy})
+	var reflection, compassion float64
+	for _, c := range weighted.ExplainScore().Dimensions {
+		switch c.Dimension {
+		case DimensionReflection:
+			reflection = c.MarginalEffect
+		case DimensionCompassion:
+			compassion = c.MarginalEffect
+		}
+	}
+	if reflection <= compassion {
+		t.Errorf("heavy reflection marginal effect %v, want above compassion's %v", reflection, compassion)
+	}
+}
+
+func TestExplainScoreSaturatedAndZeroDimensions(t *testing.T) {
+	metrics := uniformMetrics(0.5)
+	metrics.Transcendence = 1.0
+	metrics.Equanimity = 0
+	wc, _ := newTestCultivator(t, &WisdomConfig{InitialMetrics: metrics})
+
+	for _, c := range wc.ExplainScore().Dimensions {
+		switch c.Dimension {
+		case DimensionTranscendence:
+			if c.LogContribution != 0 || c.MarginalEffect != 0 {
+				t.Errorf("saturated dimension = %+v, want no contribution or marginal effect", c)
+			}
+		case DimensionEquanimity:
+			if c.LogContribution != 0 {
+				t.Errorf("zero dimension log contribution = %v, want 0", c.LogContribution)
+			}
+		}
+	}
+}
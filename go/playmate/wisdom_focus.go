@@ -0,0 +1,63 @@
+package playmate
+
+import (
+	"fmt"
+	"time"
+)
+
+// focusGrowthWindow is how far back recent growth counts toward a dimension in SuggestFocus
+const focusGrowthWindow = 7 * 24 * time.Hour
+
+// focusPrompts are fallback reflection prompts for dimensions with no principles
+var focusPrompts = map[WisdomDimension]string{
+	DimensionUnderstanding: "What is something you think you know well? Look at it again as if for the first time.",
+	DimensionPerspective:   "Recall a recent disagreement. How would it look from the other side?",
+	DimensionIntegration:   "What do two of your separate interests have in common?",
+	DimensionReflection:    "What did you do today out of habit rather than choice?",
+	DimensionCompassion:    "Who around you might be struggling quietly, and what would help them?",
+	DimensionEquanimity:    "What unsettled you recently, and what would it take to meet it calmly?",
+	DimensionTranscendence: "What larger pattern is today's experience a part of?",
+}
+
+// SuggestFocus returns the dimension most in need of attention, the one with the
+// lowest value plus growth over the past week, and a reflection prompt drawn from
+// its most confident principle
+func (wc *WisdomCultivator) SuggestFocus() (WisdomDimension, string) {
+	wc.mu.RLock()
+	defer wc.mu.RUnlock()
+
+	cutoff := wc.clock.Now().Add(-focusGrowthWindow)
+	focus := allDimensions[0]
+	lowest := 0.0
+	for i, dim := range allDimensions {
+		score := wc.getDimensionValue(dim) + wc.growthSince(dim, cutoff)
+		if i == 0 || score < lowest {
+			focus, lowest = dim, score
+		}
+	}
+
+	var best *WisdomPrinciple
+	for _, p := range wc.Principles {
+		if p.Archived || !hasDimension(p.Dimensions, focus) {
+			continue
+		}
+		if best == nil || p.Confidence > best.Confidence || (p.Confidence == best.Confidence && p.ID < best.ID) {
+			best = p
+		}
+	}
+
+	if best == nil {
+		return focus, focusPrompts[focus]
+	}
+	return focus, fmt.Sprintf("Reflect on \"%s\". How could you practice %s today?", best.Statement, focus)
+}
+
+// hasDimension reports whether dims contains dim
+func hasDimension(dims []WisdomDimension, dim WisdomDimension) bool {
+	for _, d := range dims {
+		if d == dim {
+			return true
+		}
+	}
+	return false
+}
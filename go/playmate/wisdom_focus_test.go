@@ -0,0 +1,64 @@
+package playmate
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSuggestFocusPicksWeakestDimension(t *testing.T) {
+	lopsided := uniformMetrics(0.6)
+	lopsided.Equanimity = 0.1
+	wc, _ := newTestCultivator(t, &WisdomConfig{InitialMetrics: lopsided})
+	setPrinciples(wc,
+		&WisdomPrinciple{ID: "a", Statement: "Breathe before replying", Confidence: 0.4, Dimensions: []WisdomDimension{DimensionEquanimity}},
+		&WisdomPrinciple{ID: "b", Statement: "Storms pass", Confidence: 0.9, Dimensions: []WisdomDimension{DimensionEquanimity, DimensionTranscendence}},
+		&WisdomPrinciple{ID: "c", Statement: "Retired advice", Confidence: 1.0, Dimensions: []WisdomDimension{DimensionEquanimity}, Archived: true},
+		&WisdomPrinciple{ID: "d", Statement: "Listen closely", Confidence: 1.0, Dimensions: []WisdomDimension{DimensionCompassion}},
+	)
+
+	dim, prompt := wc.SuggestFocus()
+	if dim != DimensionEquanimity {
+		t.Errorf("focus = %s, want %s", dim, DimensionEquanimity)
+	}
+	if !strings.Contains(prompt, "Storms pass") {
+		t.Errorf("prompt = %q, want it drawn from the most confident active equanimity principle", prompt)
+	}
+}
+
+func TestSuggestFocusFallbackPrompt(t *testing.T) {
+	lopsided := uniformMetrics(0.6)
+	lopsided.Perspective = 0.2
+	wc, _ := newTestCultivator(t, &WisdomConfig{InitialMetrics: lopsided})
+	setPrinciples(wc)
+
+	dim, prompt := wc.SuggestFocus()
+	if dim != DimensionPerspective || prompt != focusPrompts[DimensionPerspective] {
+		t.Errorf("SuggestFocus = %s, %q; want perspective with its fallback prompt", dim, prompt)
+	}
+}
+
+func TestSuggestFocusCountsRecentGrowth(t *testing.T) {
+	wc, clock := newTestCultivator(t, &WisdomConfig{InitialMetrics: uniformMetrics(0.9)})
+	wc.mu.Lock()
+	wc.Metrics.Understanding = 0.3
+	wc.mu.Unlock()
+
+	wc.GrowDimension(DimensionUnderstanding, 0.1, "study")
+	wc.mu.Lock()
+	grown := wc.Metrics.Understanding - 0.3
+	// Between the understanding value alone and the value plus its recent growth
+	wc.Metrics.Compassion = 0.3 + 1.5*grown
+	wc.mu.Unlock()
+	if grown <= 0 {
+		t.Fatalf("understanding grew by %v, want positive growth", grown)
+	}
+
+	if dim, _ := wc.SuggestFocus(); dim != DimensionCompassion {
+		t.Errorf("focus = %s, want compassion while understanding's growth is recent", dim)
+	}
+	clock.Advance(focusGrowthWindow + time.Hour)
+	if dim, _ := wc.SuggestFocus(); dim != DimensionUnderstanding {
+		t.Errorf("focus = %s, want understanding once its growth is old", dim)
+	}
+}
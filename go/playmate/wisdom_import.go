@@ -0,0 +1,117 @@
+package playmate
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// importedSource is the Source given to imported principles that don't name one
+const importedSource = "imported"
+
+// PrincipleRecord is the external representation of a principle read by ImportPrinciples
+type PrincipleRecord struct {
+	Statement  string            `json:"statement"`
+	Dimensions []WisdomDimension `json:"dimensions"`
+	Source     string            `json:"source,omitempty"`
+}
+
+// ImportPrinciples reads principles from r, given either as a JSON array of
+// records or as newline-delimited JSON records, and returns how many were added.
+// Statements matching an existing principle (ignoring case and spacing) are
+// skipped, so importing the same corpus twice adds nothing. Imported principles
+// don't count as growth.
+func (wc *WisdomCultivator) ImportPrinciples(r io.Reader) (int, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read principles: %w", err)
+	}
+
+	records, err := parsePrincipleRecords(data)
+	if err != nil {
+		return 0, err
+	}
+	for i, rec := range records {
+		if strings.TrimSpace(rec.Statement) == "" {
+			return 0, fmt.Errorf("principle %d has no statement", i+1)
+		}
+		for _, dim := range rec.Dimensions {
+			if !hasDimension(allDimensions, dim) {
+				return 0, fmt.Errorf("principle %d has unknown dimension: %s", i+1, dim)
+			}
+		}
+	}
+
+	wc.mu.Lock()
+	defer wc.mu.Unlock()
+
+	known := make(map[string]bool, len(wc.Principles))
+	for _, p := range wc.Principles {
+		known[normalizeStatement(p.Statement)] = true
+	}
+
+	added := 0
+	for _, rec := range records {
+		key := normalizeStatement(rec.Statement)
+		if known[key] {
+			continue
+		}
+		known[key] = true
+
+		source := rec.Source
+		if source == "" {
+			source = importedSource
+		}
+		wc.insertPrinciple(strings.TrimSpace(rec.Statement), rec.Dimensions, source)
+		added++
+	}
+
+	if added > 0 {
+		wc.logger.Info("imported principles", "count", added)
+	}
+	return added, nil
+}
+
+// parsePrincipleRecords decodes a JSON array or newline-delimited JSON records
+func parsePrincipleRecords(data []byte) ([]PrincipleRecord, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 {
+		return nil, nil
+	}
+
+	if trimmed[0] == '[' {
+		var records []PrincipleRecord
+		if err := json.Unmarshal(trimmed, &records); err != nil {
+			return nil, fmt.Errorf("failed to parse principles: %w", err)
+		}
+		return records, nil
+	}
+
+	records := make([]PrincipleRecord, 0)
+	scanner := bufio.NewScanner(bytes.NewReader(trimmed))
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+		var rec PrincipleRecord
+		if err := json.Unmarshal(text, &rec); err != nil {
+			return nil, fmt.Errorf("failed to parse principle on line %d: %w", line, err)
+		}
+		records = append(records, rec)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read principles: %w", err)
+	}
+	return records, nil
+}
+
+// normalizeStatement folds case and whitespace so equivalent statements compare equal
+func normalizeStatement(statement string) string {
+	return strings.Join(strings.Fields(strings.ToLower(statement)), " ")
+}
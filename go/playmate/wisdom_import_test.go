@@ -0,0 +1,91 @@
+package playmate
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// importFile imports principles from a testdata file
+func importFile(t *testing.T, wc *WisdomCultivator, name string) int {
+	t.Helper()
+	f, err := os.Open("testdata/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	n, err := wc.ImportPrinciples(f)
+	if err != nil {
+		t.Fatalf("ImportPrinciples(%s): %v", name, err)
+	}
+	return n
+}
+
+func TestImportPrinciplesFromFile(t *testing.T) {
+	for _, name := range []string{"principles.jsonl", "principles.json"} {
+		t.Run(name, func(t *testing.T) {
+			wc, _ := newTestCultivator(t, nil)
+			before := len(wc.GetPrinciples())
+			metrics := *wc.GetMetrics()
+
+			added := importFile(t, wc, name)
+			// The JSONL corpus repeats one statement and restates a foundational one
+			want := map[string]int{"principles.jsonl": 3, "principles.json": 2}[name]
+			if added != want {
+				t.Errorf("imported %d principles, want %d", added, want)
+			}
+			if got := len(wc.GetPrinciples()); got != before+want {
+				t.Errorf("%d principles after import, want %d", got, before+want)
+			}
+			if again := importFile(t, wc, name); again != 0 {
+				t.Errorf("re-import added %d principles, want 0", again)
+			}
+			if got := *wc.GetMetrics(); !reflect.DeepEqual(got, metrics) {
+				t.Errorf("import changed metrics:\n got %+v\nwant %+v", got, metrics)
+			}
+		})
+	}
+}
+
+func TestImportPrinciplesSources(t *testing.T) {
+	wc, _ := newTestCultivator(t, nil)
+	importFile(t, wc, "principles.jsonl")
+
+	sources := make(map[string]string)
+	for _, p := range wc.GetPrinciples() {
+		sources[p.Statement] = p.Source
+	}
+	if got := sources["Rest is part of the work"]; got != "corpus" {
+		t.Errorf("named source = %q, want corpus", got)
+	}
+	if got := sources["Small steps compound"]; got != importedSource {
+		t.Errorf("default source = %q, want %q", got, importedSource)
+	}
+}
+
+func TestImportPrinciplesRejectsBadRecords(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"unknown dimension", `{"statement": "Be bold", "dimensions": ["bravado"]}`, "unknown dimension"},
+		{"empty statement", `{"statement": "  ", "dimensions": []}`, "no statement"},
+		{"malformed line", "{\"statement\": \"Fine\"}\n{oops", "line 2"},
+		{"malformed array", `[{"statement": 1}]`, "failed to parse"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wc, _ := newTestCultivator(t, nil)
+			before := len(wc.GetPrinciples())
+			n, err := wc.ImportPrinciples(strings.NewReader(tt.input))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("error = %v, want one mentioning %q", err, tt.want)
+			}
+			if n != 0 || len(wc.GetPrinciples()) != before {
+				t.Errorf("bad input added %d principles, want none", n)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package playmate
+
+import (
+	"math"
+	"testing"
+)
+
+func TestInitialMetricsUsed(t *testing.T) {
+	initial := &WisdomMetrics{
+		Understanding: 0.7,
+		Perspective:   0.5,
+		Integration:   0.4,
+		Reflection:    0.6,
+		Compassion:    0.8,
+		Equanimity:    0.3,
+		Transcendence: 0.2,
+		OverallScore:  0.99, // Ignored; always recomputed
+	}
+	wc, _ := newTestCultivator(t, &WisdomConfig{InitialMetrics: initial})
+
+	got := wc.GetMetrics()
+	for _, dim := range allDimensions {
+		if got.dimensionValue(dim) != initial.dimensionValue(dim) {
+			t.Errorf("%s = %v, want initial %v", dim, got.dimensionValue(dim), initial.dimensionValue(dim))
+		}
+	}
+	if got.OverallScore <= 0.2 || got.OverallScore >= 0.8 {
+		t.Errorf("OverallScore = %v, want a mean of the initial dimensions", got.OverallScore)
+	}
+	if got.Balance >= 1 {
+		t.Errorf("Balance = %v, want below 1 for uneven initial dimensions", got.Balance)
+	}
+
+	// The caller's metrics are copied, not shared
+	initial.Understanding = 0
+	if wc.GetMetrics().Understanding != 0.7 {
+		t.Error("changing the config's InitialMetrics changed the cultivator")
+	}
+}
+
+func TestInitialMetricsOverallScore(t *testing.T) {
+	wc, _ := newTestCultivator(t, &WisdomConfig{InitialMetrics: uniformMetrics(0.6)})
+	if got := wc.GetMetrics().OverallScore; math.Abs(got-0.6) > 1e-9 {
+		t.Errorf("OverallScore = %v, want 0.6 for uniform initial metrics", got)
+	}
+
+	defaults, _ := newTestCultivator(t, nil)
+	if got := defaults.GetMetrics().OverallScore; math.Abs(got-baselineDimensionValue) > 1e-9 {
+		t.Errorf("default OverallScore = %v, want %v", got, baselineDimensionValue)
+	}
+}
+
+func TestInitialMetricsValidated(t *testing.T) {
+	for _, v := range []float64{-0.1, 1.1} {
+		initial := uniformMetrics(0.5)
+		initial.Reflection = v
+		if _, err := NewWisdomCultivator(&WisdomConfig{InitialMetrics: initial}); err == nil {
+			t.Errorf("NewWisdomCultivator accepted initial reflection %v", v)
+		}
+	}
+	for _, v := range []float64{0, 1} {
+		initial := uniformMetrics(0.5)
+		initial.Reflection = v
+		if _, err := NewWisdomCultivator(&WisdomConfig{InitialMetrics: initial}); err != nil {
+			t.Errorf("NewWisdomCultivator rejected initial reflection %v: %v", v, err)
+		}
+	}
+}
+
+func TestInitialMetricsYieldToSavedState(t *testing.T) {
+	store := &memoryStore{}
+	wc, _ := newTestCultivator(t, &WisdomConfig{PersistPath: "wisdom", Store: store, InitialMetrics: uniformMetrics(0.4)})
+	wc.GrowDimension(DimensionCompassion, 0.2, "kindness")
+	want := wc.GetMetrics().Compassion
+	if err := wc.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	// An experienced agent keeps what it has grown, whatever the config says
+	loaded, _ := newTestCultivator(t, &WisdomConfig{PersistPath: "wisdom", Store: store, InitialMetrics: uniformMetrics(0.9)})
+	if got := loaded.GetMetrics().Compassion; got != want {
+		t.Errorf("reloaded Compassion = %v, want saved %v", got, want)
+	}
+	loaded.mu.RLock()
+	defer loaded.mu.RUnlock()
+	if got := loaded.baseline[DimensionCompassion]; got != 0.4 {
+		t.Errorf("reloaded baseline = %v, want the saved 0.4", got)
+	}
+}
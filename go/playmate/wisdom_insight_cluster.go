@@ -0,0 +1,241 @@
+package playmate
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/o9nn/un9n/go/vectormem"
+)
+
+// maxClusterIterations bounds the k-means refinement of insight clusters
+const maxClusterIterations = 20
+
+// InsightCluster is a group of insights sharing a recurring theme
+type InsightCluster struct {
+	Representative *WisdomInsight `json:"representative"` // Copy of the member closest to the cluster center
+	Size           int            `json:"size"`
+	MemberIDs      []string       `json:"member_ids"`
+	Keywords       []string       `json:"keywords"`
+}
+
+// ClusterInsights groups insights into at most k recurring themes, largest first.
+// Insights are compared by embedding when embedFunc is provided, otherwise by
+// their keywords; clustering also falls back to keywords if embedding fails.
+func (wc *WisdomCultivator) ClusterInsights(ctx context.Context, embedFunc vectormem.EmbeddingFunc, k int) []InsightCluster {
+	wc.mu.RLock()
+	insights := make([]*WisdomInsight, len(wc.Insights))
+	for i, insight := range wc.Insights {
+		insights[i] = insight.clone()
+	}
+	wc.mu.RUnlock()
+
+	clusters := make([]InsightCluster, 0)
+	if len(insights) == 0 || k <= 0 {
+		return clusters
+	}
+
+	var vectors [][]float64
+	if embedFunc != nil {
+		var err error
+		vectors, err = embedInsights(ctx, embedFunc, insights)
+		if err != nil {
+			wc.logger.Warn("failed to embed insights, clustering by keywords", "error", err)
+			vectors = nil
+		}
+	}
+	if vectors == nil {
+		vectors = keywordVectors(insights)
+	}
+
+	for _, members := range kMeans(vectors, k) {
+		cluster := InsightCluster{
+			Size:      len(members),
+			MemberIDs: make([]string, len(members)),
+		}
+		contents := make([]string, len(members))
+		for i, idx := range members {
+			cluster.MemberIDs[i] = insights[idx].ID
+			contents[i] = insights[idx].Content
+		}
+		cluster.Representative = insights[medoid(vectors, members)]
+		cluster.Keywords = extractKeywords(contents, 3)
+		clusters = append(clusters, cluster)
+	}
+
+	sort.SliceStable(clusters, func(i, j int) bool {
+		return clusters[i].Size > clusters[j].Size
+	})
+	return clusters
+}
+
+// embedInsights embeds each insight's content as a unit vector. Embeddings of
+// differing lengths can't be compared, so they are reported as an error.
+func embedInsights(ctx context.Context, embedFunc vectormem.EmbeddingFunc, insights []*WisdomInsight) ([][]float64, error) {
+	vectors := make([][]float64, len(insights))
+	for i, insight := range insights {
+		vec, err := embedText(ctx, embedFunc, insight.Content)
+		if err != nil {
+			return nil, err
+		}
+		if i > 0 && len(vec) != len(vectors[0]) {
+			return nil, fmt.Errorf("embedding dimension mismatch: got %d, want %d", len(vec), len(vectors[0]))
+		}
+		vectors[i] = vec
+	}
+	return vectors, nil
+}
+
+// embedText embeds text as a unit vector
+func embedText(ctx context.Context, embedFunc vectormem.EmbeddingFunc, text string) ([]float64, error) {
+	embedding, err := embedFunc(ctx, text)
+	if err != nil {
+		return nil, err
+	}
+	vec := make([]float64, len(embedding))
+	for i, v := range embedding {
+		vec[i] = float64(v)
+	}
+	return normalize(vec), nil
+}
+
+// keywordVectors builds unit term-frequency vectors over the insights' shared vocabulary
+func keywordVectors(insights []*WisdomInsight) [][]float64 {
+	vocab := make(map[string]int)
+	docs := make([][]string, len(insights))
+	for i, insight := range insights {
+		for _, w := range tokenize(insight.Content) {
+			if len(w) < 3 || stopwords[w] {
+				continue
+			}
+			if _, ok := vocab[w]; !ok {
+				vocab[w] = len(vocab)
+			}
+			docs[i] = append(docs[i], w)
+		}
+	}
+
+	vectors := make([][]float64, len(insights))
+	for i, words := range docs {
+		vec := make([]float64, len(vocab))
+		for _, w := range words {
+			vec[vocab[w]]++
+		}
+		vectors[i] = normalize(vec)
+	}
+	return vectors
+}
+
+// kMeans partitions unit vectors into at most k groups by cosine similarity,
+// returning the member indices of each non-empty group. Centers are seeded
+// farthest-first from the first vector so results are deterministic.
+func kMeans(vectors [][]float64, k int) [][]int {
+	if k > len(vectors) {
+		k = len(vectors)
+	}
+
+	centers := [][]float64{vectors[0]}
+	for len(centers) < k {
+		farthest, lowest := -1, math.Inf(1)
+		for i, vec := range vectors {
+			best := math.Inf(-1)
+			for _, center := range centers {
+				best = math.Max(best, dot(vec, center))
+			}
+			if best < lowest {
+				farthest, lowest = i, best
+			}
+		}
+		if lowest >= 1-1e-9 {
+			break // Remaining vectors duplicate existing centers
+		}
+		centers = append(centers, vectors[farthest])
+	}
+
+	assignment := make([]int, len(vectors))
+	for iter := 0; iter < maxClusterIterations; iter++ {
+		changed := iter == 0
+		for i, vec := range vectors {
+			best, bestSim := 0, math.Inf(-1)
+			for c, center := range centers {
+				if sim := dot(vec, center); sim > bestSim {
+					best, bestSim = c, sim
+				}
+			}
+			if assignment[i] != best {
+				assignment[i] = best
+				changed = true
+			}
+		}
+		if !changed {
+			break
+		}
+
+		// Move each center to the normalized mean of its members
+		for c := range centers {
+			sum := make([]float64, len(centers[c]))
+			for i, vec := range vectors {
+				if assignment[i] != c {
+					continue
+				}
+				for j, v := range vec {
+					sum[j] += v
+				}
+			}
+			centers[c] = normalize(sum)
+		}
+	}
+
+	groups := make([][]int, len(centers))
+	for i, c := range assignment {
+		groups[c] = append(groups[c], i)
+	}
+	nonEmpty := make([][]int, 0, len(groups))
+	for _, group := range groups {
+		if len(group) > 0 {
+			nonEmpty = append(nonEmpty, group)
+		}
+	}
+	return nonEmpty
+}
+
+// medoid returns the member most similar on average to the rest of its group
+func medoid(vectors [][]float64, members []int) int {
+	best, bestScore := members[0], math.Inf(-1)
+	for _, i := range members {
+		score := 0.0
+		for _, j := range members {
+			score += dot(vectors[i], vectors[j])
+		}
+		if score > bestScore {
+			best, bestScore = i, score
+		}
+	}
+	return best
+}
+
+// dot returns the dot product of two vectors, ignoring any length mismatch
+func dot(a, b []float64) float64 {
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+	sum := 0.0
+	for i := 0; i < n; i++ {
+		sum += a[i] * b[i]
+	}
+	return sum
+}
+
+// normalize scales a vector to unit length in place; zero vectors are left unchanged
+func normalize(vec []float64) []float64 {
+	norm := math.Sqrt(dot(vec, vec))
+	if norm == 0 {
+		return vec
+	}
+	for i := range vec {
+		vec[i] /= norm
+	}
+	return vec
+}
@@ -0,0 +1,147 @@
+package playmate
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// addTwoThemes records insights on gardening and astronomy, interleaved,
+// and returns the IDs of each theme sorted
+func addTwoThemes(t *testing.T, wc *WisdomCultivator) (garden, sky []string) {
+	t.Helper()
+	ctx := context.Background()
+	gardenInsights := []string{
+		"The garden soil needs compost before planting",
+		"Tomato seedlings in the garden need deep watering",
+		"Compost turns garden waste into rich soil",
+	}
+	skyInsights := []string{
+		"The telescope reveals craters on the moon",
+		"Saturn rings are visible through a small telescope",
+		"Dark skies make the moon and stars brighter",
+		"Stars through the telescope look sharper under dark skies",
+	}
+	for i := 0; i < len(skyInsights); i++ {
+		if i < len(gardenInsights) {
+			garden = append(garden, wc.AddInsight(ctx, gardenInsights[i], "test", 0.5).ID)
+		}
+		sky = append(sky, wc.AddInsight(ctx, skyInsights[i], "test", 0.5).ID)
+	}
+	sort.Strings(garden)
+	sort.Strings(sky)
+	return garden, sky
+}
+
+func sortedIDs(ids []string) string {
+	c := append([]string(nil), ids...)
+	sort.Strings(c)
+	return strings.Join(c, ",")
+}
+
+func TestClusterInsightsByKeywords(t *testing.T) {
+	wc, _ := newTestCultivator(t, nil)
+	garden, sky := addTwoThemes(t, wc)
+
+	clusters := wc.ClusterInsights(context.Background(), nil, 2)
+	if len(clusters) != 2 {
+		t.Fatalf("got %d clusters, want 2", len(clusters))
+	}
+
+	// Largest first
+	if got := sortedIDs(clusters[0].MemberIDs); got != strings.Join(sky, ",") || clusters[0].Size != 4 {
+		t.Errorf("first cluster = %v, want the four astronomy insights", clusters[0].MemberIDs)
+	}
+	if got := sortedIDs(clusters[1].MemberIDs); got != strings.Join(garden, ",") || clusters[1].Size != 3 {
+		t.Errorf("second cluster = %v, want the three gardening insights", clusters[1].MemberIDs)
+	}
+	if kw := clusters[0].Keywords; len(kw) == 0 || kw[0] != "telescope" {
+		t.Errorf("astronomy keywords = %v, want telescope first", kw)
+	}
+	if rep := clusters[1].Representative; rep == nil || !strings.Contains(strings.ToLower(rep.Content), "garden") {
+		t.Errorf("gardening representative = %+v", rep)
+	}
+}
+
+func TestClusterInsightsByEmbedding(t *testing.T) {
+	wc, _ := newTestCultivator(t, nil)
+	garden, sky := addTwoThemes(t, wc)
+
+	embed := func(ctx context.Context, text string) ([]float32, error) {
+		text = strings.ToLower(text)
+		if strings.Contains(text, "telescope") || strings.Contains(text, "moon") || strings.Contains(text, "saturn") {
+			return []float32{0.1, 1}, nil
+		}
+		return []float32{1, 0.1}, nil
+	}
+	clusters := wc.ClusterInsights(context.Background(), embed, 2)
+	if len(clusters) != 2 {
+		t.Fatalf("got %d clusters, want 2", len(clusters))
+	}
+	if sortedIDs(clusters[0].MemberIDs) != strings.Join(sky, ",") || sortedIDs(clusters[1].MemberIDs) != strings.Join(garden, ",") {
+		t.Errorf("clusters = %v and %v, want astronomy then gardening", clusters[0].MemberIDs, clusters[1].MemberIDs)
+	}
+}
+
+func TestClusterInsightsFallsBackWhenEmbeddingFails(t *testing.T) {
+	logger := &recordingLogger{}
+	wc, _ := newTestCultivator(t, &WisdomConfig{Logger: logger})
+	addTwoThemes(t, wc)
+
+	failing := func(ctx context.Context, text string) ([]float32, error) {
+		return nil, errors.New("embedding service down")
+	}
+	if clusters := wc.ClusterInsights(context.Background(), failing, 2); len(clusters) != 2 {
+		t.Errorf("got %d clusters, want 2 from the keyword fallback", len(clusters))
+	}
+	if len(logger.find("failed to embed insights, clustering by keywords")) != 1 {
+		t.Error("embedding failure was not logged")
+	}
+}
+
+func TestClusterInsightsFallsBackOnMixedDimensions(t *testing.T) {
+	logger := &recordingLogger{}
+	wc, _ := newTestCultivator(t, &WisdomConfig{Logger: logger})
+	garden, sky := addTwoThemes(t, wc)
+
+	// Gardening insights embed with one more dimension than the rest
+	mixed := func(ctx context.Context, text string) ([]float32, error) {
+		if strings.Contains(strings.ToLower(text), "garden") {
+			return []float32{1, 0, 0}, nil
+		}
+		return []float32{0, 1}, nil
+	}
+	clusters := wc.ClusterInsights(context.Background(), mixed, 2)
+	if len(clusters) != 2 {
+		t.Fatalf("got %d clusters, want 2 from the keyword fallback", len(clusters))
+	}
+	if got := sortedIDs(clusters[0].MemberIDs); got != strings.Join(sky, ",") {
+		t.Errorf("first cluster = %v, want the astronomy insights", clusters[0].MemberIDs)
+	}
+	if got := sortedIDs(clusters[1].MemberIDs); got != strings.Join(garden, ",") {
+		t.Errorf("second cluster = %v, want the gardening insights", clusters[1].MemberIDs)
+	}
+	if len(logger.find("failed to embed insights, clustering by keywords")) != 1 {
+		t.Error("dimension mismatch was not logged")
+	}
+}
+
+func TestClusterInsightsEdgeCases(t *testing.T) {
+	wc, _ := newTestCultivator(t, nil)
+	if got := wc.ClusterInsights(context.Background(), nil, 3); len(got) != 0 {
+		t.Errorf("clusters with no insights = %v, want none", got)
+	}
+
+	wc.AddInsight(context.Background(), "compost feeds the soil", "test", 0.5)
+	got := wc.ClusterInsights(context.Background(), nil, 5)
+	if len(got) != 1 || got[0].Size != 1 {
+		t.Errorf("k above the insight count gave %+v, want one cluster", got)
+	}
+
+	got[0].Representative.Content = "mutated"
+	if wc.GetRecentInsights(1)[0].Content != "compost feeds the soil" {
+		t.Error("insight changed through a cluster representative")
+	}
+}
@@ -0,0 +1,109 @@
+package playmate
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+// linkSides returns the insight's connected principles and the principle's
+// supporting insights
+func linkSides(wc *WisdomCultivator, insightID, principleID string) ([]string, []string) {
+	wc.mu.RLock()
+	defer wc.mu.RUnlock()
+	return wc.findInsight(insightID).Connections, wc.Principles[principleID].Insights
+}
+
+func TestLinkInsightToPrinciple(t *testing.T) {
+	wc, _ := newTestCultivator(t, nil)
+	insight := wc.AddInsight(context.Background(), "Anger fades when named", "reflection", 0.6)
+	principle := wc.AddPrinciple("Name feelings to tame them", []WisdomDimension{DimensionEquanimity}, "reflection")
+
+	if err := wc.LinkInsightToPrinciple(insight.ID, principle.ID); err != nil {
+		t.Fatal(err)
+	}
+	connections, insights := linkSides(wc, insight.ID, principle.ID)
+	if !reflect.DeepEqual(connections, []string{principle.ID}) {
+		t.Errorf("insight connections = %v, want [%s]", connections, principle.ID)
+	}
+	if !reflect.DeepEqual(insights, []string{insight.ID}) {
+		t.Errorf("principle insights = %v, want [%s]", insights, insight.ID)
+	}
+
+	// Linking again changes nothing
+	if err := wc.LinkInsightToPrinciple(insight.ID, principle.ID); err != nil {
+		t.Fatal(err)
+	}
+	connections, insights = linkSides(wc, insight.ID, principle.ID)
+	if len(connections) != 1 || len(insights) != 1 {
+		t.Errorf("relinking duplicated the connection: %v, %v", connections, insights)
+	}
+}
+
+func TestLinkInsightToPrincipleErrors(t *testing.T) {
+	wc, _ := newTestCultivator(t, nil)
+	insight := wc.AddInsight(context.Background(), "Anger fades when named", "reflection", 0.6)
+	principle := wc.AddPrinciple("Name feelings to tame them", []WisdomDimension{DimensionEquanimity}, "reflection")
+
+	if err := wc.LinkInsightToPrinciple("missing", principle.ID); err == nil {
+		t.Error("linked a missing insight")
+	}
+	if err := wc.LinkInsightToPrinciple(insight.ID, "missing"); err == nil {
+		t.Error("linked to a missing principle")
+	}
+	connections, insights := linkSides(wc, insight.ID, principle.ID)
+	if len(connections) != 0 || len(insights) != 0 {
+		t.Errorf("failed links recorded connections: %v, %v", connections, insights)
+	}
+}
+
+func TestDimensionReportCountsSupportingInsights(t *testing.T) {
+	ctx := context.Background()
+	wc, _ := newTestCultivator(t, nil)
+	calm := wc.AddPrinciple("Breathe before answering", []WisdomDimension{DimensionEquanimity}, "reflection")
+	steady := wc.AddPrinciple("Storms pass", []WisdomDimension{DimensionEquanimity, DimensionPerspective}, "reflection")
+	kind := wc.AddPrinciple("Assume good intent", []WisdomDimension{DimensionCompassion}, "reflection")
+	named := wc.AddInsight(ctx, "Anger fades when named", "reflection", 0.6)
+	waited := wc.AddInsight(ctx, "Waiting a day changed my reply", "reflection", 0.5)
+
+	for _, link := range [][2]string{
+		{named.ID, calm.ID},
+		{named.ID, steady.ID}, // Counted once per dimension
+		{waited.ID, steady.ID},
+		{waited.ID, kind.ID},
+	} {
+		if err := wc.LinkInsightToPrinciple(link[0], link[1]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for dim, want := range map[WisdomDimension]int{
+		DimensionEquanimity:    2,
+		DimensionPerspective:   2,
+		DimensionCompassion:    1,
+		DimensionTranscendence: 0,
+	} {
+		if got := wc.GetDimensionReport(dim)["supporting_insights"]; got != want {
+			t.Errorf("%s supporting_insights = %v, want %d", dim, got, want)
+		}
+	}
+}
+
+func TestInsightLinksSaved(t *testing.T) {
+	store := &memoryStore{}
+	wc, _ := newTestCultivator(t, &WisdomConfig{PersistPath: "wisdom", Store: store})
+	insight := wc.AddInsight(context.Background(), "Anger fades when named", "reflection", 0.6)
+	principle := wc.AddPrinciple("Name feelings to tame them", []WisdomDimension{DimensionEquanimity}, "reflection")
+	if err := wc.LinkInsightToPrinciple(insight.ID, principle.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := wc.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, _ := newTestCultivator(t, &WisdomConfig{PersistPath: "wisdom", Store: store})
+	connections, insights := linkSides(loaded, insight.ID, principle.ID)
+	if !reflect.DeepEqual(connections, []string{principle.ID}) || !reflect.DeepEqual(insights, []string{insight.ID}) {
+		t.Errorf("reloaded link = %v / %v, want both sides connected", connections, insights)
+	}
+}
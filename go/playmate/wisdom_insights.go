@@ -0,0 +1,56 @@
+package playmate
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// defaultInsightHalfLife is how long an insight takes to lose half its relevance
+const defaultInsightHalfLife = 7 * 24 * time.Hour
+
+// RelevantInsights returns copies of up to n insights ranked for reflection by
+// depth weighted by recency, so a deep older insight can outrank a shallow new
+// one. Relevance halves every InsightHalfLife.
+func (wc *WisdomCultivator) RelevantInsights(n int) []*WisdomInsight {
+	wc.mu.RLock()
+	defer wc.mu.RUnlock()
+
+	now := wc.clock.Now()
+	scores := make(map[*WisdomInsight]float64, len(wc.Insights))
+	ranked := make([]*WisdomInsight, len(wc.Insights))
+	copy(ranked, wc.Insights)
+	for _, insight := range ranked {
+		scores[insight] = insight.Depth * wc.recencyDecay(now.Sub(insight.Timestamp))
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if scores[ranked[i]] != scores[ranked[j]] {
+			return scores[ranked[i]] > scores[ranked[j]]
+		}
+		return ranked[i].Timestamp.After(ranked[j].Timestamp)
+	})
+
+	if n >= 0 && n < len(ranked) {
+		ranked = ranked[:n]
+	}
+	for i, insight := range ranked {
+		ranked[i] = insight.clone()
+	}
+	return ranked
+}
+
+// clone returns a deep copy of the insight
+func (i *WisdomInsight) clone() *WisdomInsight {
+	c := *i
+	c.Connections = append([]string(nil), i.Connections...)
+	return &c
+}
+
+// recencyDecay returns the weight, from 1 down toward 0, of something age old
+func (wc *WisdomCultivator) recencyDecay(age time.Duration) float64 {
+	if age <= 0 {
+		return 1
+	}
+	return math.Pow(0.5, float64(age)/float64(wc.insightHalfLife))
+}
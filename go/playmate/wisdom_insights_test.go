@@ -0,0 +1,64 @@
+package playmate
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// insightContents returns the content of each insight in order
+func insightContents(insights []*WisdomInsight) []string {
+	contents := make([]string, len(insights))
+	for i, insight := range insights {
+		contents[i] = insight.Content
+	}
+	return contents
+}
+
+func TestRelevantInsightsWeighsDepthAgainstRecency(t *testing.T) {
+	tests := []struct {
+		name     string
+		halfLife time.Duration
+		want     []string
+	}{
+		{"slow decay favours depth", 7 * 24 * time.Hour, []string{"deep and old", "shallow and new"}},
+		{"fast decay favours recency", 12 * time.Hour, []string{"shallow and new", "deep and old"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			wc, clock := newTestCultivator(t, &WisdomConfig{InsightHalfLife: tt.halfLife})
+			wc.AddInsight(ctx, "deep and old", "test", 0.9)
+			clock.Advance(2 * 24 * time.Hour)
+			wc.AddInsight(ctx, "shallow and new", "test", 0.3)
+
+			if got := insightContents(wc.RelevantInsights(-1)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RelevantInsights = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRelevantInsightsLimit(t *testing.T) {
+	ctx := context.Background()
+	wc, clock := newTestCultivator(t, nil)
+	for _, content := range []string{"first", "second", "third"} {
+		wc.AddInsight(ctx, content, "test", 0.5)
+		clock.Advance(time.Hour)
+	}
+
+	// Equal depths rank newest first
+	if got, want := insightContents(wc.RelevantInsights(2)), []string{"third", "second"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("RelevantInsights(2) = %v, want %v", got, want)
+	}
+	if got := wc.RelevantInsights(0); len(got) != 0 {
+		t.Errorf("RelevantInsights(0) = %v, want none", insightContents(got))
+	}
+
+	// Results are copies
+	wc.RelevantInsights(1)[0].Content = "changed"
+	if got := wc.RelevantInsights(1)[0].Content; got != "third" {
+		t.Errorf("modifying a result changed the insight to %q", got)
+	}
+}
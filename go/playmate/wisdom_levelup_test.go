@@ -0,0 +1,77 @@
+package playmate
+
+import (
+	"reflect"
+	"testing"
+)
+
+// levelChange records one OnLevelUp call
+type levelChange struct {
+	dim      WisdomDimension
+	from, to string
+}
+
+// newLevelCultivator creates a cultivator with every dimension at start, exact
+// growth, and OnLevelUp recording into changes
+func newLevelCultivator(t *testing.T, start float64, notifyDown bool) (*WisdomCultivator, *[]levelChange) {
+	changes := new([]levelChange)
+	wc, _ := newTestCultivator(t, &WisdomConfig{
+		InitialMetrics:  uniformMetrics(start),
+		GrowthCurve:     func(current, amount float64) float64 { return amount },
+		NotifyLevelDown: notifyDown,
+		OnLevelUp: func(dim WisdomDimension, from, to string) {
+			*changes = append(*changes, levelChange{dim, from, to})
+		},
+	})
+	*changes = nil
+	return wc, changes
+}
+
+func TestOnLevelUpFiresOncePerCrossing(t *testing.T) {
+	wc, changes := newLevelCultivator(t, 0.3, false)
+
+	for i := 0; i < 6; i++ {
+		wc.GrowDimension(DimensionCompassion, 0.03, "test")
+	}
+	want := []levelChange{{DimensionCompassion, "developing", "established"}}
+	if !reflect.DeepEqual(*changes, want) {
+		t.Errorf("level changes = %v, want %v", *changes, want)
+	}
+}
+
+func TestOnLevelUpSpanningLevels(t *testing.T) {
+	wc, changes := newLevelCultivator(t, 0.1, false)
+
+	wc.GrowDimension(DimensionCompassion, 0.55, "epiphany")
+	want := []levelChange{{DimensionCompassion, "nascent", "mature"}}
+	if !reflect.DeepEqual(*changes, want) {
+		t.Errorf("level changes = %v, want one jump %v", *changes, want)
+	}
+}
+
+func TestOnLevelUpIgnoresDeclineByDefault(t *testing.T) {
+	wc, changes := newLevelCultivator(t, 0.42, false)
+
+	wc.RecordSetback(DimensionCompassion, 0.05, "test")
+	if len(*changes) != 0 {
+		t.Errorf("level changes after a setback = %v, want none", *changes)
+	}
+
+	// Regaining the level counts as a crossing
+	wc.GrowDimension(DimensionCompassion, 0.05, "test")
+	want := []levelChange{{DimensionCompassion, "developing", "established"}}
+	if !reflect.DeepEqual(*changes, want) {
+		t.Errorf("level changes after recovering = %v, want %v", *changes, want)
+	}
+}
+
+func TestNotifyLevelDown(t *testing.T) {
+	wc, changes := newLevelCultivator(t, 0.42, true)
+
+	wc.RecordSetback(DimensionCompassion, 0.05, "test")
+	wc.RecordSetback(DimensionCompassion, 0.05, "test")
+	want := []levelChange{{DimensionCompassion, "established", "developing"}}
+	if !reflect.DeepEqual(*changes, want) {
+		t.Errorf("level changes = %v, want %v", *changes, want)
+	}
+}
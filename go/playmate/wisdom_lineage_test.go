@@ -0,0 +1,59 @@
+package playmate
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestGetLineageTwoLevels(t *testing.T) {
+	wc, _ := newTestCultivator(t, nil)
+
+	listen := wc.AddPrinciple("Listen before answering", []WisdomDimension{DimensionCompassion}, "test")
+	pause := wc.AddPrinciple("Pause before reacting", []WisdomDimension{DimensionEquanimity}, "test")
+	insight := wc.AddInsight(context.Background(), "people open up when they feel heard", "conversation", 0.5)
+
+	patience, err := wc.DerivePrinciple([]string{listen.ID, insight.ID}, "Patience is a form of care", []WisdomDimension{DimensionCompassion}, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	presence, err := wc.DerivePrinciple([]string{patience.ID, pause.ID}, "Presence comes before advice", []WisdomDimension{DimensionReflection}, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(presence.DerivedFrom, []string{patience.ID, pause.ID}) {
+		t.Errorf("DerivedFrom = %v, want [%s %s]", presence.DerivedFrom, patience.ID, pause.ID)
+	}
+
+	// Nearest ancestors first; the insight parent ends its branch
+	var got []string
+	for _, p := range wc.GetLineage(presence.ID) {
+		got = append(got, p.ID)
+	}
+	want := []string{patience.ID, pause.ID, listen.ID}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("lineage = %v, want %v", got, want)
+	}
+
+	if lineage := wc.GetLineage(listen.ID); len(lineage) != 0 {
+		t.Errorf("lineage of a root principle = %v, want empty", lineage)
+	}
+	if lineage := wc.GetLineage("missing"); len(lineage) != 0 {
+		t.Errorf("lineage of a missing principle = %v, want empty", lineage)
+	}
+}
+
+func TestDerivePrincipleErrors(t *testing.T) {
+	wc, _ := newTestCultivator(t, nil)
+	before := len(wc.GetAllPrinciples())
+
+	if _, err := wc.DerivePrinciple(nil, "From nothing", nil, "test"); err == nil {
+		t.Error("deriving without parents succeeded")
+	}
+	if _, err := wc.DerivePrinciple([]string{"principle_missing"}, "From nowhere", nil, "test"); err == nil {
+		t.Error("deriving from a missing parent succeeded")
+	}
+	if n := len(wc.GetAllPrinciples()); n != before {
+		t.Errorf("failed derivations stored %d principles", n-before)
+	}
+}
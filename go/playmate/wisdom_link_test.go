@@ -0,0 +1,85 @@
+package playmate
+
+import "testing"
+
+// linkBoost links two new principles and returns how much transcendence and
+// integration grew from the link itself
+func linkBoost(t *testing.T, a, b []WisdomDimension) (transcendence, integration float64) {
+	t.Helper()
+	wc, _ := newTestCultivator(t, nil)
+	p1 := wc.AddPrinciple("first", a, "test")
+	p2 := wc.AddPrinciple("second", b, "test")
+
+	before := *wc.GetMetrics()
+	if err := wc.LinkPrinciples(p1.ID, p2.ID); err != nil {
+		t.Fatal(err)
+	}
+	after := *wc.GetMetrics()
+	return after.Transcendence - before.Transcendence, after.Integration - before.Integration
+}
+
+func TestLinkBoostGrowsWithDiversity(t *testing.T) {
+	overlapping, _ := linkBoost(t,
+		[]WisdomDimension{DimensionCompassion, DimensionReflection},
+		[]WisdomDimension{DimensionCompassion})
+	if overlapping != 0 {
+		t.Errorf("link spanning two dimensions grew transcendence by %v, want 0", overlapping)
+	}
+
+	three, threeIntegration := linkBoost(t,
+		[]WisdomDimension{DimensionCompassion, DimensionReflection},
+		[]WisdomDimension{DimensionEquanimity})
+	five, fiveIntegration := linkBoost(t,
+		[]WisdomDimension{DimensionCompassion, DimensionReflection, DimensionPerspective},
+		[]WisdomDimension{DimensionEquanimity, DimensionUnderstanding})
+	if three <= 0 || threeIntegration <= 0 {
+		t.Fatalf("link spanning three dimensions grew transcendence %v, integration %v; want both positive", three, threeIntegration)
+	}
+	if five <= three || fiveIntegration <= threeIntegration {
+		t.Errorf("five-dimension link grew %v/%v, three-dimension link %v/%v; want the more diverse link larger",
+			five, fiveIntegration, three, threeIntegration)
+	}
+}
+
+func TestDeriveAcrossDomainsGrowsTranscendence(t *testing.T) {
+	wc, _ := newTestCultivator(t, nil)
+	listen := wc.AddPrinciple("Listen first", []WisdomDimension{DimensionCompassion}, "test")
+	pause := wc.AddPrinciple("Pause before reacting", []WisdomDimension{DimensionEquanimity}, "test")
+
+	before := wc.GetMetrics().Transcendence
+	if _, err := wc.DerivePrinciple([]string{listen.ID, pause.ID}, "Calm attention is care", []WisdomDimension{DimensionReflection}, "test"); err != nil {
+		t.Fatal(err)
+	}
+	if after := wc.GetMetrics().Transcendence; after <= before {
+		t.Errorf("transcendence %v -> %v after a three-dimension derivation, want growth", before, after)
+	}
+}
+
+func TestLinkPrinciplesErrors(t *testing.T) {
+	wc, _ := newTestCultivator(t, nil)
+	p := wc.AddPrinciple("Listen first", []WisdomDimension{DimensionCompassion}, "test")
+
+	if err := wc.LinkPrinciples(p.ID, p.ID); err == nil {
+		t.Error("linking a principle to itself succeeded")
+	}
+	if err := wc.LinkPrinciples(p.ID, "principle_missing"); err == nil {
+		t.Error("linking to a missing principle succeeded")
+	}
+}
+
+func TestRelinkingIsNoOp(t *testing.T) {
+	wc, _ := newTestCultivator(t, nil)
+	p1 := wc.AddPrinciple("first", []WisdomDimension{DimensionCompassion, DimensionReflection}, "test")
+	p2 := wc.AddPrinciple("second", []WisdomDimension{DimensionEquanimity}, "test")
+	if err := wc.LinkPrinciples(p1.ID, p2.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	before := *wc.GetMetrics()
+	if err := wc.LinkPrinciples(p2.ID, p1.ID); err != nil {
+		t.Fatal(err)
+	}
+	if after := *wc.GetMetrics(); after.Transcendence != before.Transcendence {
+		t.Errorf("relinking grew transcendence %v -> %v", before.Transcendence, after.Transcendence)
+	}
+}
@@ -0,0 +1,109 @@
+package playmate
+
+import (
+	"context"
+	"sort"
+
+	"github.com/o9nn/un9n/go/vectormem"
+)
+
+// principleIDKey is the memory metadata key linking a wisdom memory to its principle
+const principleIDKey = "principle_id"
+
+// RelevantPrinciples returns up to n principles most relevant to a situation,
+// ranked by the hypergraph memory. Principles not yet stored in mem are added
+// as wisdom memories first, so each principle is embedded only once. Ranking
+// uses Peek, so looking up principles doesn't count as recalling them, and
+// the results are copies.
+//
+// Stored principles are tracked by memory ID rather than metadata, since Add
+// may merge a near-duplicate principle into an existing memory and overwrite
+// its metadata; principles sharing a memory rank together, in ID order.
+func (wc *WisdomCultivator) RelevantPrinciples(ctx context.Context, mem *vectormem.HypergraphMemory, query string, n int) []*WisdomPrinciple {
+	relevant := make([]*WisdomPrinciple, 0)
+	if mem == nil || n <= 0 {
+		return relevant
+	}
+
+	wc.mu.RLock()
+	byID := make(map[string]*WisdomPrinciple, len(wc.Principles))
+	known := make(map[string]string, len(wc.principleMemories))
+	for id, principle := range wc.Principles {
+		if principle.Archived {
+			continue
+		}
+		byID[id] = principle.clone()
+		if memID, ok := wc.principleMemories[id]; ok {
+			known[id] = memID
+		}
+	}
+	wc.mu.RUnlock()
+
+	// Store principles whose memory is missing, in a stable order
+	ids := make([]string, 0, len(byID))
+	for id := range byID {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	added := make(map[string]string)
+	for _, id := range ids {
+		if memID, ok := known[id]; ok {
+			if _, err := mem.Get(memID); err == nil {
+				continue
+			}
+		}
+		principle := byID[id]
+		metadata := map[string]interface{}{
+			principleIDKey: id,
+			"source":       principle.Source,
+		}
+		stored, err := mem.Add(ctx, vectormem.WisdomMemory, principle.Statement, metadata)
+		if err != nil {
+			wc.logger.Warn("failed to store principle in memory", "id", id, "error", err)
+			continue
+		}
+		added[id] = stored.ID
+		known[id] = stored.ID
+	}
+
+	if len(added) > 0 {
+		wc.mu.Lock()
+		if wc.principleMemories == nil {
+			wc.principleMemories = make(map[string]string)
+		}
+		for id, memID := range added {
+			wc.principleMemories[id] = memID
+		}
+		wc.mu.Unlock()
+	}
+
+	// Group principles by memory; ids is sorted, so each group is in ID order
+	byMemory := make(map[string][]string)
+	for _, id := range ids {
+		if memID, ok := known[id]; ok {
+			byMemory[memID] = append(byMemory[memID], id)
+		}
+	}
+
+	// Other wisdom memories may rank alongside principles, so search them all
+	results, err := mem.Peek(ctx, query, vectormem.QueryOptions{
+		Type:  vectormem.WisdomMemory,
+		Limit: len(mem.Recent(vectormem.WisdomMemory, -1)),
+	})
+	if err != nil {
+		wc.logger.Warn("failed to query principles", "error", err)
+		return relevant
+	}
+
+	for _, m := range results {
+		for _, id := range byMemory[m.ID] {
+			relevant = append(relevant, byID[id])
+			if len(relevant) == n {
+				return relevant
+			}
+		}
+	}
+
+	return relevant
+}
@@ -0,0 +1,162 @@
+package playmate
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/o9nn/un9n/go/vectormem"
+)
+
+// newPrincipleMemory returns a memory whose embeddings count a few topic
+// words, along with a counter of embedding calls
+func newPrincipleMemory(t *testing.T, configure func(*vectormem.HypergraphConfig)) (*vectormem.HypergraphMemory, *int64) {
+	t.Helper()
+	vocab := []string{"journal", "rest", "forgive"}
+	var calls int64
+	config := vectormem.DefaultConfig()
+	config.EmbeddingFunc = func(ctx context.Context, text string) ([]float32, error) {
+		atomic.AddInt64(&calls, 1)
+		// A constant component keeps off-topic text from embedding as zero
+		vec := []float32{0.1, 0, 0, 0}
+		for _, word := range strings.Fields(strings.ToLower(text)) {
+			for i, v := range vocab {
+				if word == v {
+					vec[i+1]++
+				}
+			}
+		}
+		return vec, nil
+	}
+	if configure != nil {
+		configure(config)
+	}
+	mem, err := vectormem.NewHypergraphMemory(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(mem.Stop)
+	return mem, &calls
+}
+
+func TestRelevantPrinciplesRanksBySimilarity(t *testing.T) {
+	wc, _ := newTestCultivator(t, nil)
+	journal := wc.AddPrinciple("Journal each evening to notice patterns", []WisdomDimension{DimensionCompassion}, "test")
+	rest := wc.AddPrinciple("Rest restores clarity", []WisdomDimension{DimensionEquanimity}, "test")
+	wc.AddPrinciple("Forgive yourself as readily as you forgive others", []WisdomDimension{DimensionCompassion}, "test")
+	mem, _ := newPrincipleMemory(t, nil)
+	ctx := context.Background()
+
+	got := wc.RelevantPrinciples(ctx, mem, "should I journal tonight", 2)
+	if len(got) != 2 || got[0].ID != journal.ID {
+		t.Fatalf("relevant principles = %v, want %q first", statements(got), journal.Statement)
+	}
+
+	got = wc.RelevantPrinciples(ctx, mem, "I am exhausted and need rest", 1)
+	if len(got) != 1 || got[0].ID != rest.ID {
+		t.Errorf("relevant principles = %v, want only %q", statements(got), rest.Statement)
+	}
+}
+
+func TestRelevantPrinciplesEmbedsOnce(t *testing.T) {
+	wc, _ := newTestCultivator(t, nil)
+	wc.AddPrinciple("Journal each evening to notice patterns", []WisdomDimension{DimensionReflection}, "test")
+	mem, calls := newPrincipleMemory(t, nil)
+	ctx := context.Background()
+	principles := len(wc.GetPrinciples())
+
+	wc.RelevantPrinciples(ctx, mem, "journal", 1)
+	if n := len(mem.Recent(vectormem.WisdomMemory, -1)); n != principles {
+		t.Fatalf("stored %d wisdom memories, want one per principle (%d)", n, principles)
+	}
+	first := atomic.LoadInt64(calls)
+
+	// A second lookup embeds only the query
+	wc.RelevantPrinciples(ctx, mem, "journal", 1)
+	if n := len(mem.Recent(vectormem.WisdomMemory, -1)); n != principles {
+		t.Errorf("second lookup stored %d wisdom memories, want %d", n, principles)
+	}
+	if got := atomic.LoadInt64(calls) - first; got != 1 {
+		t.Errorf("second lookup made %d embedding calls, want 1", got)
+	}
+}
+
+func TestRelevantPrinciplesLeavesMemoryUntouched(t *testing.T) {
+	wc, _ := newTestCultivator(t, nil)
+	wc.AddPrinciple("Journal each evening to notice patterns", []WisdomDimension{DimensionReflection}, "test")
+	mem, _ := newPrincipleMemory(t, func(c *vectormem.HypergraphConfig) {
+		c.QueryLogSize = 10
+		c.ReinforcementRate = 0.5
+	})
+	ctx := context.Background()
+
+	wc.RelevantPrinciples(ctx, mem, "journal", 1)
+	wc.RelevantPrinciples(ctx, mem, "journal", 1)
+
+	for _, m := range mem.Recent(vectormem.WisdomMemory, -1) {
+		if m.AccessCount != 0 || m.Importance != 1 {
+			t.Errorf("memory %q access count %d, importance %v; want lookups not to count as recall", m.Content, m.AccessCount, m.Importance)
+		}
+	}
+	if got := mem.RecentQueries(10); len(got) != 0 {
+		t.Errorf("query log has %d entries, want none", len(got))
+	}
+}
+
+func TestRelevantPrinciplesSurvivesDedupMerge(t *testing.T) {
+	wc, _ := newTestCultivator(t, nil)
+	first := wc.AddPrinciple("Journal to notice", []WisdomDimension{DimensionReflection}, "test")
+	second := wc.AddPrinciple("Journal to remember", []WisdomDimension{DimensionReflection}, "test")
+	mem, calls := newPrincipleMemory(t, func(c *vectormem.HypergraphConfig) { c.DedupThreshold = 0.99 })
+	ctx := context.Background()
+
+	got := wc.RelevantPrinciples(ctx, mem, "journal", 2)
+	if len(got) != 2 || got[0].ID != first.ID || got[1].ID != second.ID {
+		t.Fatalf("relevant principles = %v, want both journal principles", statements(got))
+	}
+	stored := len(mem.Recent(vectormem.WisdomMemory, -1))
+	before := atomic.LoadInt64(calls)
+
+	// The merged memory's metadata names only one principle, but neither is re-added
+	wc.RelevantPrinciples(ctx, mem, "journal", 2)
+	if n := len(mem.Recent(vectormem.WisdomMemory, -1)); n != stored {
+		t.Errorf("second lookup stored %d wisdom memories, want %d", n, stored)
+	}
+	if got := atomic.LoadInt64(calls) - before; got != 1 {
+		t.Errorf("second lookup made %d embedding calls, want 1", got)
+	}
+}
+
+func TestRelevantPrinciplesReturnsCopies(t *testing.T) {
+	wc, _ := newTestCultivator(t, nil)
+	journal := wc.AddPrinciple("Journal each evening to notice patterns", []WisdomDimension{DimensionReflection}, "test")
+	mem, _ := newPrincipleMemory(t, nil)
+
+	got := wc.RelevantPrinciples(context.Background(), mem, "journal", 1)
+	if len(got) != 1 {
+		t.Fatalf("relevant principles = %v, want one", statements(got))
+	}
+	got[0].Statement = "scribbled"
+
+	for _, p := range wc.GetPrinciples() {
+		if p.ID == journal.ID && p.Statement != journal.Statement {
+			t.Errorf("stored statement = %q, want it unchanged", p.Statement)
+		}
+	}
+}
+
+func TestRelevantPrinciplesWithoutMemory(t *testing.T) {
+	wc, _ := newTestCultivator(t, nil)
+	if got := wc.RelevantPrinciples(context.Background(), nil, "journal", 3); len(got) != 0 {
+		t.Errorf("RelevantPrinciples(nil) = %v, want none", statements(got))
+	}
+}
+
+func statements(principles []*WisdomPrinciple) []string {
+	out := make([]string, len(principles))
+	for i, p := range principles {
+		out[i] = p.Statement
+	}
+	return out
+}
@@ -0,0 +1,41 @@
+package playmate
+
+import "time"
+
+const (
+	// stagnationWindow is how far back StagnantDimensions looks for growth
+	stagnationWindow = 7 * 24 * time.Hour
+
+	// stagnationThreshold is the growth over stagnationWindow below which a dimension is stagnant
+	stagnationThreshold = 0.01
+)
+
+// IsPlateaued reports whether every dimension grew by less than threshold
+// within the last window, meaning the caller should change strategy
+func (wc *WisdomCultivator) IsPlateaued(window time.Duration, threshold float64) bool {
+	wc.mu.RLock()
+	defer wc.mu.RUnlock()
+
+	return len(wc.stagnantDimensions(window, threshold)) == len(allDimensions)
+}
+
+// StagnantDimensions returns the dimensions that grew by less than 0.01 over
+// the past week, in canonical dimension order
+func (wc *WisdomCultivator) StagnantDimensions() []WisdomDimension {
+	wc.mu.RLock()
+	defer wc.mu.RUnlock()
+
+	return wc.stagnantDimensions(stagnationWindow, stagnationThreshold)
+}
+
+// stagnantDimensions returns the dimensions whose growth within window is below threshold (must hold lock)
+func (wc *WisdomCultivator) stagnantDimensions(window time.Duration, threshold float64) []WisdomDimension {
+	cutoff := wc.clock.Now().Add(-window)
+	stagnant := make([]WisdomDimension, 0)
+	for _, dim := range allDimensions {
+		if wc.growthSince(dim, cutoff) < threshold {
+			stagnant = append(stagnant, dim)
+		}
+	}
+	return stagnant
+}
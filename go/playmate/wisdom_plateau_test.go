@@ -0,0 +1,88 @@
+package playmate
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// setGrowthHistory replaces the growth history with the given events
+func setGrowthHistory(wc *WisdomCultivator, events ...GrowthEvent) {
+	wc.mu.Lock()
+	defer wc.mu.Unlock()
+	wc.GrowthHistory = events
+}
+
+func TestIsPlateaued(t *testing.T) {
+	wc, clock := newTestCultivator(t, nil)
+	now := clock.Now()
+	growth := func(ago time.Duration, dim WisdomDimension, delta float64) GrowthEvent {
+		return GrowthEvent{Timestamp: now.Add(-ago), Dimension: dim, Delta: delta, Trigger: "test"}
+	}
+
+	t.Run("flat recent history", func(t *testing.T) {
+		setGrowthHistory(wc,
+			growth(30*24*time.Hour, DimensionCompassion, 0.2), // Long ago
+			growth(time.Hour, DimensionCompassion, 0.001),
+			growth(2*time.Hour, DimensionReflection, 0.002),
+		)
+		if !wc.IsPlateaued(7*24*time.Hour, 0.01) {
+			t.Error("flat recent history not reported as plateaued")
+		}
+	})
+
+	t.Run("one dimension growing", func(t *testing.T) {
+		setGrowthHistory(wc,
+			growth(time.Hour, DimensionCompassion, 0.001),
+			growth(3*24*time.Hour, DimensionUnderstanding, 0.02),
+		)
+		if wc.IsPlateaued(7*24*time.Hour, 0.01) {
+			t.Error("actively growing history reported as plateaued")
+		}
+		// The same growth falls outside a shorter window
+		if !wc.IsPlateaued(24*time.Hour, 0.01) {
+			t.Error("growth older than the window still counted")
+		}
+	})
+
+	t.Run("setbacks offset growth", func(t *testing.T) {
+		setGrowthHistory(wc,
+			growth(time.Hour, DimensionEquanimity, 0.05),
+			growth(2*time.Hour, DimensionEquanimity, -0.045),
+		)
+		if !wc.IsPlateaued(7*24*time.Hour, 0.01) {
+			t.Error("growth cancelled by setbacks not reported as plateaued")
+		}
+	})
+
+	t.Run("no history", func(t *testing.T) {
+		setGrowthHistory(wc)
+		if !wc.IsPlateaued(7*24*time.Hour, 0.01) {
+			t.Error("empty history not reported as plateaued")
+		}
+	})
+}
+
+func TestStagnantDimensions(t *testing.T) {
+	wc, clock := newTestCultivator(t, nil)
+	now := clock.Now()
+	setGrowthHistory(wc,
+		GrowthEvent{Timestamp: now.Add(-time.Hour), Dimension: DimensionUnderstanding, Delta: 0.05},
+		GrowthEvent{Timestamp: now.Add(-6 * 24 * time.Hour), Dimension: DimensionCompassion, Delta: 0.02},
+		GrowthEvent{Timestamp: now.Add(-8 * 24 * time.Hour), Dimension: DimensionReflection, Delta: 0.5},
+		GrowthEvent{Timestamp: now.Add(-time.Hour), Dimension: DimensionEquanimity, Delta: 0.005},
+	)
+
+	want := []WisdomDimension{
+		DimensionPerspective, DimensionIntegration, DimensionReflection, DimensionEquanimity, DimensionTranscendence,
+	}
+	if got := wc.StagnantDimensions(); !reflect.DeepEqual(got, want) {
+		t.Errorf("StagnantDimensions = %v, want %v", got, want)
+	}
+
+	// A week later everything has gone quiet
+	clock.Advance(stagnationWindow)
+	if got := wc.StagnantDimensions(); len(got) != len(allDimensions) {
+		t.Errorf("StagnantDimensions a week later = %v, want all dimensions", got)
+	}
+}
@@ -0,0 +1,79 @@
+package playmate
+
+import (
+	"fmt"
+	"math"
+)
+
+// baselineDimensionValue is the value every dimension starts from unless
+// WisdomConfig.InitialMetrics says otherwise
+const baselineDimensionValue = 0.1
+
+// defaultBaseline starts every dimension at baselineDimensionValue
+func defaultBaseline() map[WisdomDimension]float64 {
+	baseline := make(map[WisdomDimension]float64, len(allDimensions))
+	for _, dim := range allDimensions {
+		baseline[dim] = baselineDimensionValue
+	}
+	return baseline
+}
+
+// applyInitialMetrics validates configured starting values and makes them both
+// the current metrics and the baseline; nil keeps the defaults
+func (wc *WisdomCultivator) applyInitialMetrics(initial *WisdomMetrics) error {
+	if initial == nil {
+		return nil
+	}
+	for _, dim := range allDimensions {
+		value := initial.dimensionValue(dim)
+		if value < 0 || value > 1 {
+			return fmt.Errorf("invalid initial %s: %v", dim, value)
+		}
+	}
+	for _, dim := range allDimensions {
+		value := initial.dimensionValue(dim)
+		wc.baseline[dim] = value
+		wc.setDimensionValue(dim, value)
+	}
+	return nil
+}
+
+// restoreBaseline adopts a saved baseline so rebuilding after a restart replays
+// from the same starting values; state saved without one keeps the current
+// baseline (must hold lock)
+func (wc *WisdomCultivator) restoreBaseline(saved map[WisdomDimension]float64) {
+	for _, dim := range allDimensions {
+		if value, ok := saved[dim]; ok {
+			wc.baseline[dim] = value
+		}
+	}
+}
+
+// RebuildFromHistory repairs metrics that have drifted from the recorded
+// GrowthHistory, e.g. after a bug or a manual edit of saved state. Every
+// dimension is reset to its baseline (0.1 unless WisdomConfig.InitialMetrics
+// says otherwise) and each growth event is replayed in order; recorded deltas
+// already include the diminishing returns applied when they happened, so they
+// are added as-is, keeping each dimension within [0, 1]. Daily growth totals
+// and the overall score are recomputed too. Level-change notifications are not
+// sent.
+func (wc *WisdomCultivator) RebuildFromHistory() {
+	wc.mu.Lock()
+	defer wc.mu.Unlock()
+
+	for _, dim := range allDimensions {
+		wc.setDimensionValue(dim, wc.baseline[dim])
+	}
+	daily := make(map[string]float64)
+	for _, event := range wc.GrowthHistory {
+		value := wc.getDimensionValue(event.Dimension)
+		wc.setDimensionValue(event.Dimension, math.Max(0, math.Min(1.0, value+event.Delta)))
+		daily[event.Timestamp.Format("2006-01-02")] += event.Delta
+	}
+	wc.DailyGrowth = daily
+
+	wc.updateOverallScore()
+	wc.Metrics.LastUpdated = wc.clock.Now()
+	wc.dirty = true
+	wc.logger.Info("rebuilt wisdom metrics from growth history", "events", len(wc.GrowthHistory))
+}
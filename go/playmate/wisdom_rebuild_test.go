@@ -0,0 +1,107 @@
+package playmate
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// checkMetricsMatch compares every dimension and the overall score
+func checkMetricsMatch(t *testing.T, got, want *WisdomMetrics) {
+	t.Helper()
+	for _, dim := range allDimensions {
+		if g, w := got.dimensionValue(dim), want.dimensionValue(dim); math.Abs(g-w) > 1e-9 {
+			t.Errorf("%s = %v, want %v", dim, g, w)
+		}
+	}
+	if math.Abs(got.OverallScore-want.OverallScore) > 1e-9 {
+		t.Errorf("OverallScore = %v, want %v", got.OverallScore, want.OverallScore)
+	}
+}
+
+func TestRebuildFromHistoryRestoresMetrics(t *testing.T) {
+	wc, clock := newTestCultivator(t, nil)
+
+	wc.GrowDimension(DimensionUnderstanding, 0.3, "study")
+	wc.GrowDimension(DimensionCompassion, 0.2, "discussion")
+	clock.Advance(24 * time.Hour)
+	wc.GrowDimension(DimensionUnderstanding, 0.3, "study")
+	wc.RecordSetback(DimensionCompassion, 0.05, "impatience")
+	// Clamped at 1.0; the recorded delta is only the real change
+	wc.GrowDimension(DimensionEquanimity, 5, "retreat")
+
+	want := wc.GetMetrics()
+	wc.mu.RLock()
+	wantDaily := make(map[string]float64, len(wc.DailyGrowth))
+	for day, growth := range wc.DailyGrowth {
+		wantDaily[day] = growth
+	}
+	wc.mu.RUnlock()
+
+	var levelChanges int
+	wc.mu.Lock()
+	wc.onLevelUp = func(WisdomDimension, string, string) { levelChanges++ }
+	for _, dim := range allDimensions {
+		wc.setDimensionValue(dim, 0.9)
+	}
+	wc.DailyGrowth = map[string]float64{"1999-01-01": 3}
+	wc.updateOverallScore()
+	wc.mu.Unlock()
+
+	wc.RebuildFromHistory()
+
+	checkMetricsMatch(t, wc.GetMetrics(), want)
+	wc.mu.RLock()
+	defer wc.mu.RUnlock()
+	if len(wc.DailyGrowth) != len(wantDaily) {
+		t.Errorf("DailyGrowth = %v, want %v", wc.DailyGrowth, wantDaily)
+	}
+	for day, growth := range wantDaily {
+		if math.Abs(wc.DailyGrowth[day]-growth) > 1e-9 {
+			t.Errorf("DailyGrowth[%s] = %v, want %v", day, wc.DailyGrowth[day], growth)
+		}
+	}
+	if levelChanges != 0 {
+		t.Errorf("rebuild sent %d level-change notifications, want none", levelChanges)
+	}
+	if !wc.dirty {
+		t.Error("rebuild did not mark the cultivator dirty")
+	}
+}
+
+func TestRebuildFromHistoryUsesInitialMetrics(t *testing.T) {
+	initial := uniformMetrics(0.4)
+	wc, _ := newTestCultivator(t, &WisdomConfig{InitialMetrics: initial})
+	wc.GrowDimension(DimensionPerspective, 0.2, "travel")
+	want := wc.GetMetrics()
+
+	wc.mu.Lock()
+	wc.setDimensionValue(DimensionPerspective, 0)
+	wc.setDimensionValue(DimensionReflection, 0)
+	wc.mu.Unlock()
+
+	wc.RebuildFromHistory()
+	checkMetricsMatch(t, wc.GetMetrics(), want)
+	if got := wc.GetMetrics().Reflection; got != 0.4 {
+		t.Errorf("untouched dimension rebuilt to %v, want its initial 0.4", got)
+	}
+}
+
+func TestRebuildFromHistoryAfterReload(t *testing.T) {
+	store := &memoryStore{}
+	wc, _ := newTestCultivator(t, &WisdomConfig{
+		PersistPath:    "wisdom",
+		Store:          store,
+		InitialMetrics: uniformMetrics(0.3),
+	})
+	wc.GrowDimension(DimensionIntegration, 0.25, "synthesis")
+	want := wc.GetMetrics()
+	if err := wc.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Reloaded without InitialMetrics; the saved baseline must still apply
+	loaded, _ := newTestCultivator(t, &WisdomConfig{PersistPath: "wisdom", Store: store})
+	loaded.RebuildFromHistory()
+	checkMetricsMatch(t, loaded.GetMetrics(), want)
+}
@@ -0,0 +1,75 @@
+package playmate
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// RenderReport renders the cultivator's progress as a Markdown document with a
+// dimension table, the most confident principles, recent insights, and the
+// current growth streak. Recent growth and the streak are relative to the
+// cultivator's clock, so output is deterministic for a given state and time.
+func (wc *WisdomCultivator) RenderReport() string {
+	wc.mu.RLock()
+	defer wc.mu.RUnlock()
+
+	var b strings.Builder
+
+	b.WriteString("# Wisdom Report\n\n")
+	fmt.Fprintf(&b, "**Overall score:** %.3f  \n", wc.Metrics.OverallScore)
+	fmt.Fprintf(&b, "**Growth rate:** %.4f  \n", wc.Metrics.GrowthRate)
+	fmt.Fprintf(&b, "**Current streak:** %d day(s)\n\n", wc.currentStreak())
+
+	// Dimension table
+	b.WriteString("## Dimensions\n\n")
+	b.WriteString("| Dimension | Value | Level | Recent Growth (7d) |\n")
+	b.WriteString("|---|---|---|---|\n")
+	cutoff := wc.clock.Now().AddDate(0, 0, -7)
+	for _, dim := range allDimensions {
+		value := wc.getDimensionValue(dim)
+		fmt.Fprintf(&b, "| %s | %.3f | %s | %+.4f |\n",
+			dim, value, wc.getDimensionLevel(value), wc.growthSince(dim, cutoff))
+	}
+	b.WriteString("\n")
+
+	// Top principles by confidence
+	principles := make([]*WisdomPrinciple, 0, len(wc.Principles))
+	for _, p := range wc.Principles {
+		if !p.Archived {
+			principles = append(principles, p)
+		}
+	}
+	sort.Slice(principles, func(i, j int) bool {
+		if principles[i].Confidence != principles[j].Confidence {
+			return principles[i].Confidence > principles[j].Confidence
+		}
+		return principles[i].ID < principles[j].ID
+	})
+	if len(principles) > 5 {
+		principles = principles[:5]
+	}
+
+	b.WriteString("## Top Principles\n\n")
+	if len(principles) == 0 {
+		b.WriteString("_No principles yet._\n")
+	}
+	for i, p := range principles {
+		fmt.Fprintf(&b, "%d. %s _(confidence %.2f, %d validation(s))_\n",
+			i+1, p.Statement, p.Confidence, p.Validations)
+	}
+	b.WriteString("\n")
+
+	// Most recent insights, newest first
+	b.WriteString("## Recent Insights\n\n")
+	if len(wc.Insights) == 0 {
+		b.WriteString("_No insights yet._\n")
+	}
+	for i := len(wc.Insights) - 1; i >= 0 && i >= len(wc.Insights)-5; i-- {
+		insight := wc.Insights[i]
+		fmt.Fprintf(&b, "- %s: %s _(depth %.2f)_\n",
+			insight.Timestamp.Format("2006-01-02"), insight.Content, insight.Depth)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,59 @@
+package playmate
+
+import (
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files")
+
+// buildReportCultivator grows a cultivator through a fixed two-day history
+func buildReportCultivator(t *testing.T) *WisdomCultivator {
+	t.Helper()
+	ctx := context.Background()
+
+	clock := newFakeClock()
+	wc, err := NewWisdomCultivator(&WisdomConfig{Clock: clock})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wc.AddInsight(ctx, "Listening closely changes what I hear", "discussion", 0.6)
+	wc.GrowDimension(DimensionCompassion, 0.1, "practice")
+	clock.Advance(24 * time.Hour)
+	wc.AddInsight(ctx, "Stillness makes room for patterns to appear", "reflection", 0.8)
+	wc.AddPrinciple("Pause before answering", []WisdomDimension{DimensionEquanimity, DimensionReflection}, "experience")
+	wc.GrowDimension(DimensionEquanimity, 0.05, "practice")
+	return wc
+}
+
+func TestRenderReportGolden(t *testing.T) {
+	got := buildReportCultivator(t).RenderReport()
+
+	golden := filepath.Join("testdata", "wisdom_report.golden")
+	if *update {
+		if err := os.WriteFile(golden, []byte(got), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != string(want) {
+		t.Errorf("report differs from %s (run with -update to accept):\n%s", golden, got)
+	}
+}
+
+func TestRenderReportDeterministic(t *testing.T) {
+	first := buildReportCultivator(t).RenderReport()
+	for i := 0; i < 3; i++ {
+		if again := buildReportCultivator(t).RenderReport(); again != first {
+			t.Fatalf("render %d differs:\n%s\nwant:\n%s", i, again, first)
+		}
+	}
+}
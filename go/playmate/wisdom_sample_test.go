@@ -0,0 +1,93 @@
+package playmate
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// setPrinciples replaces the cultivator's principles with the given ones
+func setPrinciples(wc *WisdomCultivator, principles ...*WisdomPrinciple) {
+	wc.mu.Lock()
+	defer wc.mu.Unlock()
+	wc.Principles = make(map[string]*WisdomPrinciple)
+	for _, p := range principles {
+		wc.Principles[p.ID] = p
+	}
+}
+
+// sampleCounts draws one principle per trial and counts how often each ID is drawn
+func sampleCounts(wc *WisdomCultivator, trials int, dims []WisdomDimension) map[string]int {
+	r := rand.New(rand.NewSource(1))
+	counts := make(map[string]int)
+	for i := 0; i < trials; i++ {
+		for _, p := range wc.SamplePrinciples(1, dims, r) {
+			counts[p.ID]++
+		}
+	}
+	return counts
+}
+
+func TestSamplePrinciplesFavoursConfidence(t *testing.T) {
+	wc, _ := newTestCultivator(t, nil)
+	setPrinciples(wc,
+		&WisdomPrinciple{ID: "strong", Confidence: 0.9},
+		&WisdomPrinciple{ID: "weak", Confidence: 0.1},
+	)
+
+	counts := sampleCounts(wc, 2000, nil)
+	// Expect roughly 9:1; allow generous slack for sampling noise
+	if counts["strong"] < 6*counts["weak"] {
+		t.Errorf("counts = %v, want strong drawn far more often than weak", counts)
+	}
+	if counts["weak"] == 0 {
+		t.Errorf("counts = %v, want weak still drawn occasionally", counts)
+	}
+}
+
+func TestSamplePrinciplesDimensionBias(t *testing.T) {
+	wc, _ := newTestCultivator(t, nil)
+	setPrinciples(wc,
+		&WisdomPrinciple{ID: "calm", Confidence: 0.5, Dimensions: []WisdomDimension{DimensionEquanimity}},
+		&WisdomPrinciple{ID: "kind", Confidence: 0.5, Dimensions: []WisdomDimension{DimensionCompassion}},
+	)
+
+	counts := sampleCounts(wc, 2000, []WisdomDimension{DimensionEquanimity})
+	// A full overlap triples the weight, so expect roughly 3:1
+	if counts["calm"] < 2*counts["kind"] {
+		t.Errorf("counts = %v, want principles sharing the dimension favoured", counts)
+	}
+}
+
+func TestSamplePrinciplesWithoutReplacement(t *testing.T) {
+	wc, _ := newTestCultivator(t, nil)
+	setPrinciples(wc,
+		&WisdomPrinciple{ID: "a", Confidence: 0.9},
+		&WisdomPrinciple{ID: "b", Confidence: 0.5},
+		&WisdomPrinciple{ID: "c", Confidence: 0.2},
+		&WisdomPrinciple{ID: "zero", Confidence: 0},
+		&WisdomPrinciple{ID: "archived", Confidence: 0.9, Archived: true},
+	)
+
+	sampled := wc.SamplePrinciples(10, nil, rand.New(rand.NewSource(1)))
+	ids := make(map[string]bool)
+	for _, p := range sampled {
+		if ids[p.ID] {
+			t.Errorf("principle %s sampled twice", p.ID)
+		}
+		ids[p.ID] = true
+	}
+	if len(sampled) != 3 || !ids["a"] || !ids["b"] || !ids["c"] {
+		t.Errorf("sampled %v, want exactly the three active principles with confidence", ids)
+	}
+}
+
+func TestSamplePrinciplesReproducible(t *testing.T) {
+	wc, _ := newTestCultivator(t, nil)
+	draw := func() []string {
+		return statements(wc.SamplePrinciples(3, nil, rand.New(rand.NewSource(7))))
+	}
+	if first, second := draw(), draw(); !reflect.DeepEqual(first, second) {
+		t.Errorf("same seed sampled %v then %v", first, second)
+	}
+}
@@ -0,0 +1,114 @@
+package playmate
+
+import "fmt"
+
+// growthCurves are the built-in growth curves, by the name saved with the state
+var growthCurves = map[string]GrowthCurve{
+	"linear":    LinearGrowthCurve,
+	"quadratic": QuadraticGrowthCurve,
+}
+
+// customGrowthCurve names a GrowthCurve supplied without a GrowthCurveName
+const customGrowthCurve = "custom"
+
+// scoringConfig is the persisted scoring model, so state reloaded with a
+// different configuration can be detected and scored consistently
+type scoringConfig struct {
+	Weights     map[WisdomDimension]float64 `json:"weights"`
+	GrowthCurve string                      `json:"growth_curve"`
+}
+
+// configureScoring applies the growth curve and dimension weights from config
+func (wc *WisdomCultivator) configureScoring(config *WisdomConfig) error {
+	if config.GrowthCurve != nil {
+		wc.growthCurve = config.GrowthCurve
+		wc.growthCurveName = customGrowthCurve
+		wc.customCurve = true
+	}
+	if config.GrowthCurveName != "" {
+		if config.GrowthCurve == nil {
+			curve, ok := growthCurves[config.GrowthCurveName]
+			if !ok {
+				return fmt.Errorf("unknown growth curve: %s", config.GrowthCurveName)
+			}
+			wc.growthCurve = curve
+		}
+		wc.growthCurveName = config.GrowthCurveName
+		wc.customCurve = true
+	}
+
+	for dim, weight := range config.DimensionWeights {
+		if _, ok := dimensionWeights[dim]; !ok {
+			return fmt.Errorf("unknown wisdom dimension: %s", dim)
+		}
+		if weight <= 0 {
+			return fmt.Errorf("invalid weight for %s: %v", dim, weight)
+		}
+		wc.weights[dim] = weight
+		wc.customWeights = true
+	}
+	return nil
+}
+
+// scoringConfig returns the scoring model to persist (must hold lock)
+func (wc *WisdomCultivator) scoringConfig() *scoringConfig {
+	return &scoringConfig{
+		Weights:     copyWeights(wc.weights),
+		GrowthCurve: wc.growthCurveName,
+	}
+}
+
+// restoreScoring reconciles the saved scoring model with the configured one.
+// Explicitly configured weights or curves win; otherwise the saved ones are
+// restored so scoring does not silently change. Mismatches are logged. (must hold lock)
+func (wc *WisdomCultivator) restoreScoring(saved *scoringConfig) {
+	if saved == nil {
+		return
+	}
+
+	if len(saved.Weights) > 0 && !weightsEqual(saved.Weights, wc.weights) {
+		if wc.customWeights {
+			wc.logger.Warn("saved dimension weights differ from configured weights; using configured", "saved", saved.Weights)
+		} else {
+			for dim, weight := range saved.Weights {
+				if _, ok := wc.weights[dim]; ok && weight > 0 {
+					wc.weights[dim] = weight
+				}
+			}
+			wc.logger.Warn("restored saved dimension weights", "weights", wc.weights)
+		}
+	}
+
+	if saved.GrowthCurve != "" && saved.GrowthCurve != wc.growthCurveName {
+		curve, known := growthCurves[saved.GrowthCurve]
+		switch {
+		case wc.customCurve:
+			wc.logger.Warn("saved growth curve differs from configured curve; using configured", "saved", saved.GrowthCurve, "configured", wc.growthCurveName)
+		case !known:
+			wc.logger.Warn("saved growth curve cannot be restored; configure it to keep scoring consistent", "saved", saved.GrowthCurve)
+		default:
+			wc.growthCurve = curve
+			wc.growthCurveName = saved.GrowthCurve
+			wc.logger.Warn("restored saved growth curve", "curve", saved.GrowthCurve)
+		}
+	}
+}
+
+// copyWeights returns a copy of a dimension weight map
+func copyWeights(weights map[WisdomDimension]float64) map[WisdomDimension]float64 {
+	c := make(map[WisdomDimension]float64, len(weights))
+	for dim, weight := range weights {
+		c[dim] = weight
+	}
+	return c
+}
+
+// weightsEqual reports whether two weight maps assign every dimension the same weight
+func weightsEqual(a, b map[WisdomDimension]float64) bool {
+	for _, dim := range allDimensions {
+		if a[dim] != b[dim] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,124 @@
+package playmate
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSavedWeightsRestoredIntoDefaults(t *testing.T) {
+	store := &memoryStore{}
+	custom := map[WisdomDimension]float64{DimensionCompassion: 4, DimensionTranscendence: 0.5}
+
+	wc, _ := newTestCultivator(t, &WisdomConfig{
+		PersistPath:      "wisdom",
+		Store:            store,
+		InitialMetrics:   spreadMetrics(),
+		DimensionWeights: custom,
+	})
+	if err := wc.Save(); err != nil {
+		t.Fatal(err)
+	}
+	want := wc.GetMetrics().OverallScore
+
+	logger := &recordingLogger{}
+	loaded, _ := newTestCultivator(t, &WisdomConfig{PersistPath: "wisdom", Store: store, Logger: logger})
+	for dim, weight := range custom {
+		if got := loaded.weights[dim]; got != weight {
+			t.Errorf("%s weight after load = %v, want %v", dim, got, weight)
+		}
+	}
+	if got := loaded.weights[DimensionUnderstanding]; got != dimensionWeights[DimensionUnderstanding] {
+		t.Errorf("unchanged understanding weight = %v, want the default", got)
+	}
+	if got := loaded.GetMetrics().OverallScore; math.Abs(got-want) > 1e-9 {
+		t.Errorf("overall score after load = %v, want %v as saved", got, want)
+	}
+	if len(logger.find("restored saved dimension weights")) != 1 {
+		t.Error("restoring saved weights was not logged")
+	}
+}
+
+func TestConfiguredScoringWinsOverSaved(t *testing.T) {
+	store := &memoryStore{}
+	wc, _ := newTestCultivator(t, &WisdomConfig{
+		PersistPath:      "wisdom",
+		Store:            store,
+		DimensionWeights: map[WisdomDimension]float64{DimensionCompassion: 4},
+		GrowthCurveName:  "quadratic",
+	})
+	if err := wc.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	logger := &recordingLogger{}
+	loaded, _ := newTestCultivator(t, &WisdomConfig{
+		PersistPath:      "wisdom",
+		Store:            store,
+		Logger:           logger,
+		DimensionWeights: map[WisdomDimension]float64{DimensionCompassion: 2},
+		GrowthCurveName:  "linear",
+	})
+	if got := loaded.weights[DimensionCompassion]; got != 2 {
+		t.Errorf("compassion weight = %v, want the configured 2", got)
+	}
+	if loaded.growthCurveName != "linear" {
+		t.Errorf("growth curve = %q, want the configured linear", loaded.growthCurveName)
+	}
+	for _, msg := range []string{
+		"saved dimension weights differ from configured weights; using configured",
+		"saved growth curve differs from configured curve; using configured",
+	} {
+		if len(logger.find(msg)) != 1 {
+			t.Errorf("mismatch not warned: %q", msg)
+		}
+	}
+}
+
+func TestCustomGrowthCurveCannotBeRestored(t *testing.T) {
+	store := &memoryStore{}
+	wc, _ := newTestCultivator(t, &WisdomConfig{
+		PersistPath: "wisdom",
+		Store:       store,
+		GrowthCurve: func(current, amount float64) float64 { return amount },
+	})
+	if err := wc.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	logger := &recordingLogger{}
+	loaded, _ := newTestCultivator(t, &WisdomConfig{PersistPath: "wisdom", Store: store, Logger: logger})
+	if loaded.growthCurveName != "linear" {
+		t.Errorf("growth curve = %q, want linear kept", loaded.growthCurveName)
+	}
+	if len(logger.find("saved growth curve cannot be restored; configure it to keep scoring consistent")) != 1 {
+		t.Error("unrestorable curve not warned")
+	}
+}
+
+func TestMatchingScoringLoadsQuietly(t *testing.T) {
+	store := &memoryStore{}
+	wc, _ := newTestCultivator(t, &WisdomConfig{PersistPath: "wisdom", Store: store})
+	if err := wc.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	logger := &recordingLogger{}
+	newTestCultivator(t, &WisdomConfig{PersistPath: "wisdom", Store: store, Logger: logger})
+	for _, e := range logger.entries {
+		if e.level == "warn" {
+			t.Errorf("unexpected warning loading matching scoring: %s", e.msg)
+		}
+	}
+}
+
+func TestInvalidDimensionWeights(t *testing.T) {
+	for name, weights := range map[string]map[WisdomDimension]float64{
+		"unknown dimension": {"bravery": 1},
+		"zero weight":       {DimensionCompassion: 0},
+		"negative weight":   {DimensionCompassion: -1},
+	} {
+		if _, err := NewWisdomCultivator(&WisdomConfig{DimensionWeights: weights}); err == nil {
+			t.Errorf("%s: NewWisdomCultivator accepted %v", name, weights)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package playmate
+
+import "sort"
+
+// SearchPrinciples returns copies of up to limit active principles whose
+// statements match query, most relevant first with ties broken by ID. A
+// principle's relevance is mostly the share of the query's salient words its statement
+// contains, plus a little for overall word overlap; principles sharing no words
+// with the query are left out. A negative limit returns every match.
+func (wc *WisdomCultivator) SearchPrinciples(query string, limit int) []*WisdomPrinciple {
+	wc.mu.RLock()
+	defer wc.mu.RUnlock()
+
+	scores := make(map[string]float64)
+	matches := make([]*WisdomPrinciple, 0)
+	for _, p := range wc.Principles {
+		if p.Archived {
+			continue
+		}
+		if score := statementRelevance(query, p.Statement); score > 0 {
+			scores[p.ID] = score
+			matches = append(matches, p)
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if scores[matches[i].ID] != scores[matches[j].ID] {
+			return scores[matches[i].ID] > scores[matches[j].ID]
+		}
+		return matches[i].ID < matches[j].ID
+	})
+
+	if limit >= 0 && limit < len(matches) {
+		matches = matches[:limit]
+	}
+
+	results := make([]*WisdomPrinciple, len(matches))
+	for i, p := range matches {
+		results[i] = p.clone()
+	}
+	return results
+}
+
+// statementRelevance scores how well a statement answers a query, weighting
+// coverage of the query's salient words above their overall overlap
+func statementRelevance(query, statement string) float64 {
+	queryWords := keywordCounts([]string{query})
+	if len(queryWords) == 0 {
+		return 0
+	}
+	statementWords := keywordCounts([]string{statement})
+
+	matched := 0
+	for w := range queryWords {
+		if statementWords[w] > 0 {
+			matched++
+		}
+	}
+	if matched == 0 {
+		return 0
+	}
+
+	coverage := float64(matched) / float64(len(queryWords))
+	return 0.8*coverage + 0.2*thoughtSimilarity(query, statement)
+}
+
+func (p *WisdomPrinciple) clone() *WisdomPrinciple {
+	c := *p
+	c.Dimensions = append([]WisdomDimension(nil), p.Dimensions...)
+	c.Refinements = append([]string(nil), p.Refinements...)
+	c.DerivedFrom = append([]string(nil), p.DerivedFrom...)
+	c.LinkedTo = append([]string(nil), p.LinkedTo...)
+	c.Insights = append([]string(nil), p.Insights...)
+	return &c
+}
@@ -0,0 +1,77 @@
+package playmate
+
+import (
+	"reflect"
+	"testing"
+)
+
+// resultIDs lists principle IDs in result order
+func resultIDs(principles []*WisdomPrinciple) []string {
+	ids := make([]string, len(principles))
+	for i, p := range principles {
+		ids[i] = p.ID
+	}
+	return ids
+}
+
+func newSearchCultivator(t *testing.T) *WisdomCultivator {
+	wc, _ := newTestCultivator(t, nil)
+	setPrinciples(wc,
+		&WisdomPrinciple{ID: "patience", Statement: "Patience lets rivers carve stone"},
+		&WisdomPrinciple{ID: "rivers", Statement: "Rivers carve stone slowly"},
+		&WisdomPrinciple{ID: "listen", Statement: "Listen before you speak"},
+		&WisdomPrinciple{ID: "old", Statement: "Stone rivers never move", Archived: true},
+		&WisdomPrinciple{ID: "water", Statement: "Water finds weakness in stone", Dimensions: []WisdomDimension{DimensionPerspective}},
+	)
+	return wc
+}
+
+func TestSearchPrinciplesRanksByRelevance(t *testing.T) {
+	wc := newSearchCultivator(t)
+
+	// Both rivers principles cover every query word; the shorter statement
+	// overlaps more overall. water mentions only stone, listen nothing, and the
+	// archived old principle is skipped.
+	got := resultIDs(wc.SearchPrinciples("rivers carve stone", -1))
+	if want := []string{"rivers", "patience", "water"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SearchPrinciples = %v, want %v", got, want)
+	}
+}
+
+func TestSearchPrinciplesLimit(t *testing.T) {
+	wc := newSearchCultivator(t)
+
+	if got := resultIDs(wc.SearchPrinciples("rivers carve stone", 1)); !reflect.DeepEqual(got, []string{"rivers"}) {
+		t.Errorf("limit 1 = %v, want [rivers]", got)
+	}
+	if got := wc.SearchPrinciples("rivers carve stone", 0); len(got) != 0 {
+		t.Errorf("limit 0 = %v, want none", resultIDs(got))
+	}
+}
+
+func TestSearchPrinciplesNoMatch(t *testing.T) {
+	wc := newSearchCultivator(t)
+
+	for _, query := range []string{"mountains", "", "the and of"} {
+		if got := wc.SearchPrinciples(query, -1); len(got) != 0 {
+			t.Errorf("SearchPrinciples(%q) = %v, want none", query, resultIDs(got))
+		}
+	}
+}
+
+func TestSearchPrinciplesReturnsCopies(t *testing.T) {
+	wc := newSearchCultivator(t)
+
+	got := wc.SearchPrinciples("weakness", -1)
+	if len(got) != 1 || got[0].ID != "water" {
+		t.Fatalf("SearchPrinciples = %v, want [water]", resultIDs(got))
+	}
+	got[0].Statement = "changed"
+	got[0].Dimensions[0] = DimensionCompassion
+
+	wc.mu.RLock()
+	defer wc.mu.RUnlock()
+	if p := wc.Principles["water"]; p.Statement == "changed" || p.Dimensions[0] != DimensionPerspective {
+		t.Errorf("modifying a result changed the stored principle: %+v", p)
+	}
+}
@@ -0,0 +1,23 @@
+package playmate
+
+import "math"
+
+// maxSetback bounds how far a single setback can lower a dimension
+const maxSetback = 0.1
+
+// RecordSetback lowers a dimension after unwise action, recording the loss as a
+// negative growth event. The reduction is capped at 0.1 per setback and never
+// takes the dimension below zero; diminishing returns do not apply. Setbacks
+// count against daily growth, growth rate, and streaks.
+func (wc *WisdomCultivator) RecordSetback(dim WisdomDimension, amount float64, trigger string) {
+	wc.mu.Lock()
+	defer wc.mu.Unlock()
+
+	amount = math.Min(math.Abs(amount), maxSetback)
+	if amount == 0 {
+		return
+	}
+	wc.changeDimension(dim, -amount, trigger)
+	wc.dirty = true
+	wc.logger.Info("recorded wisdom setback", "dimension", dim, "amount", amount, "trigger", trigger)
+}
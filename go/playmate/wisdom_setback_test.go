@@ -0,0 +1,93 @@
+package playmate
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestRecordSetbackLowersDimension(t *testing.T) {
+	wc, _ := newTestCultivator(t, &WisdomConfig{InitialMetrics: uniformMetrics(0.5)})
+
+	wc.RecordSetback(DimensionEquanimity, 0.05, "snapped at a friend")
+	if got := wc.GetMetrics().Equanimity; math.Abs(got-0.45) > 1e-9 {
+		t.Errorf("Equanimity = %v, want 0.45", got)
+	}
+
+	wc.mu.RLock()
+	defer wc.mu.RUnlock()
+	if len(wc.GrowthHistory) != 1 {
+		t.Fatalf("recorded %d growth events, want 1", len(wc.GrowthHistory))
+	}
+	event := wc.GrowthHistory[0]
+	if math.Abs(event.Delta+0.05) > 1e-9 || event.Dimension != DimensionEquanimity || event.Trigger != "snapped at a friend" {
+		t.Errorf("setback event = %+v, want a -0.05 equanimity event", event)
+	}
+	if !wc.dirty {
+		t.Error("setback did not mark the cultivator dirty")
+	}
+}
+
+func TestRecordSetbackBounds(t *testing.T) {
+	tests := []struct {
+		name      string
+		initial   float64
+		amount    float64
+		wantValue float64
+		wantDelta float64
+	}{
+		{"capped per setback", 0.5, 0.4, 0.4, -maxSetback},
+		{"sign ignored", 0.5, -0.05, 0.45, -0.05},
+		{"floored at zero", 0.03, 0.1, 0, -0.03},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wc, _ := newTestCultivator(t, &WisdomConfig{InitialMetrics: uniformMetrics(tt.initial)})
+			wc.RecordSetback(DimensionCompassion, tt.amount, "test")
+
+			if got := wc.GetMetrics().Compassion; math.Abs(got-tt.wantValue) > 1e-9 {
+				t.Errorf("Compassion = %v, want %v", got, tt.wantValue)
+			}
+			wc.mu.RLock()
+			defer wc.mu.RUnlock()
+			if got := wc.GrowthHistory[0].Delta; math.Abs(got-tt.wantDelta) > 1e-9 {
+				t.Errorf("recorded delta = %v, want %v", got, tt.wantDelta)
+			}
+		})
+	}
+}
+
+func TestRecordSetbackZeroIgnored(t *testing.T) {
+	wc, _ := newTestCultivator(t, nil)
+	wc.RecordSetback(DimensionCompassion, 0, "nothing happened")
+
+	wc.mu.RLock()
+	defer wc.mu.RUnlock()
+	if len(wc.GrowthHistory) != 0 || wc.dirty {
+		t.Errorf("zero setback recorded %d events (dirty %v), want none", len(wc.GrowthHistory), wc.dirty)
+	}
+}
+
+func TestSetbackInGrowthSummaries(t *testing.T) {
+	grow := func(current, amount float64) float64 { return amount }
+	wc, clock := newTestCultivator(t, &WisdomConfig{InitialMetrics: uniformMetrics(0.5), GrowthCurve: grow})
+
+	wc.GrowDimension(DimensionUnderstanding, 0.04, "study")
+	clock.Advance(24 * time.Hour)
+	wc.GrowDimension(DimensionUnderstanding, 0.02, "study")
+	if streak := wc.CurrentStreak(); streak != 2 {
+		t.Fatalf("streak before setback = %d, want 2", streak)
+	}
+	wc.RecordSetback(DimensionUnderstanding, 0.08, "rushed to judgment")
+
+	summary := wc.GrowthSummary(2)
+	if math.Abs(summary[0].Growth-0.04) > 1e-9 || math.Abs(summary[1].Growth+0.06) > 1e-9 {
+		t.Errorf("GrowthSummary = %+v, want 0.04 then -0.06", summary)
+	}
+	if streak := wc.CurrentStreak(); streak != 0 {
+		t.Errorf("streak after a net-negative day = %d, want 0", streak)
+	}
+	if rate := wc.GetMetrics().GrowthRate; math.Abs(rate-(-0.02/3)) > 1e-9 {
+		t.Errorf("GrowthRate = %v, want the average delta %v", rate, -0.02/3)
+	}
+}
@@ -0,0 +1,59 @@
+package playmate
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFoundationalPrinciplesNotDuplicatedOnReload(t *testing.T) {
+	store := &memoryStore{}
+	config := func() *WisdomConfig { return &WisdomConfig{PersistPath: "wisdom", Store: store} }
+
+	wc, _ := newTestCultivator(t, config())
+	seeded := wc.CountBySource()[foundationalSource]
+	if seeded == 0 {
+		t.Fatal("new cultivator has no foundational principles")
+	}
+	wc.AddPrinciple("Rest before deciding", []WisdomDimension{DimensionEquanimity}, "reflection")
+	if err := wc.Save(); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]int{foundationalSource: seeded, "reflection": 1}
+
+	for i := 0; i < 3; i++ {
+		loaded, _ := newTestCultivator(t, config())
+		if got := loaded.CountBySource(); !reflect.DeepEqual(got, want) {
+			t.Fatalf("reload %d: CountBySource = %v, want %v", i+1, got, want)
+		}
+		if err := loaded.Save(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Loading again into the same cultivator replaces rather than adds
+	if err := wc.Load(); err != nil {
+		t.Fatal(err)
+	}
+	if got := wc.CountBySource(); !reflect.DeepEqual(got, want) {
+		t.Errorf("after Load: CountBySource = %v, want %v", got, want)
+	}
+}
+
+func TestLearnedPrinciplesNotMistakenForFoundational(t *testing.T) {
+	store := &memoryStore{}
+	config := func() *WisdomConfig { return &WisdomConfig{PersistPath: "wisdom", Store: store} }
+
+	wc, _ := newTestCultivator(t, config())
+	learned := wc.AddPrinciple("Listen before answering", []WisdomDimension{DimensionCompassion}, "discussion")
+	if err := wc.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, _ := newTestCultivator(t, config())
+	loaded.mu.RLock()
+	got := loaded.Principles[learned.ID]
+	loaded.mu.RUnlock()
+	if got == nil || got.Source != "discussion" {
+		t.Errorf("reloaded learned principle = %+v, want source discussion", got)
+	}
+}
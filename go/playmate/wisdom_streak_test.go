@@ -0,0 +1,68 @@
+package playmate
+
+import (
+	"testing"
+	"time"
+)
+
+// growOnDays grows understanding once on each listed day offset from the
+// clock's current date, leaving the clock on the last day
+func growOnDays(wc *WisdomCultivator, clock *fakeClock, offsets ...int) {
+	day := 0
+	for _, offset := range offsets {
+		clock.Advance(time.Duration(offset-day) * 24 * time.Hour)
+		day = offset
+		wc.GrowDimension(DimensionUnderstanding, 0.01, "test")
+	}
+}
+
+func TestCurrentStreakResetsAfterGap(t *testing.T) {
+	wc, clock := newTestCultivator(t, nil)
+
+	// Days 0 and 1, a gap on day 2, then days 3 through 5
+	growOnDays(wc, clock, 0, 1, 3, 4, 5)
+	if got := wc.CurrentStreak(); got != 3 {
+		t.Errorf("streak on day 5 = %d, want 3", got)
+	}
+
+	clock.Advance(24 * time.Hour)
+	if got := wc.CurrentStreak(); got != 0 {
+		t.Errorf("streak on day 6 before growing = %d, want 0", got)
+	}
+
+	wc.GrowDimension(DimensionUnderstanding, 0.01, "test")
+	if got := wc.CurrentStreak(); got != 4 {
+		t.Errorf("streak on day 6 after growing = %d, want 4", got)
+	}
+}
+
+func TestCurrentStreakEmpty(t *testing.T) {
+	wc, _ := newTestCultivator(t, nil)
+	if got := wc.CurrentStreak(); got != 0 {
+		t.Errorf("streak with no growth = %d, want 0", got)
+	}
+}
+
+func TestGrowthSummaryZeroFillsGaps(t *testing.T) {
+	wc, clock := newTestCultivator(t, nil)
+	growOnDays(wc, clock, 0, 2, 3)
+
+	summary := wc.GrowthSummary(5)
+	want := []string{"2026-03-13", "2026-03-14", "2026-03-15", "2026-03-16", "2026-03-17"}
+	if len(summary) != len(want) {
+		t.Fatalf("got %d days, want %d", len(summary), len(want))
+	}
+	for i, day := range summary {
+		if day.Date != want[i] {
+			t.Errorf("day %d is %s, want %s", i, day.Date, want[i])
+		}
+		grew := day.Growth > 0
+		if wantGrew := i == 1 || i == 3 || i == 4; grew != wantGrew {
+			t.Errorf("%s growth = %v, want growth %v", day.Date, day.Growth, wantGrew)
+		}
+	}
+
+	if got := wc.GrowthSummary(0); len(got) != 0 {
+		t.Errorf("GrowthSummary(0) = %v, want empty", got)
+	}
+}
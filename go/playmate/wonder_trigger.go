@@ -0,0 +1,58 @@
+package playmate
+
+// WonderTrigger decides whether something the playmate experiences inspires
+// wonder. Evaluate is called with the playmate locked, so it may read the
+// playmate's fields but must not call its methods. The returned event needs
+// only Description, Trigger, and Intensity; the rest is filled in on recording.
+type WonderTrigger interface {
+	Evaluate(p *Playmate, event interface{}) (*WonderEvent, bool)
+}
+
+// WonderTriggerFunc adapts a function to the WonderTrigger interface
+type WonderTriggerFunc func(p *Playmate, event interface{}) (*WonderEvent, bool)
+
+// Evaluate calls f(p, event)
+func (f WonderTriggerFunc) Evaluate(p *Playmate, event interface{}) (*WonderEvent, bool) {
+	return f(p, event)
+}
+
+// ThoughtEvent is passed to wonder triggers when the playmate processes a thought
+type ThoughtEvent struct {
+	Thought string
+}
+
+// MessageEvent is passed to wonder triggers when a message is added to a discussion
+type MessageEvent struct {
+	DiscussionID string
+	Message      DiscussionMessage
+}
+
+// curiosityWonderTrigger occasionally finds wonder in a thought while the
+// playmate is highly curious
+func curiosityWonderTrigger(p *Playmate, event interface{}) (*WonderEvent, bool) {
+	thought, ok := event.(ThoughtEvent)
+	if !ok || p.Curiosity <= 0.7 || p.rand.Float64() >= 0.1 {
+		return nil, false
+	}
+	return &WonderEvent{Description: "Spontaneous Insight", Trigger: thought.Thought, Intensity: p.Curiosity}, true
+}
+
+// RegisterWonderTrigger adds a trigger evaluated after those already registered
+func (p *Playmate) RegisterWonderTrigger(trigger WonderTrigger) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.wonderTriggers = append(p.wonderTriggers, trigger)
+}
+
+// evaluateWonderTriggers offers an event to every registered trigger in order,
+// recording a wonder for each that fires. Quiet playmates feel no wonder. (must hold lock)
+func (p *Playmate) evaluateWonderTriggers(event interface{}) {
+	if p.quiet {
+		return
+	}
+	for _, trigger := range p.wonderTriggers {
+		if w, ok := trigger.Evaluate(p, event); ok && w != nil {
+			p.recordWonder(w.Description, w.Trigger, w.Intensity)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package playmate
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// surprisingClaim fires on messages that claim something surprising
+func surprisingClaim(p *Playmate, event interface{}) (*WonderEvent, bool) {
+	msg, ok := event.(MessageEvent)
+	if !ok || !strings.Contains(strings.ToLower(msg.Message.Content), "actually") {
+		return nil, false
+	}
+	return &WonderEvent{Description: "A surprising claim", Trigger: msg.Message.Content, Intensity: 0.7}, true
+}
+
+// wondersOf returns copies of the playmate's wonders
+func wondersOf(p *Playmate) []WonderEvent {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	wonders := make([]WonderEvent, len(p.Wonders))
+	for i, w := range p.Wonders {
+		wonders[i] = *w
+	}
+	return wonders
+}
+
+func TestCustomWonderTriggerFires(t *testing.T) {
+	p, clock := newTestPlaymate(t, nil)
+	p.RegisterWonderTrigger(WonderTriggerFunc(surprisingClaim))
+	d := mustStartDiscussion(t, p, "animals", "ana")
+
+	if err := p.AddMessage(d.ID, "ana", "octopuses live in the sea"); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(wondersOf(p)); n != 0 {
+		t.Fatalf("got %d wonders from an unsurprising message", n)
+	}
+
+	if _, err := p.SendMessage(d.ID, "ana", "Octopuses actually have three hearts"); err != nil {
+		t.Fatal(err)
+	}
+	wonders := wondersOf(p)
+	if len(wonders) != 1 {
+		t.Fatalf("got %d wonders from a surprising claim, want 1", len(wonders))
+	}
+	w := wonders[0]
+	if w.Description != "A surprising claim" || w.Trigger != "Octopuses actually have three hearts" || w.Intensity != 0.7 {
+		t.Errorf("wonder = %+v, want the trigger's description, trigger, and intensity", w)
+	}
+	if w.ID == "" || !w.Timestamp.Equal(clock.Now()) {
+		t.Errorf("wonder ID %q at %v, want an ID and the current time", w.ID, w.Timestamp)
+	}
+}
+
+func TestWonderTriggerSeesPlaymateState(t *testing.T) {
+	// Fires on any thought once energy has dropped below a threshold
+	weary := WonderTriggerFunc(func(p *Playmate, event interface{}) (*WonderEvent, bool) {
+		if _, ok := event.(ThoughtEvent); !ok || p.Energy >= 0.3 {
+			return nil, false
+		}
+		return &WonderEvent{Description: "Strange thoughts at the edge of sleep", Trigger: "low energy", Intensity: 0.4}, true
+	})
+	p, _ := newTestPlaymate(t, func(c *PlaymateConfig) { c.WonderTriggers = []WonderTrigger{weary} })
+	ctx := context.Background()
+
+	p.processThought(ctx, "the stars are bright")
+	if n := len(wondersOf(p)); n != 0 {
+		t.Fatalf("rested playmate got %d wonders", n)
+	}
+	p.mu.Lock()
+	p.Energy = 0.2
+	p.mu.Unlock()
+	p.processThought(ctx, "the stars are bright")
+	if wonders := wondersOf(p); len(wonders) != 1 || wonders[0].Trigger != "low energy" {
+		t.Errorf("weary playmate wonders = %+v, want one from low energy", wonders)
+	}
+}
+
+func TestDefaultCuriosityWonderTrigger(t *testing.T) {
+	thoughts := func(p *Playmate, curiosity float64) int {
+		p.mu.Lock()
+		p.Curiosity = curiosity
+		p.mu.Unlock()
+		before := len(wondersOf(p))
+		for i := 0; i < 200; i++ {
+			p.processThought(context.Background(), "what makes a moment meaningful?")
+			// Wonder raises curiosity; hold it steady
+			p.mu.Lock()
+			p.Curiosity = curiosity
+			p.mu.Unlock()
+		}
+		return len(wondersOf(p)) - before
+	}
+
+	p, _ := newTestPlaymate(t, nil)
+	if n := thoughts(p, 0.5); n != 0 {
+		t.Errorf("mildly curious playmate wondered %d times, want never", n)
+	}
+	if n := thoughts(p, 0.9); n == 0 || n > 60 {
+		t.Errorf("highly curious playmate wondered %d times in 200 thoughts, want occasionally", n)
+	}
+
+	// An explicit, empty trigger list replaces the default
+	quiet, _ := newTestPlaymate(t, func(c *PlaymateConfig) { c.WonderTriggers = []WonderTrigger{} })
+	if n := thoughts(quiet, 0.9); n != 0 {
+		t.Errorf("playmate without triggers wondered %d times", n)
+	}
+}
+
+func TestEveryFiringTriggerRecordsWonder(t *testing.T) {
+	always := func(name string) WonderTrigger {
+		return WonderTriggerFunc(func(p *Playmate, event interface{}) (*WonderEvent, bool) {
+			return &WonderEvent{Description: name, Trigger: "test", Intensity: 0.1}, true
+		})
+	}
+	p, _ := newTestPlaymate(t, func(c *PlaymateConfig) { c.WonderTriggers = []WonderTrigger{always("first")} })
+	p.RegisterWonderTrigger(always("second"))
+
+	p.processThought(context.Background(), "anything")
+	wonders := wondersOf(p)
+	if len(wonders) != 2 || wonders[0].Description != "first" || wonders[1].Description != "second" {
+		t.Errorf("wonders = %+v, want one per trigger in registration order", wonders)
+	}
+}
@@ -0,0 +1,23 @@
+package vectormem
+
+import "context"
+
+// addActivationDecay is the per-hop activation decay AddAndActivate spreads with
+const addActivationDecay = 0.5
+
+// AddAndActivate adds a memory like Add and, in the same critical section,
+// spreads activation from it to depth hops, returning the activation of every
+// memory reached (the new memory itself has activation 1). This surfaces the
+// context a new memory brings to mind, such as its auto-connected neighbors,
+// in one call. If the memory merged into a duplicate, activation spreads from
+// the duplicate.
+func (hm *HypergraphMemory) AddAndActivate(ctx context.Context, memType MemoryType, content string, metadata map[string]interface{}, depth int) (MemoryView, map[string]float64, error) {
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+
+	mem, err := hm.insert(ctx, memType, content, metadata, nil, provenance{})
+	if err != nil {
+		return MemoryView{}, nil, err
+	}
+	return mem.view(), hm.spreadActivation(mem.ID, depth, addActivationDecay), nil
+}
@@ -0,0 +1,109 @@
+package vectormem
+
+import (
+	"context"
+	"math"
+	"testing"
+)
+
+// newActivationMemory holds "anchor" and "branch", which are not similar to
+// each other, and "island", which resembles neither; "bridge" resembles both
+// anchor and branch
+func newActivationMemory(t *testing.T, configure func(*HypergraphConfig)) (*HypergraphMemory, map[string]string) {
+	hm := newTestMemory(t, func(c *HypergraphConfig) {
+		c.EmbeddingFunc = tableEmbedding(map[string][]float32{
+			"anchor": {1, 0, 0},
+			"branch": {0.6, 0.8, 0},
+			"island": {0, 0, 1},
+			"bridge": {0.894, 0.447, 0},
+		})
+		if configure != nil {
+			configure(c)
+		}
+	})
+	ids := make(map[string]string)
+	for _, content := range []string{"anchor", "branch", "island"} {
+		ids[content] = mustAdd(t, hm, EpisodicMemory, content, nil).ID
+	}
+	return hm, ids
+}
+
+func checkActivation(t *testing.T, got map[string]float64, want map[string]float64) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Errorf("activation reached %d memories %v, want %d", len(got), got, len(want))
+	}
+	for id, w := range want {
+		if g, ok := got[id]; !ok || math.Abs(g-w) > 1e-9 {
+			t.Errorf("activation of %s = %v (reached %v), want %v", id, g, ok, w)
+		}
+	}
+}
+
+func TestAddAndActivateReachesAutoConnectedNeighbors(t *testing.T) {
+	hm, ids := newActivationMemory(t, nil)
+
+	mem, activation, err := hm.AddAndActivate(context.Background(), EpisodicMemory, "bridge", nil, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(mem.Connections) != 2 {
+		t.Fatalf("bridge auto-connected to %v, want anchor and branch", mem.Connections)
+	}
+	checkActivation(t, activation, map[string]float64{
+		mem.ID:        1,
+		ids["anchor"]: addActivationDecay,
+		ids["branch"]: addActivationDecay,
+	})
+}
+
+func TestAddAndActivateDepth(t *testing.T) {
+	hm, ids := newActivationMemory(t, nil)
+	if err := hm.Connect(ids["anchor"], ids["island"]); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	mem, activation, err := hm.AddAndActivate(ctx, EpisodicMemory, "bridge", nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkActivation(t, activation, map[string]float64{mem.ID: 1})
+
+	// The island is two hops away, through the anchor
+	activation, err = hm.SpreadActivation(ctx, mem.ID, 2, addActivationDecay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkActivation(t, activation, map[string]float64{
+		mem.ID:        1,
+		ids["anchor"]: addActivationDecay,
+		ids["branch"]: addActivationDecay,
+		ids["island"]: addActivationDecay * addActivationDecay,
+	})
+}
+
+func TestAddAndActivateDuplicate(t *testing.T) {
+	hm, ids := newActivationMemory(t, func(c *HypergraphConfig) { c.DedupThreshold = 0.99 })
+
+	mem, activation, err := hm.AddAndActivate(context.Background(), EpisodicMemory, "anchor", nil, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mem.ID != ids["anchor"] {
+		t.Errorf("duplicate returned %s, want the existing anchor %s", mem.ID, ids["anchor"])
+	}
+	checkActivation(t, activation, map[string]float64{ids["anchor"]: 1})
+}
+
+func TestAddAndActivateEmbeddingError(t *testing.T) {
+	hm, _ := newActivationMemory(t, nil)
+
+	mem, activation, err := hm.AddAndActivate(context.Background(), EpisodicMemory, "unknown", nil, 1)
+	if err == nil || mem.ID != "" || activation != nil {
+		t.Errorf("AddAndActivate = %v, %v, %v; want only an error", mem, activation, err)
+	}
+	if n := hm.GetStats()["total_memories"]; n != 3 {
+		t.Errorf("total memories = %v, want 3", n)
+	}
+}
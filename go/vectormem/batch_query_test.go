@@ -0,0 +1,81 @@
+package vectormem
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBatchQueryMatchesSequentialQueries(t *testing.T) {
+	embedder := &countingEmbedder{embed: wordEmbedding("kettle", "stove", "harbour", "boats", "tea")}
+	build := func(batch bool) *HypergraphMemory {
+		hm := newTestMemory(t, func(c *HypergraphConfig) {
+			c.Clock = newFakeClock()
+			c.EmbeddingFunc = embedder.single
+			if batch {
+				c.BatchEmbeddingFunc = embedder.batch
+			}
+			// Queries change importance and connections, so order matters
+			c.ReinforcementRate = 0.05
+			c.HebbianLearning = true
+		})
+		for _, content := range []string{"kettle on", "kettle stove", "stove tea", "harbour boats", "boats"} {
+			mustAdd(t, hm, EpisodicMemory, content, nil)
+		}
+		mustAdd(t, hm, DeclarativeMemory, "tea kettle", nil)
+		return hm
+	}
+	queries := []string{"kettle", "boats", "kettle tea", "kettle", "nothing"}
+	ctx := context.Background()
+
+	for _, memType := range []MemoryType{"", EpisodicMemory} {
+		sequential := build(false)
+		want := make([][]MemoryView, len(queries))
+		for i, query := range queries {
+			results, err := sequential.Query(ctx, query, memType, 2)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want[i] = results
+		}
+
+		batched := build(true)
+		embedder.reset()
+		got, err := batched.BatchQuery(ctx, queries, memType, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if embedder.batches != 1 || embedder.singles != 0 {
+			t.Errorf("type %q: embedded with %d batch and %d single calls, want one batch", memType, embedder.batches, embedder.singles)
+		}
+		if !reflect.DeepEqual(got, want) {
+			for i := range queries {
+				t.Errorf("type %q, query %q: batch %v, sequential %v", memType, queries[i], contents(got[i]), contents(want[i]))
+			}
+		}
+	}
+}
+
+func TestBatchQueryWithoutEmbeddings(t *testing.T) {
+	hm := newTestMemory(t, nil)
+	mustAdd(t, hm, EpisodicMemory, "the kettle whistles", nil)
+	mustAdd(t, hm, EpisodicMemory, "boats in the harbour", nil)
+
+	results, err := hm.BatchQuery(context.Background(), []string{"kettle", "harbour"}, "", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := []string{strings.Join(contents(results[0]), "|"), strings.Join(contents(results[1]), "|")}
+	if got[0] != "the kettle whistles" || got[1] != "boats in the harbour" {
+		t.Errorf("text-only batch results = %v", got)
+	}
+}
+
+func TestBatchQueryEmpty(t *testing.T) {
+	hm := newTestMemory(t, nil)
+	results, err := hm.BatchQuery(context.Background(), nil, "", 5)
+	if err != nil || len(results) != 0 {
+		t.Errorf("BatchQuery(nil) = %v, %v; want no results", results, err)
+	}
+}
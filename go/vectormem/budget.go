@@ -0,0 +1,58 @@
+package vectormem
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// wordTokenCounter approximates tokens by counting words
+func wordTokenCounter(content string) int {
+	return len(strings.Fields(content))
+}
+
+// SelectForBudget picks memories to place in an LLM context window: it scores
+// every memory against query as Query does, then greedily takes the highest
+// scoring memories whose content still fits in the remaining tokenBudget,
+// skipping any too large to fit. Results are in score order. tokenCounter
+// measures content (defaults to counting words).
+func (hm *HypergraphMemory) SelectForBudget(ctx context.Context, query string, tokenBudget int, tokenCounter func(string) int) ([]MemoryView, error) {
+	if tokenBudget < 0 {
+		return nil, fmt.Errorf("invalid token budget: %d", tokenBudget)
+	}
+	if tokenCounter == nil {
+		tokenCounter = wordTokenCounter
+	}
+
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+
+	start := time.Now()
+	defer hm.recordQueryLatency(start)
+
+	queryEmbedding, err := hm.embedQuery(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	remaining := tokenBudget
+	results := make([]*Memory, 0)
+	selected := make([]ScoredResult, 0)
+	for _, result := range hm.scoreMemories(queryEmbedding, query, QueryOptions{}) {
+		if remaining == 0 {
+			break
+		}
+		tokens := tokenCounter(result.Memory.Content)
+		if tokens > remaining {
+			continue
+		}
+		results = append(results, result.Memory)
+		selected = append(selected, result)
+		remaining -= tokens
+	}
+	hm.markRetrieved(results)
+	hm.logQuery(query, selected)
+
+	return views(results), nil
+}
@@ -0,0 +1,89 @@
+package vectormem
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+// newBudgetMemory holds four memories ranked best to worst against "query"
+// and a token counter giving each a fixed size
+func newBudgetMemory(t *testing.T) (*HypergraphMemory, func(string) int) {
+	hm := newTestMemory(t, func(c *HypergraphConfig) {
+		c.Clock = newFakeClock()
+		c.EmbeddingFunc = tableEmbedding(map[string][]float32{
+			"query": {1, 0},
+			"best":  {1, 0},
+			"good":  {0.9, 0.44},
+			"fair":  {0.7, 0.71},
+			"poor":  {0.3, 0.95},
+		})
+	})
+	for _, content := range []string{"poor", "fair", "good", "best"} {
+		mustAdd(t, hm, EpisodicMemory, content, nil)
+	}
+	tokens := map[string]int{"best": 6, "good": 3, "fair": 5, "poor": 1}
+	return hm, func(content string) int { return tokens[content] }
+}
+
+func TestSelectForBudget(t *testing.T) {
+	tests := []struct {
+		budget int
+		want   []string
+	}{
+		{20, []string{"best", "good", "fair", "poor"}},
+		{9, []string{"best", "good"}}, // Exactly fills the budget
+		{8, []string{"best", "poor"}}, // Skips what no longer fits
+		{5, []string{"good", "poor"}}, // The best is too large on its own
+		{0, []string{}},
+	}
+	for _, tt := range tests {
+		hm, counter := newBudgetMemory(t)
+		selected, err := hm.SelectForBudget(context.Background(), "query", tt.budget, counter)
+		if err != nil {
+			t.Fatalf("budget %d: %v", tt.budget, err)
+		}
+		if got := contents(selected); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("budget %d: selected %v, want %v", tt.budget, got, tt.want)
+		}
+		used := 0
+		for _, v := range selected {
+			used += counter(v.Content)
+		}
+		if used > tt.budget {
+			t.Errorf("budget %d: selection uses %d tokens", tt.budget, used)
+		}
+	}
+}
+
+func TestSelectForBudgetDefaults(t *testing.T) {
+	hm := newTestMemory(t, nil)
+	mustAdd(t, hm, EpisodicMemory, "the kettle whistles on the stove", nil)
+	mustAdd(t, hm, EpisodicMemory, "kettle on", nil)
+
+	// Words are counted by default: only the two-word memory fits in three
+	selected, err := hm.SelectForBudget(context.Background(), "kettle", 3, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := contents(selected); !reflect.DeepEqual(got, []string{"kettle on"}) {
+		t.Errorf("selected %v, want only the short memory", got)
+	}
+
+	if _, err := hm.SelectForBudget(context.Background(), "kettle", -1, nil); err == nil {
+		t.Error("negative budget accepted")
+	}
+}
+
+func TestSelectForBudgetMarksRetrieved(t *testing.T) {
+	hm, counter := newBudgetMemory(t)
+	if _, err := hm.SelectForBudget(context.Background(), "query", 8, counter); err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range hm.Recent("", -1) {
+		selected := v.Content == "best" || v.Content == "poor"
+		if (v.AccessCount > 0) != selected {
+			t.Errorf("%s access count = %d, want access only when selected", v.Content, v.AccessCount)
+		}
+	}
+}
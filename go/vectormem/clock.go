@@ -0,0 +1,13 @@
+package vectormem
+
+import "time"
+
+// Clock provides the current time; inject a fake clock for reproducible tests
+type Clock interface {
+	Now() time.Time
+}
+
+// realClock is the system clock
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() }
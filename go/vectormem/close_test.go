@@ -0,0 +1,53 @@
+package vectormem
+
+import "testing"
+
+func TestHypergraphMemoryClose(t *testing.T) {
+	store := &memoryStore{}
+	configure := func(c *HypergraphConfig) {
+		c.PersistPath = "memories"
+		c.Store = store
+	}
+	hm := newTestMemory(t, configure)
+	mustAdd(t, hm, EpisodicMemory, "kettle on the stove", nil)
+
+	if err := hm.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if store.writes != 1 {
+		t.Fatalf("Close wrote %d times, want 1 for a dirty memory", store.writes)
+	}
+	select {
+	case <-hm.stopChan:
+	default:
+		t.Error("Close did not stop background maintenance")
+	}
+
+	// Further changes are not flushed by a second Close
+	mustAdd(t, hm, EpisodicMemory, "boats in the harbour", nil)
+	if err := hm.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if store.writes != 1 {
+		t.Errorf("second Close wrote again: %d writes", store.writes)
+	}
+
+	loaded := newTestMemory(t, configure)
+	if got := loaded.GetStats()["total_memories"]; got != 1 {
+		t.Errorf("loaded %v memories, want the 1 saved on Close", got)
+	}
+}
+
+func TestHypergraphMemoryCloseClean(t *testing.T) {
+	store := &memoryStore{}
+	hm := newTestMemory(t, func(c *HypergraphConfig) {
+		c.PersistPath = "memories"
+		c.Store = store
+	})
+	if err := hm.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if store.writes != 0 {
+		t.Errorf("Close of a clean memory wrote %d times, want 0", store.writes)
+	}
+}
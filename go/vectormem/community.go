@@ -0,0 +1,111 @@
+package vectormem
+
+import (
+	"math"
+	"sort"
+)
+
+const (
+	// maxCommunityIterations caps label propagation rounds in DetectCommunities
+	maxCommunityIterations = 50
+
+	// minEdgeWeight keeps every connection counting in DetectCommunities, even
+	// between memories with unrelated content
+	minEdgeWeight = 0.1
+)
+
+// DetectCommunities groups memories into topical communities by label
+// propagation over the connection graph: each memory repeatedly adopts the
+// community with the greatest total edge weight among its neighbors, where an
+// edge is weighted by the similarity of the memories it joins. Memories are
+// visited in ID order and ties go to the lowest label, so results are
+// deterministic. It returns each unexpired memory's community ID; IDs are
+// numbered from 0 in order of each community's smallest memory ID, and
+// unconnected memories form communities of their own.
+func (hm *HypergraphMemory) DetectCommunities() map[string]int {
+	hm.mu.RLock()
+	defer hm.mu.RUnlock()
+
+	now := hm.clock.Now()
+	ids := make([]string, 0, len(hm.memories))
+	for id, mem := range hm.memories {
+		if !mem.expired(now) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	// Every memory starts in its own community, labelled by its index
+	index := make(map[string]int, len(ids))
+	for i, id := range ids {
+		index[id] = i
+	}
+	type edge struct {
+		to     int
+		weight float64
+	}
+	edges := make([][]edge, len(ids))
+	for i, id := range ids {
+		mem := hm.memories[id]
+		for _, connID := range mem.Connections {
+			j, ok := index[connID]
+			if !ok || j == i {
+				continue
+			}
+			weight := math.Max(minEdgeWeight, hm.resultSimilarity(mem, hm.memories[connID]))
+			edges[i] = append(edges[i], edge{to: j, weight: weight})
+		}
+	}
+
+	labels := make([]int, len(ids))
+	for i := range labels {
+		labels[i] = i
+	}
+	for iter := 0; iter < maxCommunityIterations; iter++ {
+		changed := false
+		for i := range ids {
+			if len(edges[i]) == 0 {
+				continue
+			}
+			weights := make(map[int]float64)
+			for _, e := range edges[i] {
+				weights[labels[e.to]] += e.weight
+			}
+			best, bestWeight := labels[i], weights[labels[i]]
+			for label, weight := range weights {
+				if weight > bestWeight || (weight == bestWeight && label < best) {
+					best, bestWeight = label, weight
+				}
+			}
+			if best != labels[i] {
+				labels[i] = best
+				changed = true
+			}
+		}
+		if !changed {
+			break
+		}
+	}
+
+	// Renumber densely in order of first appearance, i.e. smallest member ID
+	renumber := make(map[int]int)
+	communities := make(map[string]int, len(ids))
+	for i, id := range ids {
+		c, ok := renumber[labels[i]]
+		if !ok {
+			c = len(renumber)
+			renumber[labels[i]] = c
+		}
+		communities[id] = c
+	}
+	return communities
+}
+
+// CommunitySizes counts the members of each community returned by DetectCommunities
+func CommunitySizes(communities map[string]int) map[int]int {
+	sizes := make(map[int]int)
+	for _, c := range communities {
+		sizes[c]++
+	}
+	return sizes
+}
@@ -0,0 +1,93 @@
+package vectormem
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// connectAll connects every pair of memories
+func connectAll(t *testing.T, hm *HypergraphMemory, mems ...MemoryView) {
+	t.Helper()
+	for i, a := range mems {
+		for _, b := range mems[i+1:] {
+			if err := hm.Connect(a.ID, b.ID); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+}
+
+func TestDetectCommunitiesSeparatesDenseSubgraphs(t *testing.T) {
+	clock := newFakeClock()
+	hm := newTestMemory(t, func(c *HypergraphConfig) { c.Clock = clock })
+
+	var tides, kitchen []MemoryView
+	for _, content := range []string{"tide and moon", "moon over the sea", "sea tide rising", "the moon pulls the tide"} {
+		tides = append(tides, mustAdd(t, hm, EpisodicMemory, content, nil))
+	}
+	for _, content := range []string{"kettle on the stove", "tea from the kettle", "stove heats the tea", "kettle whistles"} {
+		kitchen = append(kitchen, mustAdd(t, hm, EpisodicMemory, content, nil))
+	}
+	connectAll(t, hm, tides...)
+	connectAll(t, hm, kitchen...)
+	// A single weak bridge doesn't merge the communities
+	if err := hm.Connect(tides[3].ID, kitchen[0].ID); err != nil {
+		t.Fatal(err)
+	}
+	loner := mustAdd(t, hm, DeclarativeMemory, "an unconnected fact", nil)
+	expiring, err := hm.AddWithTTL(context.Background(), EpisodicMemory, "a fleeting tide", nil, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := hm.Connect(expiring.ID, tides[0].ID); err != nil {
+		t.Fatal(err)
+	}
+	clock.Advance(time.Hour)
+
+	communities := hm.DetectCommunities()
+	if _, ok := communities[expiring.ID]; ok {
+		t.Error("expired memory assigned a community")
+	}
+	for _, group := range [][]MemoryView{tides, kitchen} {
+		for _, mem := range group[1:] {
+			if communities[mem.ID] != communities[group[0].ID] {
+				t.Errorf("%q in community %d, want %d with %q", mem.Content, communities[mem.ID], communities[group[0].ID], group[0].Content)
+			}
+		}
+	}
+	if communities[tides[0].ID] == communities[kitchen[0].ID] {
+		t.Error("separate subgraphs merged into one community")
+	}
+	if c := communities[loner.ID]; c == communities[tides[0].ID] || c == communities[kitchen[0].ID] {
+		t.Error("unconnected memory joined a community")
+	}
+
+	sizes := CommunitySizes(communities)
+	if len(sizes) != 3 {
+		t.Fatalf("community sizes = %v, want three communities", sizes)
+	}
+	if sizes[communities[tides[0].ID]] != 4 || sizes[communities[kitchen[0].ID]] != 4 || sizes[communities[loner.ID]] != 1 {
+		t.Errorf("community sizes = %v, want 4, 4 and 1", sizes)
+	}
+	for c := range sizes {
+		if c < 0 || c >= len(sizes) {
+			t.Errorf("community ID %d not numbered densely from 0", c)
+		}
+	}
+
+	if again := hm.DetectCommunities(); !reflect.DeepEqual(again, communities) {
+		t.Errorf("second detection = %v, want the same %v", again, communities)
+	}
+}
+
+func TestDetectCommunitiesEmpty(t *testing.T) {
+	hm := newTestMemory(t, nil)
+	if got := hm.DetectCommunities(); len(got) != 0 {
+		t.Errorf("communities of an empty memory = %v", got)
+	}
+	if got := CommunitySizes(nil); len(got) != 0 {
+		t.Errorf("CommunitySizes(nil) = %v", got)
+	}
+}
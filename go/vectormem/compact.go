@@ -0,0 +1,196 @@
+package vectormem
+
+import (
+	"context"
+	"math"
+	"sort"
+)
+
+// CompactClusters merges groups of same-type memories whose similarity to a
+// cluster seed is at least similarityThreshold. Each group collapses into a single
+// representative (the most important member) which sums the group's importance
+// (up to the same cap feedback uses) and access counts, unions its connections,
+// averages its embeddings and numeric metadata, and keeps the longest lifetime.
+// It returns how many memories were merged away. Compaction is heavier than
+// dedup-on-insert and is intended for maintenance windows; clusters are found on
+// a snapshot so queries are only blocked while each cluster is merged.
+func (hm *HypergraphMemory) CompactClusters(ctx context.Context, similarityThreshold float64) (int, error) {
+	clusters := hm.findClusters(hm.snapshotMemories(), similarityThreshold)
+
+	merged := 0
+	for _, ids := range clusters {
+		if err := ctx.Err(); err != nil {
+			return merged, err
+		}
+
+		hm.mu.Lock()
+		// Members may have been removed or expired since the snapshot
+		now := hm.clock.Now()
+		cluster := make([]*Memory, 0, len(ids))
+		for _, id := range ids {
+			if mem, ok := hm.memories[id]; ok && !mem.expired(now) {
+				cluster = append(cluster, mem)
+			}
+		}
+		if len(cluster) > 1 {
+			merged += hm.mergeCluster(cluster)
+		}
+		hm.mu.Unlock()
+	}
+
+	if merged > 0 {
+		hm.logger.Info("compacted memory clusters", "merged", merged)
+	}
+	return merged, nil
+}
+
+// findClusters greedily groups same-type memories around seeds in ID order,
+// returning the member IDs of each cluster
+func (hm *HypergraphMemory) findClusters(snapshot []memorySnapshot, threshold float64) [][]string {
+	byType := make(map[MemoryType][]memorySnapshot)
+	for _, snap := range snapshot {
+		byType[snap.memType] = append(byType[snap.memType], snap)
+	}
+
+	types := make([]MemoryType, 0, len(byType))
+	for memType := range byType {
+		types = append(types, memType)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+
+	clusters := make([][]string, 0)
+	for _, memType := range types {
+		members := byType[memType]
+
+		assigned := make(map[string]bool)
+		for i, seed := range members {
+			if assigned[seed.id] {
+				continue
+			}
+			cluster := []string{seed.id}
+			for _, other := range members[i+1:] {
+				if assigned[other.id] {
+					continue
+				}
+				if hm.memorySimilarity(seed, other) >= threshold {
+					cluster = append(cluster, other.id)
+					assigned[other.id] = true
+				}
+			}
+			if len(cluster) > 1 {
+				assigned[seed.id] = true
+				clusters = append(clusters, cluster)
+			}
+		}
+	}
+
+	return clusters
+}
+
+// memorySimilarity compares two memories by embedding when both have one, otherwise by text
+func (hm *HypergraphMemory) memorySimilarity(a, b memorySnapshot) float64 {
+	if a.embedding != nil && b.embedding != nil {
+		return hm.similarity(a.embedding, b.embedding)
+	}
+	return textSimilarity(a.content, b.content)
+}
+
+// mergeCluster folds every member of a cluster into its most important member
+// and returns how many memories were removed (must hold lock)
+func (hm *HypergraphMemory) mergeCluster(cluster []*Memory) int {
+	rep := cluster[0]
+	for _, mem := range cluster[1:] {
+		if mem.Importance > rep.Importance {
+			rep = mem
+		}
+	}
+
+	// Metadata changes below; reindex the representative once it settles
+	hm.unindexMemory(rep)
+
+	numericSums := make(map[string]float64)
+	numericCounts := make(map[string]int)
+	repEmbedding := rep.vector()
+	embeddingSum := make([]float64, len(repEmbedding))
+	embeddingCount := 0
+
+	for _, mem := range cluster {
+		for k, v := range mem.Metadata {
+			if f, ok := v.(float64); ok {
+				numericSums[k] += f
+				numericCounts[k]++
+			}
+		}
+		if embedding := mem.vector(); embedding != nil && len(embedding) == len(repEmbedding) {
+			for i, v := range embedding {
+				embeddingSum[i] += float64(v)
+			}
+			embeddingCount++
+		}
+	}
+
+	for _, mem := range cluster {
+		if mem == rep {
+			continue
+		}
+
+		rep.Importance = math.Min(maxImportance, rep.Importance+mem.Importance)
+		rep.AccessCount += mem.AccessCount
+		if mem.CreatedAt.Before(rep.CreatedAt) {
+			rep.CreatedAt = mem.CreatedAt
+		}
+		if mem.AccessedAt.After(rep.AccessedAt) {
+			rep.AccessedAt = mem.AccessedAt
+		}
+		if rep.ExpiresAt != nil && (mem.ExpiresAt == nil || mem.ExpiresAt.After(*rep.ExpiresAt)) {
+			rep.ExpiresAt = mem.ExpiresAt
+		}
+
+		// Representative values win; other members fill in missing keys
+		for k, v := range mem.Metadata {
+			if rep.Metadata == nil {
+				rep.Metadata = make(map[string]interface{})
+			}
+			if _, ok := rep.Metadata[k]; !ok {
+				rep.Metadata[k] = v
+			}
+		}
+
+		// Re-home the member's connections onto the representative
+		for _, connID := range mem.Connections {
+			neighbor, ok := hm.memories[connID]
+			if !ok || neighbor == rep || isConnected(rep, neighbor) {
+				continue
+			}
+			rep.Connections = append(rep.Connections, connID)
+			neighbor.Connections = append(neighbor.Connections, rep.ID)
+		}
+	}
+
+	for k, sum := range numericSums {
+		if numericCounts[k] > 1 {
+			rep.Metadata[k] = sum / float64(numericCounts[k])
+		}
+	}
+	if embeddingCount > 1 {
+		// Replace rather than modify in place; snapshots may share the old slice
+		averaged := make([]float32, len(repEmbedding))
+		for i := range averaged {
+			averaged[i] = float32(embeddingSum[i] / float64(embeddingCount))
+		}
+		hm.setEmbedding(rep, averaged)
+	}
+
+	hm.indexMemory(rep)
+
+	removed := 0
+	for _, mem := range cluster {
+		if mem != rep {
+			hm.removeMemory(mem.ID)
+			removed++
+		}
+	}
+
+	hm.dirty = true
+	return removed
+}
@@ -0,0 +1,123 @@
+package vectormem
+
+import (
+	"context"
+	"math"
+	"testing"
+)
+
+func TestCompactClustersMergesAndUnionsConnections(t *testing.T) {
+	hm := newTestMemory(t, func(c *HypergraphConfig) {
+		c.Clock = newFakeClock()
+		c.EmbeddingFunc = tableEmbedding(map[string][]float32{
+			"kettle on":           {1, 0, 0},
+			"the kettle is on":    {0.98, 0.1, 0},
+			"kettle switched on":  {0.97, 0, 0.1},
+			"harbour walk":        {0, 1, 0},
+			"walk by the harbour": {0.05, 0.99, 0},
+			"read a novel":        {0, 0, 1},
+			"kettles boil water":  {1, 0, 0},
+		})
+	})
+	ctx := context.Background()
+
+	a1 := mustAdd(t, hm, EpisodicMemory, "kettle on", map[string]interface{}{"mood": 0.2})
+	a2 := mustAdd(t, hm, EpisodicMemory, "the kettle is on", map[string]interface{}{"mood": 0.4})
+	a3 := mustAdd(t, hm, EpisodicMemory, "kettle switched on", map[string]interface{}{"room": "kitchen"})
+	mustAdd(t, hm, EpisodicMemory, "harbour walk", nil)
+	mustAdd(t, hm, EpisodicMemory, "walk by the harbour", nil)
+	loner := mustAdd(t, hm, EpisodicMemory, "read a novel", nil)
+	fact := mustAdd(t, hm, DeclarativeMemory, "kettles boil water", nil)
+	if err := hm.Connect(a2.ID, loner.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := hm.Connect(a3.ID, fact.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	merged, err := hm.CompactClusters(ctx, 0.95)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if merged != 3 {
+		t.Errorf("merged %d memories, want 3", merged)
+	}
+	if n := len(hm.Recent(EpisodicMemory, -1)); n != 3 {
+		t.Errorf("%d episodic memories remain, want 3", n)
+	}
+	if n := len(hm.Recent(DeclarativeMemory, -1)); n != 1 {
+		t.Errorf("%d declarative memories remain, want the other type untouched", n)
+	}
+
+	// The first seed represents the kettle cluster when importance ties
+	connected, err := hm.GetConnected(a1.ID)
+	if err != nil {
+		t.Fatalf("representative %s: %v", a1.ID, err)
+	}
+	if !hasContent(connected, "read a novel") || !hasContent(connected, "kettles boil water") {
+		t.Errorf("representative connections = %v, want the members' neighbors", contents(connected))
+	}
+	for _, id := range []string{loner.ID, fact.ID} {
+		neighbors, err := hm.GetConnected(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(neighbors) != 1 || neighbors[0].ID != a1.ID {
+			t.Errorf("neighbors of %s = %v, want only the representative", id, contents(neighbors))
+		}
+	}
+
+	var rep MemoryView
+	for _, v := range hm.Recent(EpisodicMemory, -1) {
+		if v.ID == a1.ID {
+			rep = v
+		}
+	}
+	if rep.Importance != 3.0 {
+		t.Errorf("representative importance = %v, want the summed 3.0", rep.Importance)
+	}
+	if mood, _ := rep.Metadata["mood"].(float64); math.Abs(mood-0.3) > 1e-9 {
+		t.Errorf("averaged mood = %v, want 0.3", mood)
+	}
+	if rep.Metadata["room"] != "kitchen" {
+		t.Errorf("metadata = %v, want the room filled in from a member", rep.Metadata)
+	}
+}
+
+func TestCompactClustersCapsImportance(t *testing.T) {
+	hm := newTestMemory(t, func(c *HypergraphConfig) {
+		c.Clock = newFakeClock()
+		c.EmbeddingFunc = wordEmbedding("kettle")
+	})
+	for i := 0; i < 8; i++ {
+		mustAdd(t, hm, EpisodicMemory, "kettle", nil)
+	}
+
+	merged, err := hm.CompactClusters(context.Background(), 0.99)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if merged != 7 {
+		t.Errorf("merged %d memories, want 7", merged)
+	}
+	if got := hm.Recent("", 1)[0].Importance; got != maxImportance {
+		t.Errorf("importance = %v, want capped at %v", got, maxImportance)
+	}
+}
+
+func TestCompactClustersHonorsContext(t *testing.T) {
+	hm := newTestMemory(t, func(c *HypergraphConfig) {
+		c.EmbeddingFunc = wordEmbedding("kettle")
+	})
+	mustAdd(t, hm, EpisodicMemory, "kettle", nil)
+	mustAdd(t, hm, EpisodicMemory, "kettle", nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := hm.CompactClusters(ctx, 0.9); err == nil {
+		t.Error("CompactClusters ignored a canceled context")
+	}
+	if n := len(hm.Recent("", -1)); n != 2 {
+		t.Errorf("%d memories remain after a canceled compaction, want 2", n)
+	}
+}
@@ -0,0 +1,58 @@
+package vectormem
+
+// ConsolidationPolicy selects when the memory consolidates after growing past MaxMemories
+type ConsolidationPolicy string
+
+const (
+	// ConsolidateEager consolidates inside the Add that exceeded capacity
+	ConsolidateEager ConsolidationPolicy = "eager"
+	// ConsolidateBackground signals a background goroutine to consolidate, so
+	// Add returns without waiting; the memory may briefly exceed capacity
+	ConsolidateBackground ConsolidationPolicy = "background"
+	// ConsolidateManual never consolidates on its own; call ConsolidateNow
+	ConsolidateManual ConsolidationPolicy = "manual"
+)
+
+// valid reports whether the policy is one of the known policies
+func (p ConsolidationPolicy) valid() bool {
+	switch p {
+	case ConsolidateEager, ConsolidateBackground, ConsolidateManual:
+		return true
+	default:
+		return false
+	}
+}
+
+// consolidationLoop consolidates whenever an Add signals the memory is over
+// capacity, until the memory is stopped
+func (hm *HypergraphMemory) consolidationLoop() {
+	for {
+		select {
+		case <-hm.stopChan:
+			return
+		case <-hm.consolidateSignal:
+			if evicted := hm.ConsolidateNow(); evicted > 0 {
+				hm.logger.Debug("background consolidation finished", "evicted", evicted)
+			}
+		}
+	}
+}
+
+// checkCapacity consolidates according to the policy once the memory holds
+// more than MaxMemories (must hold lock)
+func (hm *HypergraphMemory) checkCapacity() {
+	if len(hm.memories) <= hm.maxMemories {
+		return
+	}
+
+	switch hm.consolidationPolicy {
+	case ConsolidateEager:
+		hm.consolidate()
+	case ConsolidateBackground:
+		// A pending signal already covers this insert
+		select {
+		case hm.consolidateSignal <- struct{}{}:
+		default:
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package vectormem
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// blockingLogger holds up consolidation at its first eviction, while the
+// memory is locked, until released
+type blockingLogger struct {
+	nopLogger
+	evicting chan struct{}
+	release  chan struct{}
+}
+
+func newBlockingLogger() *blockingLogger {
+	return &blockingLogger{evicting: make(chan struct{}, 1), release: make(chan struct{})}
+}
+
+func (l *blockingLogger) Info(msg string, keyvals ...interface{}) {
+	if msg != "consolidation evicted memory" {
+		return
+	}
+	select {
+	case l.evicting <- struct{}{}:
+		<-l.release
+	default:
+	}
+}
+
+func newPolicyMemory(t *testing.T, policy ConsolidationPolicy, logger Logger) *HypergraphMemory {
+	return newTestMemory(t, func(c *HypergraphConfig) {
+		c.MaxMemories = 3
+		c.ConsolidationPolicy = policy
+		if logger != nil {
+			c.Logger = logger
+		}
+	})
+}
+
+func fill(t *testing.T, hm *HypergraphMemory, n int) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		mustAdd(t, hm, EpisodicMemory, fmt.Sprintf("memory %d", i), nil)
+	}
+}
+
+func TestConsolidationPolicyEager(t *testing.T) {
+	hm := newPolicyMemory(t, ConsolidateEager, nil)
+	fill(t, hm, 5)
+	if got := hm.GetStats()["total_memories"]; got != 3 {
+		t.Errorf("eager memory holds %v memories, want capacity 3", got)
+	}
+}
+
+func TestConsolidationPolicyManual(t *testing.T) {
+	hm := newPolicyMemory(t, ConsolidateManual, nil)
+	fill(t, hm, 5)
+	if got := hm.GetStats()["total_memories"]; got != 5 {
+		t.Fatalf("manual memory holds %v memories, want all 5 until ConsolidateNow", got)
+	}
+	if evicted := hm.ConsolidateNow(); evicted != 2 {
+		t.Errorf("ConsolidateNow evicted %d, want 2", evicted)
+	}
+	if got := hm.GetStats()["total_memories"]; got != 3 {
+		t.Errorf("after ConsolidateNow: %v memories, want 3", got)
+	}
+}
+
+func TestConsolidationPolicyBackground(t *testing.T) {
+	logger := newBlockingLogger()
+	hm := newPolicyMemory(t, ConsolidateBackground, logger)
+	fill(t, hm, 3)
+
+	// The Add that exceeds capacity returns even though consolidation is
+	// stuck holding the lock; an eager Add would never return
+	added := make(chan struct{})
+	go func() {
+		defer close(added)
+		if _, err := hm.Add(context.Background(), EpisodicMemory, "one too many", nil); err != nil {
+			t.Error(err)
+		}
+	}()
+	select {
+	case <-added:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Add over capacity blocked on consolidation")
+	}
+	select {
+	case <-logger.evicting:
+	case <-time.After(5 * time.Second):
+		t.Fatal("background consolidation never started")
+	}
+	close(logger.release)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for hm.GetStats()["total_memories"] != 3 {
+		if time.Now().After(deadline) {
+			t.Fatalf("background consolidation left %v memories, want 3", hm.GetStats()["total_memories"])
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestUnknownConsolidationPolicy(t *testing.T) {
+	config := DefaultConfig()
+	config.ConsolidationPolicy = "sometimes"
+	if _, err := NewHypergraphMemory(config); err == nil {
+		t.Error("NewHypergraphMemory accepted an unknown consolidation policy")
+	}
+}
@@ -0,0 +1,56 @@
+package vectormem
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestLoadRecomputesStaleDecay(t *testing.T) {
+	clock := newFakeClock()
+	store := &memoryStore{}
+	configure := func(c *HypergraphConfig) {
+		c.Clock = clock
+		c.PersistPath = "memories"
+		c.Store = store
+	}
+
+	hm := newTestMemory(t, configure)
+	stale := mustAdd(t, hm, EpisodicMemory, "left the lights on", nil)
+	if err := hm.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	// 100 hours at the default 0.01 rate decays to e^-1
+	clock.Advance(100 * time.Hour)
+	loaded := newTestMemory(t, configure)
+
+	views := loaded.Recent("", -1)
+	if len(views) != 1 || views[0].ID != stale.ID {
+		t.Fatalf("loaded %v, want the saved memory", contents(views))
+	}
+	if got, want := views[0].Decay, math.Exp(-1); math.Abs(got-want) > 1e-9 {
+		t.Errorf("decay after load = %v, want %v", got, want)
+	}
+}
+
+func TestLoadKeepsFreshDecay(t *testing.T) {
+	clock := newFakeClock()
+	store := &memoryStore{}
+	configure := func(c *HypergraphConfig) {
+		c.Clock = clock
+		c.PersistPath = "memories"
+		c.Store = store
+	}
+
+	hm := newTestMemory(t, configure)
+	mustAdd(t, hm, EpisodicMemory, "just now", nil)
+	if err := hm.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := newTestMemory(t, configure)
+	if got := loaded.Recent("", 1)[0].Decay; got != 1.0 {
+		t.Errorf("decay of a memory accessed at load time = %v, want 1.0", got)
+	}
+}
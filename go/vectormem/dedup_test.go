@@ -0,0 +1,73 @@
+package vectormem
+
+import "testing"
+
+func newDedupMemory(t *testing.T) *HypergraphMemory {
+	return newTestMemory(t, func(c *HypergraphConfig) {
+		c.DedupThreshold = 0.95
+		c.EmbeddingFunc = tableEmbedding(map[string][]float32{
+			"the kettle is on the stove": {1, 0, 0},
+			"the kettle's on the stove":  {0.99, 0.05, 0},
+			"the kettle is in the shed":  {0.7, 0.7, 0},
+		})
+	})
+}
+
+func TestDedupMergesNearDuplicate(t *testing.T) {
+	hm := newDedupMemory(t)
+
+	first := mustAdd(t, hm, EpisodicMemory, "the kettle is on the stove", map[string]interface{}{"room": "kitchen"})
+	second := mustAdd(t, hm, EpisodicMemory, "the kettle's on the stove", map[string]interface{}{"seen_by": "ana"})
+	if second.ID != first.ID {
+		t.Fatalf("near-duplicate stored as %s, want merged into %s", second.ID, first.ID)
+	}
+
+	recent := hm.Recent(EpisodicMemory, -1)
+	if len(recent) != 1 {
+		t.Fatalf("got %d memories, want 1", len(recent))
+	}
+	mem := recent[0]
+	if mem.Content != "the kettle is on the stove" {
+		t.Errorf("merged content = %q, want the original kept", mem.Content)
+	}
+	if mem.Importance <= 1.0 || mem.AccessCount != 1 {
+		t.Errorf("importance %v, access count %d; want a boosted importance and one access", mem.Importance, mem.AccessCount)
+	}
+	if mem.Metadata["room"] != "kitchen" || mem.Metadata["seen_by"] != "ana" {
+		t.Errorf("metadata = %v, want both inserts' keys", mem.Metadata)
+	}
+}
+
+func TestDedupInsertsBelowThreshold(t *testing.T) {
+	hm := newDedupMemory(t)
+
+	first := mustAdd(t, hm, EpisodicMemory, "the kettle is on the stove", nil)
+	second := mustAdd(t, hm, EpisodicMemory, "the kettle is in the shed", nil)
+	if second.ID == first.ID {
+		t.Fatal("dissimilar memory merged into existing one")
+	}
+	if n := len(hm.Recent(EpisodicMemory, -1)); n != 2 {
+		t.Errorf("got %d memories, want 2", n)
+	}
+}
+
+func TestDedupIgnoresOtherTypes(t *testing.T) {
+	hm := newDedupMemory(t)
+
+	first := mustAdd(t, hm, EpisodicMemory, "the kettle is on the stove", nil)
+	second := mustAdd(t, hm, DeclarativeMemory, "the kettle is on the stove", nil)
+	if second.ID == first.ID {
+		t.Fatal("memory of a different type merged into existing one")
+	}
+}
+
+func TestDedupImportanceIsCapped(t *testing.T) {
+	hm := newDedupMemory(t)
+
+	for i := 0; i < 100; i++ {
+		mustAdd(t, hm, EpisodicMemory, "the kettle is on the stove", nil)
+	}
+	if got := hm.Recent(EpisodicMemory, 1)[0].Importance; got != maxImportance {
+		t.Errorf("importance after repeated merges = %v, want capped at %v", got, maxImportance)
+	}
+}
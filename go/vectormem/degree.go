@@ -0,0 +1,48 @@
+package vectormem
+
+import "sort"
+
+// DegreeDistribution maps each connection count to how many unexpired memories
+// have that many connections, showing whether a few hubs dominate the graph
+func (hm *HypergraphMemory) DegreeDistribution() map[int]int {
+	hm.mu.RLock()
+	defer hm.mu.RUnlock()
+
+	now := hm.clock.Now()
+	distribution := make(map[int]int)
+	for _, mem := range hm.memories {
+		if !mem.expired(now) {
+			distribution[len(mem.Connections)]++
+		}
+	}
+	return distribution
+}
+
+// TopHubs returns views of the n most-connected unexpired memories, most
+// connections first with ties broken by ID. Memories without connections are
+// never hubs.
+func (hm *HypergraphMemory) TopHubs(n int) []MemoryView {
+	hm.mu.RLock()
+	defer hm.mu.RUnlock()
+
+	now := hm.clock.Now()
+	hubs := make([]*Memory, 0)
+	for _, mem := range hm.memories {
+		if len(mem.Connections) > 0 && !mem.expired(now) {
+			hubs = append(hubs, mem)
+		}
+	}
+
+	sort.Slice(hubs, func(i, j int) bool {
+		if len(hubs[i].Connections) != len(hubs[j].Connections) {
+			return len(hubs[i].Connections) > len(hubs[j].Connections)
+		}
+		return hubs[i].ID < hubs[j].ID
+	})
+
+	if n >= 0 && n < len(hubs) {
+		hubs = hubs[:n]
+	}
+
+	return views(hubs)
+}
@@ -0,0 +1,71 @@
+package vectormem
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDegreeDistributionOfStar(t *testing.T) {
+	clock := newFakeClock()
+	hm := newTestMemory(t, func(c *HypergraphConfig) { c.Clock = clock })
+
+	hub := mustAdd(t, hm, EpisodicMemory, "hub", nil)
+	leaves := make([]MemoryView, 5)
+	for i := range leaves {
+		leaves[i] = mustAdd(t, hm, EpisodicMemory, fmt.Sprintf("leaf %d", i), nil)
+		if err := hm.Connect(hub.ID, leaves[i].ID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := hm.Connect(leaves[0].ID, leaves[1].ID); err != nil {
+		t.Fatal(err)
+	}
+	mustAdd(t, hm, EpisodicMemory, "isolated", nil)
+	if _, err := hm.AddWithTTL(context.Background(), EpisodicMemory, "fleeting", nil, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	clock.Advance(time.Hour)
+
+	want := map[int]int{5: 1, 2: 2, 1: 3, 0: 1}
+	if got := hm.DegreeDistribution(); !reflect.DeepEqual(got, want) {
+		t.Errorf("DegreeDistribution = %v, want %v", got, want)
+	}
+
+	top := hm.TopHubs(1)
+	if len(top) != 1 || top[0].ID != hub.ID {
+		t.Fatalf("TopHubs(1) = %v, want the hub", contents(top))
+	}
+	all := hm.TopHubs(-1)
+	if len(all) != 6 {
+		t.Fatalf("TopHubs(-1) = %v, want the six connected memories", contents(all))
+	}
+	second, third := leaves[0].ID, leaves[1].ID
+	if third < second {
+		second, third = third, second
+	}
+	if all[1].ID != second || all[2].ID != third {
+		t.Errorf("TopHubs order = %v, want the linked leaves next in ID order", contents(all))
+	}
+	for _, v := range all[3:] {
+		if len(v.Connections) != 1 {
+			t.Errorf("%s ranked after the linked leaves with %d connections", v.Content, len(v.Connections))
+		}
+	}
+	if got := hm.TopHubs(0); len(got) != 0 {
+		t.Errorf("TopHubs(0) = %v, want none", contents(got))
+	}
+}
+
+func TestDegreeDistributionEmpty(t *testing.T) {
+	hm := newTestMemory(t, nil)
+	if got := hm.DegreeDistribution(); len(got) != 0 {
+		t.Errorf("DegreeDistribution of an empty memory = %v", got)
+	}
+	mustAdd(t, hm, EpisodicMemory, "alone", nil)
+	if got := hm.TopHubs(5); len(got) != 0 {
+		t.Errorf("TopHubs without connections = %v, want none", contents(got))
+	}
+}
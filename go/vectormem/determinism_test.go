@@ -0,0 +1,77 @@
+package vectormem
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// newDeterministicMemory adds the same memories, all scoring equally against
+// "query", to a memory on a fixed clock and returns it with their IDs
+func newDeterministicMemory(t *testing.T) (*HypergraphMemory, []string) {
+	table := map[string][]float32{"query": {1, 0}}
+	for i := 0; i < 12; i++ {
+		table[fmt.Sprintf("twin %d", i)] = []float32{1, 1}
+	}
+	clock := newFakeClock()
+	hm := newTestMemory(t, func(c *HypergraphConfig) {
+		c.Clock = clock
+		c.EmbeddingFunc = tableEmbedding(table)
+	})
+
+	ids := make([]string, 0, 12)
+	for i := 0; i < 12; i++ {
+		ids = append(ids, mustAdd(t, hm, EpisodicMemory, fmt.Sprintf("twin %d", i), nil).ID)
+	}
+	return hm, ids
+}
+
+func TestDeterministicIDs(t *testing.T) {
+	_, first := newDeterministicMemory(t)
+	_, second := newDeterministicMemory(t)
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("IDs differ between runs on the same clock:\n%v\n%v", first, second)
+	}
+
+	seen := make(map[string]bool)
+	for _, id := range first {
+		if seen[id] {
+			t.Errorf("duplicate ID %s at a fixed time", id)
+		}
+		seen[id] = true
+	}
+}
+
+func TestQueryTieBreaksByID(t *testing.T) {
+	ctx := context.Background()
+	hm, ids := newDeterministicMemory(t)
+	other, _ := newDeterministicMemory(t)
+
+	sorted := append([]string(nil), ids...)
+	sort.Strings(sorted)
+
+	var want []string
+	for run := 0; run < 5; run++ {
+		for _, m := range []*HypergraphMemory{hm, other} {
+			views, err := m.Query(ctx, "query", "", 5)
+			if err != nil {
+				t.Fatalf("Query: %v", err)
+			}
+			got := make([]string, len(views))
+			for i, v := range views {
+				got[i] = v.ID
+			}
+			if want == nil {
+				want = got
+				if !reflect.DeepEqual(want, sorted[:5]) {
+					t.Fatalf("tied results = %v, want the lowest IDs in order %v", want, sorted[:5])
+				}
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("run %d: results = %v, want %v", run, got, want)
+			}
+		}
+	}
+}
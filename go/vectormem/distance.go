@@ -0,0 +1,102 @@
+package vectormem
+
+import (
+	"fmt"
+	"math"
+)
+
+// DistanceMetric selects how embedding similarity is computed
+type DistanceMetric string
+
+const (
+	// MetricCosine compares embedding direction, ignoring magnitude
+	MetricCosine DistanceMetric = "cosine"
+	// MetricDot uses the raw dot product, for models trained with it
+	MetricDot DistanceMetric = "dot"
+	// MetricEuclidean converts Euclidean distance d to a similarity 1/(1+d)
+	MetricEuclidean DistanceMetric = "euclidean"
+)
+
+// valid reports whether the metric is one of the known metrics
+func (m DistanceMetric) valid() bool {
+	switch m {
+	case MetricCosine, MetricDot, MetricEuclidean:
+		return true
+	default:
+		return false
+	}
+}
+
+// similarity compares two embeddings using the configured metric.
+// Higher values always mean more similar.
+func (hm *HypergraphMemory) similarity(a, b []float32) float64 {
+	switch hm.distanceMetric {
+	case MetricDot:
+		return dotProduct(a, b)
+	case MetricEuclidean:
+		return euclideanSimilarity(a, b)
+	default:
+		return cosineSimilarity(a, b)
+	}
+}
+
+// normalizedSimilarity maps the configured metric's similarity onto [0, 1] so it
+// is comparable with text relevance. Opposed or unrelated embeddings score 0.
+func (hm *HypergraphMemory) normalizedSimilarity(a, b []float32) float64 {
+	return hm.normalizeSimilarity(hm.similarity(a, b))
+}
+
+// normalizeSimilarity maps a raw similarity from the configured metric onto [0, 1]
+func (hm *HypergraphMemory) normalizeSimilarity(sim float64) float64 {
+	switch hm.distanceMetric {
+	case MetricDot:
+		// Squash the unbounded dot product into [0, 1)
+		if sim <= 0 {
+			return 0
+		}
+		return sim / (1.0 + sim)
+	case MetricEuclidean:
+		// Already in (0, 1]
+		return sim
+	default:
+		return math.Max(0, sim)
+	}
+}
+
+// checkEmbeddingDim asserts that an embedding has the expected length. When no
+// dimension is configured, the first embedding seen fixes it.
+func (hm *HypergraphMemory) checkEmbeddingDim(embedding []float32) error {
+	if hm.embeddingDim == 0 {
+		hm.embeddingDim = len(embedding)
+		return nil
+	}
+	if len(embedding) != hm.embeddingDim {
+		return fmt.Errorf("embedding dimension mismatch: got %d, want %d", len(embedding), hm.embeddingDim)
+	}
+	return nil
+}
+
+func dotProduct(a, b []float32) float64 {
+	if len(a) != len(b) {
+		return 0
+	}
+
+	var sum float64
+	for i := range a {
+		sum += float64(a[i]) * float64(b[i])
+	}
+	return sum
+}
+
+func euclideanSimilarity(a, b []float32) float64 {
+	if len(a) != len(b) {
+		return 0
+	}
+
+	var sum float64
+	for i := range a {
+		d := float64(a[i]) - float64(b[i])
+		sum += d * d
+	}
+	return 1.0 / (1.0 + math.Sqrt(sum))
+}
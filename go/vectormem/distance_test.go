@@ -0,0 +1,84 @@
+package vectormem
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestDistanceMetricRanking(t *testing.T) {
+	// Against the query {1, 0}, "near" is closest, "aligned" points the same
+	// way, and "large" has the biggest projection
+	table := map[string][]float32{
+		"query":   {1, 0},
+		"near":    {0.5, 0},
+		"aligned": {3, 0.5},
+		"close":   {1.2, 0.9},
+		"large":   {10, 10},
+	}
+	tests := []struct {
+		metric DistanceMetric
+		want   []string
+	}{
+		{MetricCosine, []string{"near", "aligned", "close", "large"}},
+		{MetricDot, []string{"large", "aligned", "close", "near"}},
+		{MetricEuclidean, []string{"near", "close", "aligned", "large"}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.metric), func(t *testing.T) {
+			hm := newTestMemory(t, func(c *HypergraphConfig) {
+				c.DistanceMetric = tt.metric
+				c.EmbeddingFunc = tableEmbedding(table)
+			})
+			for _, content := range []string{"near", "aligned", "close", "large"} {
+				mustAdd(t, hm, DeclarativeMemory, content, nil)
+			}
+
+			results, err := hm.Query(context.Background(), "query", DeclarativeMemory, 4)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := contents(results); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ranking = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnknownDistanceMetric(t *testing.T) {
+	config := DefaultConfig()
+	config.DistanceMetric = "manhattan"
+	if _, err := NewHypergraphMemory(config); err == nil {
+		t.Error("NewHypergraphMemory accepted an unknown distance metric")
+	}
+}
+
+func TestEmbeddingDimensionMismatch(t *testing.T) {
+	hm := newTestMemory(t, func(c *HypergraphConfig) {
+		c.EmbeddingFunc = tableEmbedding(map[string][]float32{
+			"three": {1, 0, 0},
+			"two":   {1, 0},
+		})
+	})
+	mustAdd(t, hm, EpisodicMemory, "three", nil)
+
+	if _, err := hm.Add(context.Background(), EpisodicMemory, "two", nil); err == nil {
+		t.Error("Add accepted an embedding of a different dimension")
+	}
+	if _, err := hm.Query(context.Background(), "two", EpisodicMemory, 1); err == nil {
+		t.Error("Query accepted an embedding of a different dimension")
+	}
+}
+
+func TestSimilarityFunctions(t *testing.T) {
+	a, b := []float32{3, 4}, []float32{0, 0}
+	if got := euclideanSimilarity(a, b); got != 1.0/6.0 {
+		t.Errorf("euclideanSimilarity = %v, want 1/(1+5)", got)
+	}
+	if got := dotProduct(a, []float32{1, 1}); got != 7 {
+		t.Errorf("dotProduct = %v, want 7", got)
+	}
+	if got := cosineSimilarity(a, []float32{6, 8}); got < 0.9999 {
+		t.Errorf("cosineSimilarity of parallel vectors = %v, want 1", got)
+	}
+}
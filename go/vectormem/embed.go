@@ -0,0 +1,90 @@
+package vectormem
+
+import (
+	"context"
+	"fmt"
+)
+
+// BatchEmbeddingFunc creates embeddings for many texts in one call, returning
+// one embedding per text in the same order
+type BatchEmbeddingFunc func(ctx context.Context, texts []string) ([][]float32, error)
+
+// embedBatch embeds texts with the batch embedding function when configured,
+// otherwise one at a time with the embedding function. It returns nil when
+// neither is configured.
+func (hm *HypergraphMemory) embedBatch(ctx context.Context, texts []string) ([][]float32, error) {
+	if len(texts) == 0 {
+		return nil, nil
+	}
+
+	if hm.batchEmbedFunc != nil {
+		embeddings, err := hm.batchEmbedFunc(ctx, texts)
+		if err != nil {
+			hm.logger.Error("failed to create batch embeddings", "count", len(texts), "error", err)
+			return nil, fmt.Errorf("failed to create batch embeddings: %w", err)
+		}
+		if len(embeddings) != len(texts) {
+			return nil, fmt.Errorf("batch embedding returned %d embeddings for %d texts", len(embeddings), len(texts))
+		}
+		return embeddings, nil
+	}
+
+	if hm.embedFunc == nil {
+		return nil, nil
+	}
+
+	embeddings := make([][]float32, len(texts))
+	for i, text := range texts {
+		embedding, err := hm.embedFunc(ctx, text)
+		if err != nil {
+			hm.logger.Error("failed to create embedding", "error", err)
+			return nil, fmt.Errorf("failed to create embedding: %w", err)
+		}
+		embeddings[i] = embedding
+	}
+	return embeddings, nil
+}
+
+// ReembedAll recomputes every memory's embedding, for example after switching
+// embedding models, and returns how many memories were updated. Embedding runs
+// on a snapshot without holding the lock; results are applied under a brief lock,
+// skipping memories removed in the meantime.
+func (hm *HypergraphMemory) ReembedAll(ctx context.Context) (int, error) {
+	snapshot := hm.snapshotMemories()
+
+	texts := make([]string, len(snapshot))
+	for i, snap := range snapshot {
+		texts[i] = snap.content
+	}
+
+	embeddings, err := hm.embedBatch(ctx, texts)
+	if err != nil {
+		return 0, err
+	}
+	if embeddings == nil {
+		return 0, fmt.Errorf("no embedding function configured")
+	}
+
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+
+	for _, embedding := range embeddings {
+		if err := hm.checkEmbeddingDim(embedding); err != nil {
+			return 0, err
+		}
+	}
+
+	updated := 0
+	for i, snap := range snapshot {
+		if mem, ok := hm.memories[snap.id]; ok {
+			hm.setEmbedding(mem, embeddings[i])
+			updated++
+		}
+	}
+
+	if updated > 0 {
+		hm.dirty = true
+		hm.logger.Info("re-embedded memories", "count", updated)
+	}
+	return updated, nil
+}
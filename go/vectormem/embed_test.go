@@ -0,0 +1,151 @@
+package vectormem
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// countingEmbedder wraps an embedding function, counting single and batch calls
+type countingEmbedder struct {
+	mu         sync.Mutex
+	embed      EmbeddingFunc
+	singles    int
+	batches    int
+	batchSizes []int
+}
+
+func (c *countingEmbedder) single(ctx context.Context, text string) ([]float32, error) {
+	c.mu.Lock()
+	c.singles++
+	c.mu.Unlock()
+	return c.embed(ctx, text)
+}
+
+func (c *countingEmbedder) batch(ctx context.Context, texts []string) ([][]float32, error) {
+	c.mu.Lock()
+	c.batches++
+	c.batchSizes = append(c.batchSizes, len(texts))
+	c.mu.Unlock()
+	out := make([][]float32, len(texts))
+	for i, text := range texts {
+		out[i], _ = c.embed(ctx, text)
+	}
+	return out, nil
+}
+
+func (c *countingEmbedder) reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.singles, c.batches, c.batchSizes = 0, 0, nil
+}
+
+func TestBatchEmbeddingUsedOnBulkPaths(t *testing.T) {
+	embedder := &countingEmbedder{embed: wordEmbedding("kettle", "stove", "harbour", "boats")}
+	hm := newTestMemory(t, func(c *HypergraphConfig) {
+		c.EmbeddingFunc = embedder.single
+		c.BatchEmbeddingFunc = embedder.batch
+	})
+	for _, content := range []string{"kettle on", "stove hot", "harbour calm", "boats out"} {
+		mustAdd(t, hm, EpisodicMemory, content, nil)
+	}
+	ctx := context.Background()
+
+	t.Run("ReembedAll", func(t *testing.T) {
+		embedder.reset()
+		n, err := hm.ReembedAll(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n != 4 || embedder.batches != 1 || embedder.batchSizes[0] != 4 || embedder.singles != 0 {
+			t.Errorf("re-embedded %d with %d batch calls %v and %d single calls, want 4 in one batch",
+				n, embedder.batches, embedder.batchSizes, embedder.singles)
+		}
+	})
+
+	t.Run("BatchQuery", func(t *testing.T) {
+		embedder.reset()
+		results, err := hm.BatchQuery(ctx, []string{"kettle", "harbour", "boats"}, EpisodicMemory, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(results) != 3 || embedder.batches != 1 || embedder.batchSizes[0] != 3 || embedder.singles != 0 {
+			t.Errorf("%d result lists with %d batch calls %v and %d single calls, want 3 queries in one batch",
+				len(results), embedder.batches, embedder.batchSizes, embedder.singles)
+		}
+	})
+
+	t.Run("ImportJSONL", func(t *testing.T) {
+		embedder.reset()
+		input := strings.Join([]string{
+			`{"id":"x","type":"episodic","content":"kettle"}`,
+			`{"id":"y","type":"episodic","content":"stove"}`,
+		}, "\n")
+		if _, err := hm.ImportJSONL(ctx, strings.NewReader(input)); err != nil {
+			t.Fatal(err)
+		}
+		if embedder.batches != 1 || embedder.batchSizes[0] != 2 || embedder.singles != 0 {
+			t.Errorf("%d batch calls %v and %d single calls, want 2 texts in one batch",
+				embedder.batches, embedder.batchSizes, embedder.singles)
+		}
+	})
+}
+
+func TestBatchEmbeddingFallsBackToSingle(t *testing.T) {
+	embedder := &countingEmbedder{embed: wordEmbedding("kettle", "stove")}
+	hm := newTestMemory(t, func(c *HypergraphConfig) { c.EmbeddingFunc = embedder.single })
+	mustAdd(t, hm, EpisodicMemory, "kettle on", nil)
+	mustAdd(t, hm, EpisodicMemory, "stove hot", nil)
+
+	embedder.reset()
+	n, err := hm.ReembedAll(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 2 || embedder.singles != 2 {
+		t.Errorf("re-embedded %d with %d single calls, want 2 of each", n, embedder.singles)
+	}
+}
+
+func TestBatchEmbeddingErrors(t *testing.T) {
+	failure := errors.New("service unavailable")
+	tests := []struct {
+		name  string
+		batch BatchEmbeddingFunc
+		want  string
+	}{
+		{
+			name: "length mismatch",
+			batch: func(ctx context.Context, texts []string) ([][]float32, error) {
+				return [][]float32{{1, 0}}, nil
+			},
+			want: "returned 1 embeddings for 2 texts",
+		},
+		{
+			name: "failure",
+			batch: func(ctx context.Context, texts []string) ([][]float32, error) {
+				return nil, failure
+			},
+			want: failure.Error(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hm := newTestMemory(t, func(c *HypergraphConfig) {
+				c.EmbeddingFunc = wordEmbedding("kettle", "stove")
+				c.BatchEmbeddingFunc = tt.batch
+			})
+			mustAdd(t, hm, EpisodicMemory, "kettle on", nil)
+			mustAdd(t, hm, EpisodicMemory, "stove hot", nil)
+
+			if _, err := hm.ReembedAll(context.Background()); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("ReembedAll error = %v, want one mentioning %q", err, tt.want)
+			}
+			if _, err := hm.BatchQuery(context.Background(), []string{"kettle", "stove"}, "", 1); err == nil {
+				t.Error("BatchQuery succeeded despite the failing batch embedder")
+			}
+		})
+	}
+}
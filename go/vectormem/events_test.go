@@ -0,0 +1,32 @@
+package vectormem
+
+import (
+	"testing"
+	"time"
+
+	"github.com/o9nn/un9n/go/events"
+)
+
+func TestAddPublishesInsertEvent(t *testing.T) {
+	bus := events.NewBus(8)
+	ch, cancel := bus.Subscribe(events.KindMemoryInsert)
+	defer cancel()
+	clock := newFakeClock()
+	hm := newTestMemory(t, func(c *HypergraphConfig) {
+		c.Clock = clock
+		c.Events = bus
+	})
+
+	mem := mustAdd(t, hm, EpisodicMemory, "kettle on the stove", nil)
+	select {
+	case event := <-ch:
+		if event.Source != "vectormem" || event.Data["id"] != mem.ID || event.Data["type"] != string(EpisodicMemory) {
+			t.Errorf("event = %+v, want the inserted memory", event)
+		}
+		if !event.Timestamp.Equal(clock.Now()) {
+			t.Errorf("timestamp = %v, want the memory clock's %v", event.Timestamp, clock.Now())
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no insert event published")
+	}
+}
@@ -0,0 +1,62 @@
+package vectormem
+
+import (
+	"sort"
+	"time"
+)
+
+// EvictionCandidate is a memory consolidation would evict, with its retention score
+type EvictionCandidate struct {
+	ID      string     `json:"id"`
+	Type    MemoryType `json:"type"`
+	Content string     `json:"content"`
+	Score   float64    `json:"score"` // Lower scores are evicted first
+}
+
+// PreviewConsolidation returns the memories consolidation would evict at the
+// current capacity, lowest score first, without changing anything. It is empty
+// when the memory is within capacity.
+func (hm *HypergraphMemory) PreviewConsolidation() []EvictionCandidate {
+	hm.mu.RLock()
+	defer hm.mu.RUnlock()
+	return hm.evictionCandidates(hm.clock.Now())
+}
+
+// ConsolidateNow refreshes decay and evicts the lowest-scoring memories until
+// the memory is within capacity, returning how many were evicted
+func (hm *HypergraphMemory) ConsolidateNow() int {
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+
+	evicted := hm.consolidate()
+	if evicted > 0 {
+		hm.dirty = true
+	}
+	return evicted
+}
+
+// evictionCandidates scores every memory by importance, decay at now, access
+// count, and connections, and returns the lowest-scoring ones beyond capacity.
+// It does not modify the memories. (must hold lock)
+func (hm *HypergraphMemory) evictionCandidates(now time.Time) []EvictionCandidate {
+	toRemove := len(hm.memories) - hm.maxMemories
+	if toRemove <= 0 {
+		return nil
+	}
+
+	scored := make([]EvictionCandidate, 0, len(hm.memories))
+	for id, mem := range hm.memories {
+		score := mem.Importance * hm.decayAt(mem, now) * (1.0 + float64(mem.AccessCount)*0.1) * (1.0 + float64(len(mem.Connections))*0.05)
+		scored = append(scored, EvictionCandidate{ID: id, Type: mem.Type, Content: mem.Content, Score: score})
+	}
+
+	// Lowest scores first, ties broken by ID
+	sort.Slice(scored, func(i, j int) bool {
+		if scored[i].Score != scored[j].Score {
+			return scored[i].Score < scored[j].Score
+		}
+		return scored[i].ID < scored[j].ID
+	})
+
+	return scored[:toRemove]
+}
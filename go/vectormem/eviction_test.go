@@ -0,0 +1,88 @@
+package vectormem
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestPreviewConsolidationMatchesEviction(t *testing.T) {
+	ctx := context.Background()
+	clock := newFakeClock()
+	hm := newTestMemory(t, func(c *HypergraphConfig) {
+		c.Clock = clock
+		c.MaxMemories = 3
+		c.ConsolidationPolicy = ConsolidateManual
+	})
+
+	// Older memories decay further; a queried one gains access
+	for i := 0; i < 6; i++ {
+		mustAdd(t, hm, EpisodicMemory, fmt.Sprintf("memory %d", i), nil)
+		clock.Advance(6 * time.Hour)
+	}
+	if _, err := hm.Query(ctx, "memory 0", "", 1); err != nil {
+		t.Fatal(err)
+	}
+
+	preview := hm.PreviewConsolidation()
+	if len(preview) != 3 {
+		t.Fatalf("preview evicts %d memories, want 3 over capacity", len(preview))
+	}
+	for i := 1; i < len(preview); i++ {
+		if preview[i].Score < preview[i-1].Score {
+			t.Errorf("preview not lowest score first: %+v", preview)
+		}
+	}
+
+	// Previewing changes nothing
+	if again := hm.PreviewConsolidation(); !reflect.DeepEqual(again, preview) {
+		t.Errorf("second preview = %+v, want %+v", again, preview)
+	}
+	if n := hm.GetStats()["total_memories"]; n != 6 {
+		t.Fatalf("total memories after preview = %v, want 6", n)
+	}
+
+	survivors := make(map[string]bool)
+	for _, v := range hm.Recent("", -1) {
+		survivors[v.ID] = true
+	}
+	for _, c := range preview {
+		delete(survivors, c.ID)
+	}
+
+	if evicted := hm.ConsolidateNow(); evicted != len(preview) {
+		t.Errorf("ConsolidateNow evicted %d, want %d", evicted, len(preview))
+	}
+	remaining := hm.Recent("", -1)
+	got := make([]string, len(remaining))
+	for i, v := range remaining {
+		got[i] = v.ID
+	}
+	want := make([]string, 0, len(survivors))
+	for id := range survivors {
+		want = append(want, id)
+	}
+	sort.Strings(got)
+	sort.Strings(want)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("after consolidation %v remain, want the unpreviewed %v", got, want)
+	}
+}
+
+func TestPreviewConsolidationWithinCapacity(t *testing.T) {
+	hm := newTestMemory(t, func(c *HypergraphConfig) {
+		c.MaxMemories = 3
+		c.ConsolidationPolicy = ConsolidateManual
+	})
+	mustAdd(t, hm, EpisodicMemory, "only memory", nil)
+
+	if preview := hm.PreviewConsolidation(); len(preview) != 0 {
+		t.Errorf("preview within capacity = %+v, want none", preview)
+	}
+	if evicted := hm.ConsolidateNow(); evicted != 0 {
+		t.Errorf("ConsolidateNow within capacity evicted %d", evicted)
+	}
+}
@@ -0,0 +1,72 @@
+package vectormem
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+)
+
+// expansionDecay is the per-hop activation decay QueryWithExpansion spreads with
+const expansionDecay = 0.5
+
+// QueryWithExpansion searches like Query, then lets relevance flow along
+// connections: activation spreads expansionDepth hops from each of the top
+// limit matches, starting at that match's score and halving with each hop, and
+// each memory's score is raised by the strongest activation reaching it.
+// Neighbors of strong matches can thereby surface even when they share little
+// with the query.
+// An expansionDepth of 0 behaves like Query.
+func (hm *HypergraphMemory) QueryWithExpansion(ctx context.Context, query string, memType MemoryType, limit, expansionDepth int) ([]MemoryView, error) {
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+
+	start := time.Now()
+	defer hm.recordQueryLatency(start)
+
+	queryEmbedding, err := hm.embedQuery(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	scored := hm.scoreMemories(queryEmbedding, query, QueryOptions{Type: memType})
+	if limit > len(scored) {
+		limit = len(scored)
+	}
+
+	index := make(map[string]int, len(scored))
+	for i, result := range scored {
+		index[result.Memory.ID] = i
+	}
+
+	// Spread from the direct matches before any score changes
+	boost := make(map[int]float64)
+	for _, seed := range scored[:limit] {
+		for id, activation := range hm.spreadActivation(seed.Memory.ID, expansionDepth, expansionDecay) {
+			i, ok := index[id]
+			if !ok || id == seed.Memory.ID {
+				continue
+			}
+			boost[i] = math.Max(boost[i], seed.Score*activation)
+		}
+	}
+	for i, b := range boost {
+		scored[i].Score += b
+	}
+
+	sort.Slice(scored, func(i, j int) bool {
+		if scored[i].Score != scored[j].Score {
+			return scored[i].Score > scored[j].Score
+		}
+		return scored[i].Memory.ID < scored[j].Memory.ID
+	})
+
+	results := make([]*Memory, limit)
+	for i := range results {
+		results[i] = scored[i].Memory
+	}
+	hm.markRetrieved(results)
+	hm.logQuery(query, scored[:limit])
+
+	return views(results), nil
+}
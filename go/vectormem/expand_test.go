@@ -0,0 +1,93 @@
+package vectormem
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+// newExpansionMemory holds "match", which equals the query, "rival", which is
+// somewhat similar to it, "neighbor", which is unrelated, and "far", which
+// is barely related; the last two are chained off match: match - neighbor - far
+func newExpansionMemory(t *testing.T) *HypergraphMemory {
+	hm := newTestMemory(t, func(c *HypergraphConfig) {
+		c.EmbeddingFunc = tableEmbedding(map[string][]float32{
+			"query":    {1, 0, 0},
+			"match":    {1, 0, 0},
+			"rival":    {0.3, 0.954, 0},
+			"neighbor": {0, 0, 1},
+			"far":      {0.1, 0.6, 0.794},
+		})
+	})
+	match := mustAdd(t, hm, EpisodicMemory, "match", nil)
+	mustAdd(t, hm, EpisodicMemory, "rival", nil)
+	neighbor := mustAdd(t, hm, EpisodicMemory, "neighbor", nil)
+	far := mustAdd(t, hm, EpisodicMemory, "far", nil)
+	if err := hm.Connect(match.ID, neighbor.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := hm.Connect(neighbor.ID, far.ID); err != nil {
+		t.Fatal(err)
+	}
+	return hm
+}
+
+func TestQueryWithExpansionSurfacesConnectedNeighbors(t *testing.T) {
+	ctx := context.Background()
+
+	direct, err := newExpansionMemory(t).Query(ctx, "query", EpisodicMemory, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := contents(direct), []string{"match", "rival"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Query = %v, want %v", got, want)
+	}
+
+	// Half of match's score outweighs rival's weak direct similarity
+	expanded, err := newExpansionMemory(t).QueryWithExpansion(ctx, "query", EpisodicMemory, 2, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := contents(expanded), []string{"match", "neighbor"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("QueryWithExpansion depth 1 = %v, want %v", got, want)
+	}
+}
+
+func TestQueryWithExpansionDepth(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		depth int
+		want  []string
+	}{
+		{0, []string{"match", "rival", "far", "neighbor"}},
+		{1, []string{"match", "neighbor", "rival", "far"}},
+		// A quarter of match's score lifts far past rival
+		{2, []string{"match", "neighbor", "far", "rival"}},
+	}
+	for _, tt := range tests {
+		got, err := newExpansionMemory(t).QueryWithExpansion(ctx, "query", EpisodicMemory, 4, tt.depth)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(contents(got), tt.want) {
+			t.Errorf("depth %d: results = %v, want %v", tt.depth, contents(got), tt.want)
+		}
+	}
+}
+
+func TestQueryWithExpansionDepthZeroMatchesQuery(t *testing.T) {
+	ctx := context.Background()
+
+	want, err := newExpansionMemory(t).Query(ctx, "query", "", 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := newExpansionMemory(t).QueryWithExpansion(ctx, "query", "", 3, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(contents(got), contents(want)) {
+		t.Errorf("QueryWithExpansion depth 0 = %v, Query = %v", contents(got), contents(want))
+	}
+}
@@ -0,0 +1,55 @@
+package vectormem
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+const (
+	// minImportance keeps repeatedly unhelpful memories retrievable in principle
+	minImportance = 0.1
+	// maxImportance stops a few popular memories from dominating every query
+	maxImportance = 5.0
+	// feedbackStep is how much one piece of feedback moves importance
+	feedbackStep = 0.1
+)
+
+// RecordFeedback reports whether a retrieved memory was actually useful. Useful
+// memories gain importance and unhelpful ones lose it, within fixed bounds, so
+// consolidation learns from real relevance.
+func (hm *HypergraphMemory) RecordFeedback(id string, useful bool) error {
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+
+	mem, ok := hm.memories[id]
+	if !ok {
+		return fmt.Errorf("memory not found: %s", id)
+	}
+
+	if useful {
+		mem.Useful++
+		mem.Importance += feedbackStep
+	} else {
+		mem.Unhelpful++
+		mem.Importance -= feedbackStep
+	}
+	mem.Importance = math.Max(minImportance, math.Min(maxImportance, mem.Importance))
+	hm.dirty = true
+
+	return nil
+}
+
+// reinforceAccess raises a memory's importance when a query returns it. The
+// boost is largest when the previous access was recent, so memories used often
+// and lately resist eviction while forgotten ones stay flat. (must hold lock)
+func (hm *HypergraphMemory) reinforceAccess(mem *Memory, now time.Time) {
+	if hm.reinforcementRate <= 0 {
+		return
+	}
+
+	days := now.Sub(mem.AccessedAt).Hours() / 24
+	recency := 1.0 / (1.0 + math.Max(0, days))
+	mem.Importance = math.Min(maxImportance, mem.Importance+hm.reinforcementRate*recency)
+	hm.dirty = true
+}
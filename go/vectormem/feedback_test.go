@@ -0,0 +1,62 @@
+package vectormem
+
+import "testing"
+
+func TestRecordFeedbackMovesImportance(t *testing.T) {
+	hm := newTestMemory(t, nil)
+	useful := mustAdd(t, hm, EpisodicMemory, "the spare key is under the mat", nil)
+	unhelpful := mustAdd(t, hm, EpisodicMemory, "it rained on tuesday", nil)
+
+	if err := hm.RecordFeedback(useful.ID, true); err != nil {
+		t.Fatal(err)
+	}
+	if err := hm.RecordFeedback(unhelpful.ID, false); err != nil {
+		t.Fatal(err)
+	}
+
+	byID := make(map[string]MemoryView)
+	for _, v := range hm.Recent("", -1) {
+		byID[v.ID] = v
+	}
+	if got := byID[useful.ID]; got.Importance <= 1.0 || got.Useful != 1 || got.Unhelpful != 0 {
+		t.Errorf("useful memory = importance %v, useful %d, unhelpful %d", got.Importance, got.Useful, got.Unhelpful)
+	}
+	if got := byID[unhelpful.ID]; got.Importance >= 1.0 || got.Useful != 0 || got.Unhelpful != 1 {
+		t.Errorf("unhelpful memory = importance %v, useful %d, unhelpful %d", got.Importance, got.Useful, got.Unhelpful)
+	}
+}
+
+func TestRecordFeedbackIsBounded(t *testing.T) {
+	hm := newTestMemory(t, nil)
+	up := mustAdd(t, hm, EpisodicMemory, "always useful", nil)
+	down := mustAdd(t, hm, EpisodicMemory, "never useful", nil)
+
+	for i := 0; i < 100; i++ {
+		if err := hm.RecordFeedback(up.ID, true); err != nil {
+			t.Fatal(err)
+		}
+		if err := hm.RecordFeedback(down.ID, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, v := range hm.Recent("", -1) {
+		switch v.ID {
+		case up.ID:
+			if v.Importance != maxImportance || v.Useful != 100 {
+				t.Errorf("after 100 useful votes: importance %v, useful %d", v.Importance, v.Useful)
+			}
+		case down.ID:
+			if v.Importance != minImportance || v.Unhelpful != 100 {
+				t.Errorf("after 100 unhelpful votes: importance %v, unhelpful %d", v.Importance, v.Unhelpful)
+			}
+		}
+	}
+}
+
+func TestRecordFeedbackUnknownMemory(t *testing.T) {
+	hm := newTestMemory(t, nil)
+	if err := hm.RecordFeedback("missing", true); err == nil {
+		t.Error("RecordFeedback on a missing memory succeeded")
+	}
+}
@@ -0,0 +1,62 @@
+package vectormem
+
+import (
+	"math"
+	"time"
+)
+
+// memoryPair is an unordered pair of memory IDs
+type memoryPair [2]string
+
+func newMemoryPair(a, b string) memoryPair {
+	if a > b {
+		a, b = b, a
+	}
+	return memoryPair{a, b}
+}
+
+// coActivation tracks how often two memories are retrieved together
+type coActivation struct {
+	strength  float64
+	updatedAt time.Time
+}
+
+// reinforceCoActivation strengthens the bond between every pair of co-retrieved
+// memories and connects pairs whose strength crosses the threshold (must hold lock)
+func (hm *HypergraphMemory) reinforceCoActivation(results []*Memory) {
+	now := hm.clock.Now()
+	for i := 0; i < len(results); i++ {
+		for j := i + 1; j < len(results); j++ {
+			a, b := results[i], results[j]
+			key := newMemoryPair(a.ID, b.ID)
+
+			ca, ok := hm.coActivation[key]
+			if !ok {
+				ca = &coActivation{updatedAt: now}
+				hm.coActivation[key] = ca
+			}
+
+			// Older co-activations fade so only sustained patterns form connections
+			elapsed := now.Sub(ca.updatedAt).Hours()
+			ca.strength = ca.strength*math.Exp(-hm.coActivationDecay*elapsed) + 1.0
+			ca.updatedAt = now
+
+			if ca.strength >= hm.coActivationThreshold && !isConnected(a, b) {
+				a.Connections = append(a.Connections, b.ID)
+				b.Connections = append(b.Connections, a.ID)
+				hm.dirty = true
+				hm.logger.Debug("connected co-activated memories", "a", a.ID, "b", b.ID, "strength", ca.strength)
+			}
+		}
+	}
+}
+
+// isConnected reports whether a already lists b as a connection
+func isConnected(a, b *Memory) bool {
+	for _, id := range a.Connections {
+		if id == b.ID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,98 @@
+package vectormem
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// newHebbianMemory returns a memory with two memories that a "kettle" query
+// retrieves together but that are too dissimilar to connect on insert, and a
+// third that the query never returns
+func newHebbianMemory(t *testing.T, clock *fakeClock) (hm *HypergraphMemory, a, b, c MemoryView) {
+	hm = newTestMemory(t, func(cfg *HypergraphConfig) {
+		cfg.Clock = clock
+		cfg.HebbianLearning = true
+		cfg.EmbeddingFunc = tableEmbedding(map[string][]float32{
+			"kettle":                {1, 1, 0},
+			"the kettle is on":      {1, 0, 0},
+			"the kettle whistles":   {0, 1, 0},
+			"a walk by the harbour": {0, 0, 1},
+		})
+	})
+	a = mustAdd(t, hm, EpisodicMemory, "the kettle is on", nil)
+	b = mustAdd(t, hm, EpisodicMemory, "the kettle whistles", nil)
+	c = mustAdd(t, hm, EpisodicMemory, "a walk by the harbour", nil)
+	return hm, a, b, c
+}
+
+func connectedIDs(t *testing.T, hm *HypergraphMemory, id string) []string {
+	t.Helper()
+	connected, err := hm.GetConnected(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids := make([]string, len(connected))
+	for i, v := range connected {
+		ids[i] = v.ID
+	}
+	return ids
+}
+
+func queryTimes(t *testing.T, hm *HypergraphMemory, query string, n int) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		if _, err := hm.Query(context.Background(), query, EpisodicMemory, 2); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestRepeatedCoRetrievalConnects(t *testing.T) {
+	hm, a, b, c := newHebbianMemory(t, newFakeClock())
+
+	queryTimes(t, hm, "kettle", 2)
+	if ids := connectedIDs(t, hm, a.ID); len(ids) != 0 {
+		t.Fatalf("connected after two queries: %v", ids)
+	}
+
+	// The default threshold of 3 is reached on the third co-retrieval
+	queryTimes(t, hm, "kettle", 1)
+	if ids := connectedIDs(t, hm, a.ID); len(ids) != 1 || ids[0] != b.ID {
+		t.Errorf("connections of %s = %v, want [%s]", a.ID, ids, b.ID)
+	}
+	if ids := connectedIDs(t, hm, c.ID); len(ids) != 0 {
+		t.Errorf("memory never retrieved gained connections %v", ids)
+	}
+
+	// Further co-retrieval does not add duplicate connections
+	queryTimes(t, hm, "kettle", 3)
+	if ids := connectedIDs(t, hm, b.ID); len(ids) != 1 {
+		t.Errorf("connections of %s = %v, want one", b.ID, ids)
+	}
+}
+
+func TestCoActivationDecays(t *testing.T) {
+	clock := newFakeClock()
+	hm, a, _, _ := newHebbianMemory(t, clock)
+
+	queryTimes(t, hm, "kettle", 2)
+	clock.Advance(24 * time.Hour)
+	queryTimes(t, hm, "kettle", 1)
+	if ids := connectedIDs(t, hm, a.ID); len(ids) != 0 {
+		t.Errorf("connected after co-activation had a day to fade: %v", ids)
+	}
+}
+
+func TestHebbianLearningDisabled(t *testing.T) {
+	hm := newTestMemory(t, func(cfg *HypergraphConfig) {
+		cfg.EmbeddingFunc = wordEmbedding("kettle", "on", "whistles")
+	})
+	a := mustAdd(t, hm, EpisodicMemory, "kettle on", nil)
+	mustAdd(t, hm, EpisodicMemory, "kettle whistles", nil)
+
+	queryTimes(t, hm, "kettle", 10)
+	if ids := connectedIDs(t, hm, a.ID); len(ids) != 0 {
+		t.Errorf("connections without HebbianLearning = %v, want none", ids)
+	}
+}
@@ -0,0 +1,128 @@
+package vectormem
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock is a Clock that only moves when a test advances it
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2026, 3, 14, 9, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// tableEmbedding embeds text by looking it up in a fixed table, so tests
+// control similarities exactly. Unknown text is an error.
+func tableEmbedding(table map[string][]float32) EmbeddingFunc {
+	return func(ctx context.Context, text string) ([]float32, error) {
+		vec, ok := table[text]
+		if !ok {
+			return nil, fmt.Errorf("no embedding for %q", text)
+		}
+		return append([]float32(nil), vec...), nil
+	}
+}
+
+// wordEmbedding embeds text as word counts over a fixed vocabulary
+func wordEmbedding(vocab ...string) EmbeddingFunc {
+	return func(ctx context.Context, text string) ([]float32, error) {
+		vec := make([]float32, len(vocab))
+		for _, word := range strings.Fields(strings.ToLower(text)) {
+			for i, v := range vocab {
+				if word == v {
+					vec[i]++
+				}
+			}
+		}
+		return vec, nil
+	}
+}
+
+// newTestMemory creates a memory from the default config as adjusted by
+// configure, stopping it when the test ends
+func newTestMemory(t *testing.T, configure func(*HypergraphConfig)) *HypergraphMemory {
+	t.Helper()
+	config := DefaultConfig()
+	if configure != nil {
+		configure(config)
+	}
+	hm, err := NewHypergraphMemory(config)
+	if err != nil {
+		t.Fatalf("NewHypergraphMemory: %v", err)
+	}
+	t.Cleanup(hm.Stop)
+	return hm
+}
+
+// mustAdd adds a memory, failing the test on error
+func mustAdd(t *testing.T, hm *HypergraphMemory, memType MemoryType, content string, metadata map[string]interface{}) MemoryView {
+	t.Helper()
+	mem, err := hm.Add(context.Background(), memType, content, metadata)
+	if err != nil {
+		t.Fatalf("Add(%q): %v", content, err)
+	}
+	return mem
+}
+
+// contents lists the content of each view in order
+func contents(views []MemoryView) []string {
+	out := make([]string, len(views))
+	for i, v := range views {
+		out[i] = v.Content
+	}
+	return out
+}
+
+// hasContent reports whether any view has the given content
+func hasContent(views []MemoryView, content string) bool {
+	for _, v := range views {
+		if v.Content == content {
+			return true
+		}
+	}
+	return false
+}
+
+// memoryStore is a persist.Store held in memory
+type memoryStore struct {
+	data   map[string][]byte
+	writes int
+}
+
+func (s *memoryStore) Read(key string) ([]byte, error) {
+	data, ok := s.data[key]
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+	return append([]byte(nil), data...), nil
+}
+
+func (s *memoryStore) Write(key string, data []byte) error {
+	if s.data == nil {
+		s.data = make(map[string][]byte)
+	}
+	s.data[key] = append([]byte(nil), data...)
+	s.writes++
+	return nil
+}
@@ -5,14 +5,18 @@ package vectormem
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
 	"os"
-	"path/filepath"
 	"sort"
+	"strings"
 	"sync"
 	"time"
+	"unicode"
+
+	"github.com/o9nn/un9n/go/events"
+	"github.com/o9nn/un9n/go/persist"
 )
 
 // MemoryType represents the type of memory being stored
@@ -37,13 +41,24 @@ type Memory struct {
 	Type        MemoryType             `json:"type"`
 	Content     string                 `json:"content"`
 	Embedding   []float32              `json:"embedding,omitempty"`
+	Quantized   *QuantizedEmbedding    `json:"quantized,omitempty"` // Replaces Embedding when quantization is enabled
 	Metadata    map[string]interface{} `json:"metadata"`
 	Connections []string               `json:"connections"` // IDs of connected memories
 	CreatedAt   time.Time              `json:"created_at"`
 	AccessedAt  time.Time              `json:"accessed_at"`
 	AccessCount int                    `json:"access_count"`
 	Importance  float64                `json:"importance"`
-	Decay       float64                `json:"decay"` // Memory decay factor
+	Decay       float64                `json:"decay"`                // Memory decay factor
+	ExpiresAt   *time.Time             `json:"expires_at,omitempty"` // Optional hard expiry
+	Useful      int                    `json:"useful,omitempty"`     // Times reported useful
+	Unhelpful   int                    `json:"unhelpful,omitempty"`  // Times reported unhelpful
+	Source      string                 `json:"source,omitempty"`     // Where the memory came from, e.g. "chat" or "docs"
+	SourceRef   string                 `json:"source_ref,omitempty"` // Locator within the source, e.g. a URL or message ID
+}
+
+// expired reports whether the memory has passed its expiry time
+func (m *Memory) expired(now time.Time) bool {
+	return m.ExpiresAt != nil && !now.Before(*m.ExpiresAt)
 }
 
 // HypergraphMemory implements a hypergraph-based memory system with vector search
@@ -53,17 +68,62 @@ type HypergraphMemory struct {
 	collections map[MemoryType][]*Memory
 	embedFunc   EmbeddingFunc
 	persistPath string
+	format      persist.Format
+	compress    bool
 	dirty       bool
+	logger      Logger
 
 	// Configuration
+	config          HypergraphConfig
 	maxMemories     int
 	decayRate       float64
 	consolidateFreq time.Duration
+	reapInterval    time.Duration
+	dedupThreshold  float64
+	distanceMetric  DistanceMetric
+	embeddingDim    int
+
+	// Hebbian co-activation learning
+	hebbianLearning       bool
+	coActivationThreshold float64
+	coActivationDecay     float64
+	coActivation          map[memoryPair]*coActivation
+
+	// Bulk embedding
+	batchEmbedFunc BatchEmbeddingFunc
+
+	bus *events.Bus
+
+	reinforcementRate float64
+
+	metaIndex metadataIndex
+
+	quantize bool
+
+	// Background operation
+	started   bool
+	stopChan  chan struct{}
+	stopOnce  sync.Once
+	closeOnce sync.Once
 
 	// Metrics
 	totalQueries    int64
 	totalInserts    int64
 	avgQueryLatency time.Duration
+	queryLatencies  *latencyHistogram
+
+	// Deterministic mode
+	clock    Clock
+	idSeq    uint64
+	idPrefix string // Set by ShardedMemory so shards never mint the same ID
+
+	store persist.Store
+
+	// Consolidation triggering
+	consolidationPolicy ConsolidationPolicy
+	consolidateSignal   chan struct{}
+
+	queryLog *queryLog
 }
 
 // EmbeddingFunc is a function that creates embeddings from text
@@ -72,20 +132,78 @@ type EmbeddingFunc func(ctx context.Context, text string) ([]float32, error)
 // HypergraphConfig holds configuration for the hypergraph memory
 type HypergraphConfig struct {
 	PersistPath     string
+	Format          persist.Format // Encoding used by Save; Load detects it automatically
+	Compress        bool           // Gzip saved state; Load detects it automatically
 	MaxMemories     int
 	DecayRate       float64
 	ConsolidateFreq time.Duration
+	ReapInterval    time.Duration  // How often the reaper removes expired memories
+	DedupThreshold  float64        // Similarity above which Add merges into an existing memory (0 disables)
+	DistanceMetric  DistanceMetric // Similarity metric for embeddings (defaults to cosine)
+	EmbeddingDim    int            // Expected embedding length; 0 adopts the first embedding's length
 	EmbeddingFunc   EmbeddingFunc
+	Logger          Logger // Optional; defaults to a no-op logger
+
+	// HebbianLearning connects memories that are repeatedly returned together by Query
+	HebbianLearning bool
+	// CoActivationThreshold is the co-activation strength at which a connection forms
+	CoActivationThreshold float64
+	// CoActivationDecay is the per-hour exponential decay of co-activation strength
+	CoActivationDecay float64
+
+	// BatchEmbeddingFunc, if set, embeds many texts in one call on bulk paths
+	// such as ImportJSONL and ReembedAll instead of calling EmbeddingFunc per item
+	BatchEmbeddingFunc BatchEmbeddingFunc
+
+	// Events, if set, receives a KindMemoryInsert event for each new memory
+	Events *events.Bus
+
+	// ReinforcementRate is how much importance a memory gains each time a query
+	// returns it, scaled down the longer it went unaccessed (0, the default,
+	// disables reinforcement; 0.05 is a reasonable rate to opt in with)
+	ReinforcementRate float64
+
+	// IndexedMetadataKeys lists metadata keys kept in an inverted index so
+	// queries filtering on them avoid scanning every memory
+	IndexedMetadataKeys []string
+
+	// QuantizeEmbeddings stores embeddings as int8 with a per-vector scale,
+	// cutting their memory use roughly fourfold at a small cost in recall
+	QuantizeEmbeddings bool
+
+	// Clock provides the current time for timestamps, IDs, decay, and expiry
+	// (defaults to the system clock). A fixed clock makes IDs and ordering
+	// reproducible across runs.
+	Clock Clock
+
+	// Store holds saved memories under the key PersistPath (defaults to the local filesystem)
+	Store persist.Store
+
+	// ConsolidationPolicy selects whether exceeding MaxMemories consolidates
+	// during the Add, in a background goroutine, or only on ConsolidateNow
+	// (defaults to ConsolidateEager). The background goroutine runs until Stop
+	// or Close.
+	ConsolidationPolicy ConsolidationPolicy
+
+	// QueryLogSize keeps the latest queries, with the IDs and scores they
+	// returned, for RecentQueries (0 disables the log)
+	QueryLogSize int
 }
 
 // DefaultConfig returns a default configuration
 func DefaultConfig() *HypergraphConfig {
 	return &HypergraphConfig{
 		PersistPath:     "",
+		Format:          persist.FormatJSON,
 		MaxMemories:     10000,
 		DecayRate:       0.01,
 		ConsolidateFreq: 1 * time.Hour,
+		ReapInterval:    1 * time.Minute,
+		DistanceMetric:  MetricCosine,
 		EmbeddingFunc:   nil,
+
+		CoActivationThreshold: 3.0,
+		CoActivationDecay:     0.1,
 	}
 }
 
@@ -98,11 +216,82 @@ func NewHypergraphMemory(config *HypergraphConfig) (*HypergraphMemory, error) {
 	hm := &HypergraphMemory{
 		memories:        make(map[string]*Memory),
 		collections:     make(map[MemoryType][]*Memory),
+		config:          *config,
 		embedFunc:       config.EmbeddingFunc,
 		persistPath:     config.PersistPath,
+		format:          config.Format,
+		compress:        config.Compress,
 		maxMemories:     config.MaxMemories,
 		decayRate:       config.DecayRate,
 		consolidateFreq: config.ConsolidateFreq,
+		reapInterval:    config.ReapInterval,
+		dedupThreshold:  config.DedupThreshold,
+		distanceMetric:  config.DistanceMetric,
+		embeddingDim:    config.EmbeddingDim,
+		stopChan:        make(chan struct{}),
+		logger:          config.Logger,
+
+		hebbianLearning:       config.HebbianLearning,
+		coActivationThreshold: config.CoActivationThreshold,
+		coActivationDecay:     config.CoActivationDecay,
+		coActivation:          make(map[memoryPair]*coActivation),
+
+		batchEmbedFunc: config.BatchEmbeddingFunc,
+		bus:            config.Events,
+
+		reinforcementRate: config.ReinforcementRate,
+
+		quantize: config.QuantizeEmbeddings,
+
+		queryLatencies: newLatencyHistogram(),
+
+		clock: config.Clock,
+		store: config.Store,
+
+		consolidationPolicy: config.ConsolidationPolicy,
+	}
+
+	if len(config.IndexedMetadataKeys) > 0 {
+		hm.metaIndex = make(metadataIndex, len(config.IndexedMetadataKeys))
+		for _, key := range config.IndexedMetadataKeys {
+			hm.metaIndex[key] = make(map[string]map[string]*Memory)
+		}
+	}
+
+	if hm.logger == nil {
+		hm.logger = nopLogger{}
+	}
+
+	if hm.distanceMetric == "" {
+		hm.distanceMetric = MetricCosine
+	}
+	if !hm.distanceMetric.valid() {
+		return nil, fmt.Errorf("unknown distance metric: %s", hm.distanceMetric)
+	}
+
+	if hm.reapInterval <= 0 {
+		hm.reapInterval = 1 * time.Minute
+	}
+
+	if hm.clock == nil {
+		hm.clock = realClock{}
+	}
+	if hm.store == nil {
+		hm.store = persist.FileStore{}
+	}
+
+	if hm.consolidationPolicy == "" {
+		hm.consolidationPolicy = ConsolidateEager
+	}
+	if !hm.consolidationPolicy.valid() {
+		return nil, fmt.Errorf("unknown consolidation policy: %s", hm.consolidationPolicy)
+	}
+
+	if config.QueryLogSize < 0 {
+		return nil, fmt.Errorf("invalid query log size: %d", config.QueryLogSize)
+	}
+	if config.QueryLogSize > 0 {
+		hm.queryLog = newQueryLog(config.QueryLogSize)
 	}
 
 	// Initialize collections
@@ -112,21 +301,60 @@ func NewHypergraphMemory(config *HypergraphConfig) (*HypergraphMemory, error) {
 
 	// Load from persistence if path specified
 	if config.PersistPath != "" {
-		if err := hm.Load(); err != nil && !os.IsNotExist(err) {
+		if err := hm.Load(); err != nil && !errors.Is(err, os.ErrNotExist) {
 			return nil, fmt.Errorf("failed to load memories: %w", err)
 		}
 	}
 
+	if hm.consolidationPolicy == ConsolidateBackground {
+		hm.consolidateSignal = make(chan struct{}, 1)
+		go hm.consolidationLoop()
+	}
+
 	return hm, nil
 }
 
-// Add adds a new memory to the hypergraph
-func (hm *HypergraphMemory) Add(ctx context.Context, memType MemoryType, content string, metadata map[string]interface{}) (*Memory, error) {
+// Add adds a new memory to the hypergraph and returns a view of it. When the
+// memory merges into a near-duplicate, the view is of that existing memory.
+func (hm *HypergraphMemory) Add(ctx context.Context, memType MemoryType, content string, metadata map[string]interface{}) (MemoryView, error) {
+	return hm.add(ctx, memType, content, metadata, nil, provenance{})
+}
+
+// AddWithTTL adds a memory that expires after ttl regardless of its importance
+func (hm *HypergraphMemory) AddWithTTL(ctx context.Context, memType MemoryType, content string, metadata map[string]interface{}, ttl time.Duration) (MemoryView, error) {
+	expiresAt := hm.clock.Now().Add(ttl)
+	return hm.add(ctx, memType, content, metadata, &expiresAt, provenance{})
+}
+
+// newID returns an unused memory ID built from the clock and a sequence
+// number, so a fixed clock yields the same IDs on every run (must hold lock)
+func (hm *HypergraphMemory) newID(memType MemoryType) string {
+	for {
+		hm.idSeq++
+		id := fmt.Sprintf("%s%s_%d_%d", hm.idPrefix, memType, hm.clock.Now().UnixNano(), hm.idSeq)
+		if _, ok := hm.memories[id]; !ok {
+			return id
+		}
+	}
+}
+
+// add inserts a memory with an optional expiry time and returns a view of it
+func (hm *HypergraphMemory) add(ctx context.Context, memType MemoryType, content string, metadata map[string]interface{}, expiresAt *time.Time, prov provenance) (MemoryView, error) {
 	hm.mu.Lock()
 	defer hm.mu.Unlock()
 
+	mem, err := hm.insert(ctx, memType, content, metadata, expiresAt, prov)
+	if err != nil {
+		return MemoryView{}, err
+	}
+	return mem.view(), nil
+}
+
+// insert embeds and stores a memory, merging it into a near-duplicate if one
+// exists (must hold lock)
+func (hm *HypergraphMemory) insert(ctx context.Context, memType MemoryType, content string, metadata map[string]interface{}, expiresAt *time.Time, prov provenance) (*Memory, error) {
 	// Generate ID
-	id := fmt.Sprintf("%s_%d_%d", memType, time.Now().UnixNano(), len(hm.memories))
+	id := hm.newID(memType)
 
 	// Create embedding if function available
 	var embedding []float32
@@ -134,8 +362,18 @@ func (hm *HypergraphMemory) Add(ctx context.Context, memType MemoryType, content
 		var err error
 		embedding, err = hm.embedFunc(ctx, content)
 		if err != nil {
+			hm.logger.Error("failed to create embedding", "type", memType, "error", err)
 			return nil, fmt.Errorf("failed to create embedding: %w", err)
 		}
+		if err := hm.checkEmbeddingDim(embedding); err != nil {
+			return nil, err
+		}
+	}
+
+	// Merge into a near-identical memory instead of inserting a duplicate
+	if existing := hm.findDuplicate(memType, embedding); existing != nil {
+		hm.mergeDuplicate(existing, metadata, expiresAt, prov)
+		return existing, nil
 	}
 
 	// Create memory
@@ -143,108 +381,436 @@ func (hm *HypergraphMemory) Add(ctx context.Context, memType MemoryType, content
 		ID:          id,
 		Type:        memType,
 		Content:     content,
-		Embedding:   embedding,
 		Metadata:    metadata,
 		Connections: make([]string, 0),
-		CreatedAt:   time.Now(),
-		AccessedAt:  time.Now(),
+		CreatedAt:   hm.clock.Now(),
+		AccessedAt:  hm.clock.Now(),
 		AccessCount: 0,
 		Importance:  1.0,
 		Decay:       1.0,
+		ExpiresAt:   expiresAt,
+		Source:      prov.source,
+		SourceRef:   prov.sourceRef,
 	}
+	hm.setEmbedding(mem, embedding)
 
 	// Store memory
 	hm.memories[id] = mem
 	hm.collections[memType] = append(hm.collections[memType], mem)
+	hm.indexMemory(mem)
 	hm.dirty = true
 	hm.totalInserts++
 
+	hm.bus.Publish(events.Event{
+		Kind:      events.KindMemoryInsert,
+		Source:    "vectormem",
+		Timestamp: mem.CreatedAt,
+		Data:      map[string]interface{}{"id": id, "type": string(memType)},
+	})
+
 	// Auto-connect to similar memories
 	if embedding != nil {
 		hm.autoConnect(ctx, mem)
 	}
 
 	// Check if consolidation needed
-	if len(hm.memories) > hm.maxMemories {
-		hm.consolidate()
-	}
+	hm.checkCapacity()
 
 	return mem, nil
 }
 
-// Query searches for similar memories using vector similarity
-func (hm *HypergraphMemory) Query(ctx context.Context, query string, memType MemoryType, limit int) ([]*Memory, error) {
-	hm.mu.RLock()
-	defer hm.mu.RUnlock()
+// findDuplicate returns the most similar same-type memory above the dedup threshold
+func (hm *HypergraphMemory) findDuplicate(memType MemoryType, embedding []float32) *Memory {
+	if hm.dedupThreshold <= 0 || embedding == nil {
+		return nil
+	}
+
+	var best *Memory
+	bestSim := hm.dedupThreshold
+	now := hm.clock.Now()
+	for _, mem := range hm.collections[memType] {
+		if !mem.hasEmbedding() || mem.expired(now) {
+			continue
+		}
+		if sim := hm.similarityTo(embedding, mem); sim >= bestSim {
+			best = mem
+			bestSim = sim
+		}
+	}
+
+	return best
+}
+
+// mergeDuplicate reinforces an existing memory with a re-insertion of itself
+func (hm *HypergraphMemory) mergeDuplicate(mem *Memory, metadata map[string]interface{}, expiresAt *time.Time, prov provenance) {
+	mem.Importance = math.Max(minImportance, math.Min(maxImportance, mem.Importance+0.1))
+	mem.AccessCount++
+	mem.AccessedAt = hm.clock.Now()
+
+	if len(metadata) > 0 {
+		hm.unindexMemory(mem)
+		if mem.Metadata == nil {
+			mem.Metadata = make(map[string]interface{}, len(metadata))
+		}
+		for k, v := range metadata {
+			mem.Metadata[k] = v
+		}
+		hm.indexMemory(mem)
+	}
+
+	// Keep whichever lifetime is longer; nil means the memory never expires
+	if mem.ExpiresAt != nil && (expiresAt == nil || expiresAt.After(*mem.ExpiresAt)) {
+		mem.ExpiresAt = expiresAt
+	}
+
+	// The first source to contribute a memory keeps credit for it
+	if mem.Source == "" {
+		mem.Source, mem.SourceRef = prov.source, prov.sourceRef
+	}
+
+	hm.dirty = true
+	hm.logger.Debug("merged duplicate memory", "id", mem.ID)
+}
+
+// QueryOptions refines how Query selects and ranks memories
+type QueryOptions struct {
+	// Type restricts the search to one memory type; empty searches all types
+	Type MemoryType
+	// Limit is the maximum number of results
+	Limit int
+	// MinScore drops results scoring below it, so an off-topic query can
+	// return fewer results than Limit, or none at all
+	MinScore float64
+	// Reranker, if set, reorders the scored results before they are truncated
+	// to Limit. It runs under the memory's lock, so it must not call back into
+	// the HypergraphMemory or modify the memories.
+	Reranker func(query string, results []ScoredResult) []ScoredResult
+	// Diverse selects results by Maximal Marginal Relevance, trading relevance
+	// against similarity to results already selected so near-duplicates don't
+	// crowd out other topics
+	Diverse bool
+	// DiversityLambda balances MMR from 0 (pure diversity) to 1 (pure relevance)
+	DiversityLambda float64
+	// Metadata restricts results to memories whose metadata holds every given
+	// key with an equal scalar value (string, bool, or number)
+	Metadata map[string]interface{}
+	// CreatedAfter, if set, restricts results to memories created at or after it
+	CreatedAfter time.Time
+	// CreatedBefore, if set, restricts results to memories created before it
+	CreatedBefore time.Time
+}
+
+// ScoredResult pairs a memory with its query score
+type ScoredResult struct {
+	Memory *Memory
+	Score  float64
+}
+
+// Query searches for similar memories using vector similarity.
+//
+// Each memory receives a base relevance in [0, 1]: embedding similarity when both
+// the query and the memory have embeddings, otherwise a text-overlap score. Both
+// paths share the same range, so memories scored either way rank comparably. The
+// base relevance is then multiplied by the memory's decay and importance.
+//
+// Query updates access statistics on the returned memories, so it takes the
+// write lock. Results are read-only views as of the query.
+func (hm *HypergraphMemory) Query(ctx context.Context, query string, memType MemoryType, limit int) ([]MemoryView, error) {
+	return hm.QueryWithOptions(ctx, query, QueryOptions{Type: memType, Limit: limit})
+}
+
+// QueryWithOptions searches for similar memories like Query, with additional filtering
+func (hm *HypergraphMemory) QueryWithOptions(ctx context.Context, query string, opts QueryOptions) ([]MemoryView, error) {
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
 
 	start := time.Now()
-	defer func() {
-		hm.totalQueries++
-		hm.avgQueryLatency = time.Duration((int64(hm.avgQueryLatency)*hm.totalQueries + int64(time.Since(start))) / (hm.totalQueries + 1))
-	}()
+	defer hm.recordQueryLatency(start)
 
 	// Get query embedding
-	var queryEmbedding []float32
-	if hm.embedFunc != nil {
-		var err error
-		queryEmbedding, err = hm.embedFunc(ctx, query)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create query embedding: %w", err)
+	queryEmbedding, err := hm.embedQuery(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return views(hm.runQuery(queryEmbedding, query, opts)), nil
+}
+
+// runQuery scores, ranks, and truncates results for an embedded query and
+// updates access statistics on the returned memories (must hold lock)
+func (hm *HypergraphMemory) runQuery(queryEmbedding []float32, query string, opts QueryOptions) []*Memory {
+	ranked := hm.rankQuery(queryEmbedding, query, opts)
+
+	results := make([]*Memory, len(ranked))
+	for i, r := range ranked {
+		results[i] = r.Memory
+	}
+	hm.markRetrieved(results)
+	hm.logQuery(query, ranked)
+
+	return results
+}
+
+// rankQuery scores, ranks, and truncates results for an embedded query without
+// changing any memory (must hold lock)
+func (hm *HypergraphMemory) rankQuery(queryEmbedding []float32, query string, opts QueryOptions) []ScoredResult {
+	scored := hm.scoreMemories(queryEmbedding, query, opts)
+	if opts.Reranker != nil {
+		scored = opts.Reranker(query, scored)
+	}
+	if opts.Diverse {
+		scored = hm.selectDiverse(scored, opts.Limit, opts.DiversityLambda)
+	}
+
+	// Return top results
+	limit := opts.Limit
+	if limit > len(scored) {
+		limit = len(scored)
+	}
+	return scored[:limit]
+}
+
+// Peek ranks memories exactly as QueryWithOptions does but leaves the memory
+// untouched: access statistics, reinforcement, Hebbian weights, the query log,
+// and query metrics are not updated. Use it to look up memories on behalf of
+// a caller rather than to recall them.
+func (hm *HypergraphMemory) Peek(ctx context.Context, query string, opts QueryOptions) ([]MemoryView, error) {
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+
+	queryEmbedding, err := hm.embedQuery(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	ranked := hm.rankQuery(queryEmbedding, query, opts)
+	results := make([]MemoryView, len(ranked))
+	for i, r := range ranked {
+		results[i] = r.Memory.view()
+	}
+	return results, nil
+}
+
+// markRetrieved updates access statistics on memories returned by a query and
+// reinforces their co-activation (must hold lock)
+func (hm *HypergraphMemory) markRetrieved(results []*Memory) {
+	now := hm.clock.Now()
+	for _, mem := range results {
+		hm.reinforceAccess(mem, now)
+		mem.AccessedAt = now
+		mem.AccessCount++
+	}
+
+	if hm.hebbianLearning {
+		hm.reinforceCoActivation(results)
+	}
+}
+
+// recordQueryLatency folds a query's duration into the running average and
+// the latency histogram (must hold lock)
+func (hm *HypergraphMemory) recordQueryLatency(start time.Time) {
+	elapsed := time.Since(start)
+	hm.avgQueryLatency = time.Duration((int64(hm.avgQueryLatency)*hm.totalQueries + int64(elapsed)) / (hm.totalQueries + 1))
+	hm.totalQueries++
+	hm.queryLatencies.observe(elapsed)
+}
+
+// BatchQuery runs several queries at once, returning one result list per query.
+// Queries are embedded together, in a single call when a batch embedding function
+// is configured, and then answered in order under one lock, so each result list
+// matches what Query would have returned had the queries been issued one by one.
+func (hm *HypergraphMemory) BatchQuery(ctx context.Context, queries []string, memType MemoryType, limit int) ([][]MemoryView, error) {
+	embeddings, err := hm.embedBatch(ctx, queries)
+	if err != nil {
+		return nil, err
+	}
+
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+
+	opts := QueryOptions{Type: memType, Limit: limit}
+	results := make([][]MemoryView, len(queries))
+	for i, query := range queries {
+		start := time.Now()
+
+		var queryEmbedding []float32
+		if embeddings != nil {
+			queryEmbedding = embeddings[i]
+			if err := hm.checkEmbeddingDim(queryEmbedding); err != nil {
+				return nil, err
+			}
 		}
+
+		results[i] = views(hm.runQuery(queryEmbedding, query, opts))
+		hm.recordQueryLatency(start)
 	}
 
-	// Get collection to search
+	return results, nil
+}
+
+// embedQuery embeds query text, returning nil when no embedding function is configured
+func (hm *HypergraphMemory) embedQuery(ctx context.Context, query string) ([]float32, error) {
+	if hm.embedFunc == nil {
+		return nil, nil
+	}
+
+	queryEmbedding, err := hm.embedFunc(ctx, query)
+	if err != nil {
+		hm.logger.Error("failed to create query embedding", "error", err)
+		return nil, fmt.Errorf("failed to create query embedding: %w", err)
+	}
+	if err := hm.checkEmbeddingDim(queryEmbedding); err != nil {
+		return nil, err
+	}
+	return queryEmbedding, nil
+}
+
+// scoreMemories scores every candidate memory against a query and returns those
+// passing the options' filters, best first (must hold lock)
+func (hm *HypergraphMemory) scoreMemories(queryEmbedding []float32, query string, opts QueryOptions) []ScoredResult {
+	// Get collection to search, narrowed by the metadata index when possible
 	var searchCollection []*Memory
-	if memType == "" {
+	if candidates, ok := hm.indexedCandidates(opts.Metadata); ok {
+		searchCollection = candidates
+	} else if opts.Type == "" {
 		// Search all collections
 		for _, col := range hm.collections {
 			searchCollection = append(searchCollection, col...)
 		}
 	} else {
-		searchCollection = hm.collections[memType]
+		searchCollection = hm.collections[opts.Type]
 	}
 
 	// Calculate similarities
-	type scoredMemory struct {
-		memory *Memory
-		score  float64
-	}
-	scored := make([]scoredMemory, 0, len(searchCollection))
+	scored := make([]ScoredResult, 0, len(searchCollection))
 
+	now := hm.clock.Now()
 	for _, mem := range searchCollection {
-		var score float64
-		if queryEmbedding != nil && mem.Embedding != nil {
-			score = cosineSimilarity(queryEmbedding, mem.Embedding)
-		} else {
-			// Fallback to simple text matching
-			score = textSimilarity(query, mem.Content)
+		// Skip expired memories the reaper has not removed yet
+		if mem.expired(now) {
+			continue
+		}
+		if opts.Type != "" && mem.Type != opts.Type {
+			continue
+		}
+		if len(opts.Metadata) > 0 && !matchesMetadata(mem, opts.Metadata) {
+			continue
+		}
+		if !opts.CreatedAfter.IsZero() && mem.CreatedAt.Before(opts.CreatedAfter) {
+			continue
+		}
+		if !opts.CreatedBefore.IsZero() && !mem.CreatedAt.Before(opts.CreatedBefore) {
+			continue
 		}
 
+		score := hm.relevance(queryEmbedding, query, mem)
+
 		// Apply decay and importance
 		score *= mem.Decay * mem.Importance
 
-		scored = append(scored, scoredMemory{memory: mem, score: score})
+		if score < opts.MinScore {
+			continue
+		}
+
+		scored = append(scored, ScoredResult{Memory: mem, Score: score})
 	}
 
-	// Sort by score
+	// Sort by score, breaking ties by ID so results don't depend on scan order
 	sort.Slice(scored, func(i, j int) bool {
-		return scored[i].score > scored[j].score
+		if scored[i].Score != scored[j].Score {
+			return scored[i].Score > scored[j].Score
+		}
+		return scored[i].Memory.ID < scored[j].Memory.ID
 	})
 
-	// Return top results
-	if limit > len(scored) {
-		limit = len(scored)
+	return scored
+}
+
+// Start begins background maintenance such as reaping expired memories.
+// It returns an error if called more than once.
+func (hm *HypergraphMemory) Start(ctx context.Context) error {
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+
+	if hm.started {
+		return fmt.Errorf("hypergraph memory already started")
+	}
+	hm.started = true
+
+	go hm.reaper(ctx)
+	return nil
+}
+
+// Stop stops background maintenance
+func (hm *HypergraphMemory) Stop() {
+	hm.stopOnce.Do(func() {
+		close(hm.stopChan)
+	})
+}
+
+// Close stops background maintenance and saves any unsaved changes.
+// It is safe to call more than once; later calls do nothing.
+func (hm *HypergraphMemory) Close() error {
+	var err error
+	hm.closeOnce.Do(func() {
+		hm.Stop()
+
+		hm.mu.RLock()
+		dirty := hm.dirty
+		hm.mu.RUnlock()
+
+		if dirty {
+			err = hm.Save()
+		}
+	})
+	return err
+}
+
+// reaper periodically removes expired memories
+func (hm *HypergraphMemory) reaper(ctx context.Context) {
+	ticker := time.NewTicker(hm.reapInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hm.stopChan:
+			return
+		case <-ticker.C:
+			hm.RemoveExpired()
+		}
+	}
+}
+
+// RemoveExpired removes all expired memories and returns how many were removed
+func (hm *HypergraphMemory) RemoveExpired() int {
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+
+	now := hm.clock.Now()
+	expired := make([]string, 0)
+	for id, mem := range hm.memories {
+		if mem.expired(now) {
+			expired = append(expired, id)
+		}
 	}
 
-	results := make([]*Memory, limit)
-	for i := 0; i < limit; i++ {
-		results[i] = scored[i].memory
-		// Update access stats
-		results[i].AccessedAt = time.Now()
-		results[i].AccessCount++
+	for _, id := range expired {
+		hm.removeMemory(id)
+		hm.logger.Debug("reaped expired memory", "id", id)
 	}
 
-	return results, nil
+	return len(expired)
+}
+
+// relevance returns the base relevance of a memory to a query, from 0 to 1
+func (hm *HypergraphMemory) relevance(queryEmbedding []float32, query string, mem *Memory) float64 {
+	if queryEmbedding != nil && mem.hasEmbedding() {
+		return hm.normalizeSimilarity(hm.similarityTo(queryEmbedding, mem))
+	}
+	// Fallback to simple text matching
+	return textRelevance(query, mem.Content)
 }
 
 // Connect creates a hyperedge between memories
@@ -267,8 +833,49 @@ func (hm *HypergraphMemory) Connect(id1, id2 string) error {
 	return nil
 }
 
-// GetConnected returns all memories connected to the given memory
-func (hm *HypergraphMemory) GetConnected(id string) ([]*Memory, error) {
+// Recent returns views of the n most recently created memories of a type
+// (or of all types when memType is empty), newest first
+func (hm *HypergraphMemory) Recent(memType MemoryType, n int) []MemoryView {
+	hm.mu.RLock()
+	defer hm.mu.RUnlock()
+
+	now := hm.clock.Now()
+	candidates := make([]*Memory, 0)
+	for _, mem := range hm.memories {
+		if (memType == "" || mem.Type == memType) && !mem.expired(now) {
+			candidates = append(candidates, mem)
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if !candidates[i].CreatedAt.Equal(candidates[j].CreatedAt) {
+			return candidates[i].CreatedAt.After(candidates[j].CreatedAt)
+		}
+		return candidates[i].ID < candidates[j].ID
+	})
+
+	if n >= 0 && n < len(candidates) {
+		candidates = candidates[:n]
+	}
+
+	return views(candidates)
+}
+
+// Get returns a view of the memory with the given ID. Expired memories the
+// reaper has not removed yet are reported as not found.
+func (hm *HypergraphMemory) Get(id string) (MemoryView, error) {
+	hm.mu.RLock()
+	defer hm.mu.RUnlock()
+
+	mem, ok := hm.memories[id]
+	if !ok || mem.expired(hm.clock.Now()) {
+		return MemoryView{}, fmt.Errorf("memory not found: %s", id)
+	}
+	return mem.view(), nil
+}
+
+// GetConnected returns views of all memories connected to the given memory
+func (hm *HypergraphMemory) GetConnected(id string) ([]MemoryView, error) {
 	hm.mu.RLock()
 	defer hm.mu.RUnlock()
 
@@ -277,10 +884,10 @@ func (hm *HypergraphMemory) GetConnected(id string) ([]*Memory, error) {
 		return nil, fmt.Errorf("memory not found: %s", id)
 	}
 
-	connected := make([]*Memory, 0, len(mem.Connections))
+	connected := make([]MemoryView, 0, len(mem.Connections))
 	for _, connID := range mem.Connections {
 		if connMem, ok := hm.memories[connID]; ok {
-			connected = append(connected, connMem)
+			connected = append(connected, connMem.view())
 		}
 	}
 
@@ -291,7 +898,11 @@ func (hm *HypergraphMemory) GetConnected(id string) ([]*Memory, error) {
 func (hm *HypergraphMemory) SpreadActivation(ctx context.Context, seedID string, depth int, decayFactor float64) (map[string]float64, error) {
 	hm.mu.RLock()
 	defer hm.mu.RUnlock()
+	return hm.spreadActivation(seedID, depth, decayFactor), nil
+}
 
+// spreadActivation computes activation spreading from a seed memory (must hold lock)
+func (hm *HypergraphMemory) spreadActivation(seedID string, depth int, decayFactor float64) map[string]float64 {
 	activation := make(map[string]float64)
 	visited := make(map[string]bool)
 
@@ -317,22 +928,23 @@ func (hm *HypergraphMemory) SpreadActivation(ctx context.Context, seedID string,
 	}
 
 	spread(seedID, 0, 1.0)
-	return activation, nil
+	return activation
 }
 
 // autoConnect automatically connects similar memories
 func (hm *HypergraphMemory) autoConnect(ctx context.Context, newMem *Memory) {
-	if newMem.Embedding == nil {
+	if !newMem.hasEmbedding() {
 		return
 	}
+	embedding := newMem.vector()
 
 	// Find similar memories in same collection
 	for _, mem := range hm.collections[newMem.Type] {
-		if mem.ID == newMem.ID || mem.Embedding == nil {
+		if mem.ID == newMem.ID || !mem.hasEmbedding() {
 			continue
 		}
 
-		similarity := cosineSimilarity(newMem.Embedding, mem.Embedding)
+		similarity := hm.similarityTo(embedding, mem)
 		if similarity > 0.8 { // High similarity threshold
 			newMem.Connections = append(newMem.Connections, mem.ID)
 			mem.Connections = append(mem.Connections, newMem.ID)
@@ -341,36 +953,31 @@ func (hm *HypergraphMemory) autoConnect(ctx context.Context, newMem *Memory) {
 }
 
 // consolidate removes low-importance memories when over capacity
-func (hm *HypergraphMemory) consolidate() {
+func (hm *HypergraphMemory) consolidate() int {
 	// Apply decay to all memories
-	for _, mem := range hm.memories {
-		timeSinceAccess := time.Since(mem.AccessedAt)
-		mem.Decay = math.Exp(-hm.decayRate * timeSinceAccess.Hours())
-	}
+	now := hm.clock.Now()
+	hm.applyDecay(now)
 
-	// Calculate effective importance
-	type scoredMem struct {
-		id    string
-		score float64
+	// Remove lowest scoring memories until under capacity
+	evicted := hm.evictionCandidates(now)
+	for _, c := range evicted {
+		hm.removeMemory(c.ID)
+		hm.logger.Info("consolidation evicted memory", "id", c.ID, "score", c.Score)
 	}
-	scored := make([]scoredMem, 0, len(hm.memories))
+	return len(evicted)
+}
 
-	for id, mem := range hm.memories {
-		// Score based on importance, decay, access count, and connections
-		score := mem.Importance * mem.Decay * (1.0 + float64(mem.AccessCount)*0.1) * (1.0 + float64(len(mem.Connections))*0.05)
-		scored = append(scored, scoredMem{id: id, score: score})
+// applyDecay recomputes each memory's decay from the time since it was last accessed
+func (hm *HypergraphMemory) applyDecay(now time.Time) {
+	for _, mem := range hm.memories {
+		mem.Decay = hm.decayAt(mem, now)
 	}
+}
 
-	// Sort by score (ascending, so lowest scores first)
-	sort.Slice(scored, func(i, j int) bool {
-		return scored[i].score < scored[j].score
-	})
-
-	// Remove lowest scoring memories until under capacity
-	toRemove := len(hm.memories) - hm.maxMemories
-	for i := 0; i < toRemove && i < len(scored); i++ {
-		hm.removeMemory(scored[i].id)
-	}
+// decayAt returns a memory's decay factor at a given time
+func (hm *HypergraphMemory) decayAt(mem *Memory, now time.Time) float64 {
+	timeSinceAccess := now.Sub(mem.AccessedAt)
+	return math.Exp(-hm.decayRate * timeSinceAccess.Hours())
 }
 
 // removeMemory removes a memory and cleans up connections
@@ -393,21 +1000,60 @@ func (hm *HypergraphMemory) removeMemory(id string) {
 		}
 	}
 
-	// Remove from collection
+	// Forget co-activation history involving this memory
+	for key := range hm.coActivation {
+		if key[0] == id || key[1] == id {
+			delete(hm.coActivation, key)
+		}
+	}
+
+	// Remove from collection and index
+	hm.removeFromCollection(mem)
+	hm.unindexMemory(mem)
+
+	// Remove from main map
+	delete(hm.memories, id)
+	hm.dirty = true
+}
+
+// removeFromCollection removes a memory from its type collection
+func (hm *HypergraphMemory) removeFromCollection(mem *Memory) {
 	col := hm.collections[mem.Type]
 	for i, m := range col {
-		if m.ID == id {
+		if m.ID == mem.ID {
 			hm.collections[mem.Type] = append(col[:i], col[i+1:]...)
 			break
 		}
 	}
+}
 
-	// Remove from main map
-	delete(hm.memories, id)
+// Reclassify moves a memory to a different memory type, keeping its connections
+func (hm *HypergraphMemory) Reclassify(id string, newType MemoryType) error {
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+
+	mem, ok := hm.memories[id]
+	if !ok {
+		return fmt.Errorf("memory not found: %s", id)
+	}
+	if _, ok := hm.collections[newType]; !ok {
+		return fmt.Errorf("unknown memory type: %s", newType)
+	}
+	if mem.Type == newType {
+		return nil
+	}
+
+	hm.removeFromCollection(mem)
+	oldType := mem.Type
+	mem.Type = newType
+	hm.collections[newType] = append(hm.collections[newType], mem)
 	hm.dirty = true
+
+	hm.logger.Debug("reclassified memory", "id", id, "from", oldType, "to", newType)
+	return nil
 }
 
-// Save persists the memory to disk
+// Save persists the memory to its store
 func (hm *HypergraphMemory) Save() error {
 	if hm.persistPath == "" {
 		return nil
@@ -416,34 +1062,35 @@ func (hm *HypergraphMemory) Save() error {
 	hm.mu.RLock()
 	defer hm.mu.RUnlock()
 
-	// Ensure directory exists
-	dir := filepath.Dir(hm.persistPath)
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return fmt.Errorf("failed to create directory: %w", err)
-	}
-
 	// Marshal memories
-	data, err := json.MarshalIndent(hm.memories, "", "  ")
+	data, err := persist.Encode(hm.memories, hm.format)
 	if err != nil {
 		return fmt.Errorf("failed to marshal memories: %w", err)
 	}
 
-	// Write to file
-	if err := os.WriteFile(hm.persistPath, data, 0644); err != nil {
-		return fmt.Errorf("failed to write file: %w", err)
+	if hm.compress {
+		if data, err = persist.Compress(data); err != nil {
+			return fmt.Errorf("failed to compress memories: %w", err)
+		}
+	}
+
+	if err := hm.store.Write(hm.persistPath, data); err != nil {
+		hm.logger.Error("failed to save memories", "path", hm.persistPath, "error", err)
+		return fmt.Errorf("failed to write memories: %w", err)
 	}
 
 	hm.dirty = false
+	hm.logger.Info("saved memories", "path", hm.persistPath, "count", len(hm.memories))
 	return nil
 }
 
-// Load loads memories from disk
+// Load loads memories from its store
 func (hm *HypergraphMemory) Load() error {
 	if hm.persistPath == "" {
 		return nil
 	}
 
-	data, err := os.ReadFile(hm.persistPath)
+	data, err := hm.store.Read(hm.persistPath)
 	if err != nil {
 		return err
 	}
@@ -452,7 +1099,7 @@ func (hm *HypergraphMemory) Load() error {
 	defer hm.mu.Unlock()
 
 	var memories map[string]*Memory
-	if err := json.Unmarshal(data, &memories); err != nil {
+	if err := persist.Decode(data, &memories); err != nil {
 		return fmt.Errorf("failed to unmarshal memories: %w", err)
 	}
 
@@ -465,8 +1112,21 @@ func (hm *HypergraphMemory) Load() error {
 
 	for _, mem := range hm.memories {
 		hm.collections[mem.Type] = append(hm.collections[mem.Type], mem)
+		// Quantize anything saved before quantization was enabled
+		if hm.quantize && mem.Embedding != nil {
+			hm.setEmbedding(mem, mem.Embedding)
+		}
 	}
+	hm.rebuildIndex()
+
+	// Persisted decay is stale after downtime; refresh it so scoring is accurate immediately
+	hm.applyDecay(hm.clock.Now())
 
+	if removed := hm.vacuum(); removed > 0 {
+		hm.logger.Warn("removed dangling connections", "count", removed)
+	}
+
+	hm.logger.Info("loaded memories", "path", hm.persistPath, "count", len(hm.memories))
 	return nil
 }
 
@@ -499,6 +1159,10 @@ func (hm *HypergraphMemory) GetStats() map[string]interface{} {
 		stats["avg_connections"] = float64(totalConnections) / float64(len(hm.memories))
 	}
 
+	stats["query_latency_p50"] = hm.queryLatencies.percentile(0.50).String()
+	stats["query_latency_p90"] = hm.queryLatencies.percentile(0.90).String()
+	stats["query_latency_p99"] = hm.queryLatencies.percentile(0.99).String()
+
 	return stats
 }
 
@@ -523,17 +1187,46 @@ func cosineSimilarity(a, b []float32) float64 {
 	return dotProduct / (math.Sqrt(normA) * math.Sqrt(normB))
 }
 
-func textSimilarity(a, b string) float64 {
-	// Simple Jaccard similarity for fallback
-	wordsA := make(map[string]bool)
-	wordsB := make(map[string]bool)
+// textRelevance scores how well content matches a query, from 0 to 1. It is
+// dominated by the fraction of query words the content contains, so a long memory
+// that fully answers a short query is not penalized, with Jaccard similarity
+// breaking ties in favor of tighter matches.
+func textRelevance(query, content string) float64 {
+	queryWords := wordSet(query)
+	if len(queryWords) == 0 {
+		return 0
+	}
+	contentWords := wordSet(content)
 
-	for _, w := range splitWords(a) {
-		wordsA[w] = true
+	matched := 0
+	for w := range queryWords {
+		if contentWords[w] {
+			matched++
+		}
 	}
-	for _, w := range splitWords(b) {
-		wordsB[w] = true
+
+	coverage := float64(matched) / float64(len(queryWords))
+	return 0.8*coverage + 0.2*textSimilarity(query, content)
+}
+
+// wordSet returns the distinct lowercase words of s with surrounding punctuation removed
+func wordSet(s string) map[string]bool {
+	words := make(map[string]bool)
+	for _, w := range splitWords(s) {
+		w = strings.ToLower(strings.TrimFunc(w, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		}))
+		if w != "" {
+			words[w] = true
+		}
 	}
+	return words
+}
+
+func textSimilarity(a, b string) float64 {
+	// Simple Jaccard similarity for fallback
+	wordsA := wordSet(a)
+	wordsB := wordSet(b)
 
 	intersection := 0
 	for w := range wordsA {
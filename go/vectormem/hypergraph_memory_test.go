@@ -0,0 +1,187 @@
+package vectormem
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestQuerySkipsExpiredBeforeReaping(t *testing.T) {
+	clock := newFakeClock()
+	hm := newTestMemory(t, func(c *HypergraphConfig) { c.Clock = clock })
+	ctx := context.Background()
+
+	if _, err := hm.AddWithTTL(ctx, EpisodicMemory, "session context", nil, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	mustAdd(t, hm, EpisodicMemory, "session notes", nil)
+
+	results, err := hm.Query(ctx, "session", EpisodicMemory, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("before expiry got %v, want both memories", contents(results))
+	}
+
+	clock.Advance(time.Minute)
+	results, err = hm.Query(ctx, "session", EpisodicMemory, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || results[0].Content != "session notes" {
+		t.Fatalf("after expiry got %v, want only the non-expiring memory", contents(results))
+	}
+	if got := hm.GetStats()["total_memories"]; got != 2 {
+		t.Errorf("total_memories = %v, want 2 until the reaper runs", got)
+	}
+}
+
+func TestRemoveExpired(t *testing.T) {
+	clock := newFakeClock()
+	hm := newTestMemory(t, func(c *HypergraphConfig) { c.Clock = clock })
+	ctx := context.Background()
+
+	short, err := hm.AddWithTTL(ctx, EpisodicMemory, "short lived", nil, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := hm.AddWithTTL(ctx, EpisodicMemory, "long lived", nil, time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	keep := mustAdd(t, hm, DeclarativeMemory, "never expires", nil)
+	if err := hm.Connect(short.ID, keep.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	clock.Advance(time.Minute)
+	if n := hm.RemoveExpired(); n != 1 {
+		t.Fatalf("RemoveExpired() = %d, want 1", n)
+	}
+	if _, err := hm.GetConnected(short.ID); err == nil {
+		t.Error("expired memory still present")
+	}
+	connected, err := hm.GetConnected(keep.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(connected) != 0 {
+		t.Errorf("connection to expired memory kept: %v", contents(connected))
+	}
+
+	clock.Advance(24 * time.Hour)
+	if n := hm.RemoveExpired(); n != 1 {
+		t.Fatalf("second RemoveExpired() = %d, want 1", n)
+	}
+	if got := hm.GetStats()["total_memories"]; got != 1 {
+		t.Errorf("total_memories = %v, want only the non-expiring memory", got)
+	}
+}
+
+func TestReaperRemovesExpired(t *testing.T) {
+	hm := newTestMemory(t, func(c *HypergraphConfig) { c.ReapInterval = 5 * time.Millisecond })
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if _, err := hm.AddWithTTL(ctx, EpisodicMemory, "transient", nil, 10*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	mustAdd(t, hm, EpisodicMemory, "durable", nil)
+	if err := hm.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for hm.GetStats()["total_memories"] != 1 {
+		if time.Now().After(deadline) {
+			t.Fatalf("reaper did not remove the expired memory: %v", hm.GetStats()["total_memories"])
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	results, err := hm.Query(ctx, "durable", "", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !hasContent(results, "durable") {
+		t.Error("non-expiring memory was reaped")
+	}
+}
+
+func TestStartTwiceFails(t *testing.T) {
+	hm := newTestMemory(t, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := hm.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := hm.Start(ctx); err == nil {
+		t.Error("second Start succeeded, want error")
+	}
+}
+
+func TestPeekMatchesQueryWithoutSideEffects(t *testing.T) {
+	hm := newTestMemory(t, func(c *HypergraphConfig) {
+		c.Clock = newFakeClock()
+		c.QueryLogSize = 10
+		c.ReinforcementRate = 0.5
+	})
+	ctx := context.Background()
+	mustAdd(t, hm, DeclarativeMemory, "tides follow the moon", nil)
+	mustAdd(t, hm, DeclarativeMemory, "the moon is bright", nil)
+	mustAdd(t, hm, DeclarativeMemory, "bread needs yeast", nil)
+
+	opts := QueryOptions{Type: DeclarativeMemory, Limit: 2}
+	peeked, err := hm.Peek(ctx, "moon tides", opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range hm.Recent(DeclarativeMemory, -1) {
+		if m.AccessCount != 0 || m.Importance != 1 {
+			t.Errorf("Peek changed %q: access count %d, importance %v", m.Content, m.AccessCount, m.Importance)
+		}
+	}
+	if got := hm.RecentQueries(10); len(got) != 0 {
+		t.Errorf("Peek logged %d queries, want none", len(got))
+	}
+	if got := hm.GetStats()["total_queries"]; got != int64(0) {
+		t.Errorf("total_queries = %v after Peek, want 0", got)
+	}
+
+	queried, err := hm.QueryWithOptions(ctx, "moon tides", opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := contents(peeked), contents(queried); !reflect.DeepEqual(got, want) {
+		t.Errorf("Peek = %v, QueryWithOptions = %v; want the same ranking", got, want)
+	}
+}
+
+func TestGet(t *testing.T) {
+	clock := newFakeClock()
+	hm := newTestMemory(t, func(c *HypergraphConfig) { c.Clock = clock })
+	ctx := context.Background()
+
+	mem := mustAdd(t, hm, EpisodicMemory, "kept", nil)
+	got, err := hm.Get(mem.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Content != "kept" {
+		t.Errorf("Get(%s).Content = %q, want %q", mem.ID, got.Content, "kept")
+	}
+
+	short, err := hm.AddWithTTL(ctx, EpisodicMemory, "short lived", nil, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clock.Advance(time.Minute)
+	if _, err := hm.Get(short.ID); err == nil {
+		t.Error("Get returned an expired memory")
+	}
+	if _, err := hm.Get("missing"); err == nil {
+		t.Error("Get returned a memory for an unknown ID")
+	}
+}
@@ -0,0 +1,123 @@
+package vectormem
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// maxJSONLLine bounds the size of a single JSONL record, which may hold a large embedding
+const maxJSONLLine = 64 * 1024 * 1024
+
+// ExportJSONL writes every memory as one JSON object per line, in ID order,
+// so the store can be streamed, diffed, and processed with line-oriented tools
+func (hm *HypergraphMemory) ExportJSONL(w io.Writer) error {
+	hm.mu.RLock()
+	defer hm.mu.RUnlock()
+
+	ids := make([]string, 0, len(hm.memories))
+	for id := range hm.memories {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	enc := json.NewEncoder(w)
+	for _, id := range ids {
+		if err := enc.Encode(hm.memories[id]); err != nil {
+			return fmt.Errorf("failed to export memory %s: %w", id, err)
+		}
+	}
+	return nil
+}
+
+// ImportJSONL reads memories written by ExportJSONL and returns how many were
+// imported. Memories replace any existing memory with the same ID. Memories
+// without an embedding are embedded, in one batch when a batch embedding
+// function is configured.
+// Connections are made bidirectional and those pointing outside the store are dropped.
+func (hm *HypergraphMemory) ImportJSONL(ctx context.Context, r io.Reader) (int, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxJSONLLine)
+
+	imported := make([]*Memory, 0)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var mem Memory
+		if err := json.Unmarshal(scanner.Bytes(), &mem); err != nil {
+			return 0, fmt.Errorf("failed to parse memory on line %d: %w", line, err)
+		}
+		if mem.ID == "" || mem.Type == "" {
+			return 0, fmt.Errorf("memory on line %d is missing an id or type", line)
+		}
+		if mem.Connections == nil {
+			mem.Connections = make([]string, 0)
+		}
+
+		imported = append(imported, &mem)
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, fmt.Errorf("failed to read memories: %w", err)
+	}
+
+	// Embed outside the lock; embedding functions may be slow
+	missing := make([]*Memory, 0)
+	texts := make([]string, 0)
+	for _, mem := range imported {
+		if !mem.hasEmbedding() {
+			missing = append(missing, mem)
+			texts = append(texts, mem.Content)
+		}
+	}
+	embeddings, err := hm.embedBatch(ctx, texts)
+	if err != nil {
+		return 0, err
+	}
+	for i, embedding := range embeddings {
+		missing[i].Embedding = embedding
+	}
+
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+
+	for _, mem := range imported {
+		if embedding := mem.vector(); embedding != nil {
+			if err := hm.checkEmbeddingDim(embedding); err != nil {
+				return 0, fmt.Errorf("memory %s: %w", mem.ID, err)
+			}
+			hm.setEmbedding(mem, embedding)
+		}
+	}
+
+	for _, mem := range imported {
+		if _, ok := hm.memories[mem.ID]; ok {
+			hm.removeMemory(mem.ID)
+		}
+		hm.memories[mem.ID] = mem
+		hm.collections[mem.Type] = append(hm.collections[mem.Type], mem)
+		hm.indexMemory(mem)
+	}
+
+	// Restore the reverse side of imported connections
+	for _, mem := range imported {
+		for _, connID := range mem.Connections {
+			if neighbor, ok := hm.memories[connID]; ok && !isConnected(neighbor, mem) {
+				neighbor.Connections = append(neighbor.Connections, mem.ID)
+			}
+		}
+	}
+	hm.vacuum()
+
+	if len(imported) > 0 {
+		hm.dirty = true
+		hm.logger.Info("imported memories", "count", len(imported))
+	}
+	return len(imported), nil
+}
@@ -0,0 +1,163 @@
+package vectormem
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// connectedContents returns the sorted contents of the memories connected to id
+func connectedContents(t *testing.T, hm *HypergraphMemory, id string) []string {
+	t.Helper()
+	views, err := hm.GetConnected(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := contents(views)
+	sort.Strings(got)
+	return got
+}
+
+func TestJSONLRoundTrip(t *testing.T) {
+	embed := wordEmbedding("kettle", "stove", "harbour", "boats")
+	configure := func(c *HypergraphConfig) { c.EmbeddingFunc = embed }
+	src := newTestMemory(t, configure)
+	kettle := mustAdd(t, src, EpisodicMemory, "kettle on the stove", map[string]interface{}{"room": "kitchen"})
+	stove := mustAdd(t, src, DeclarativeMemory, "the stove is hot", nil)
+	boats := mustAdd(t, src, EpisodicMemory, "boats in the harbour", nil)
+	if err := src.Connect(kettle.ID, stove.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := src.Connect(stove.ID, boats.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	var exported bytes.Buffer
+	if err := src.ExportJSONL(&exported); err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Count(exported.String(), "\n"); lines != 3 {
+		t.Fatalf("exported %d lines, want one per memory", lines)
+	}
+
+	dst := newTestMemory(t, configure)
+	n, err := dst.ImportJSONL(context.Background(), bytes.NewReader(exported.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 3 {
+		t.Fatalf("imported %d memories, want 3", n)
+	}
+
+	var reexported bytes.Buffer
+	if err := dst.ExportJSONL(&reexported); err != nil {
+		t.Fatal(err)
+	}
+	if reexported.String() != exported.String() {
+		t.Errorf("re-export differs:\n%s\nwant:\n%s", reexported.String(), exported.String())
+	}
+
+	// Connections survive in both directions
+	for _, mem := range []MemoryView{kettle, stove, boats} {
+		want := connectedContents(t, src, mem.ID)
+		if got := connectedContents(t, dst, mem.ID); strings.Join(got, "|") != strings.Join(want, "|") {
+			t.Errorf("connections of %q = %v, want %v", mem.Content, got, want)
+		}
+	}
+
+	views, err := dst.Query(context.Background(), "harbour", EpisodicMemory, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(views) != 1 || views[0].ID != boats.ID {
+		t.Errorf("query after import = %v, want the harbour memory", contents(views))
+	}
+}
+
+func TestImportJSONLRepairsConnections(t *testing.T) {
+	input := strings.Join([]string{
+		`{"id":"a","type":"episodic","content":"kettle","connections":["b","missing"]}`,
+		``,
+		`{"id":"b","type":"episodic","content":"stove"}`,
+	}, "\n")
+
+	hm := newTestMemory(t, nil)
+	n, err := hm.ImportJSONL(context.Background(), strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 2 {
+		t.Fatalf("imported %d memories, want 2", n)
+	}
+
+	if got := connectedContents(t, hm, "a"); len(got) != 1 || got[0] != "stove" {
+		t.Errorf("a connected to %v, want only stove with the dangling link dropped", got)
+	}
+	if got := connectedContents(t, hm, "b"); len(got) != 1 || got[0] != "kettle" {
+		t.Errorf("b connected to %v, want the reverse link to kettle", got)
+	}
+}
+
+func TestImportJSONLEmbedsMissing(t *testing.T) {
+	input := `{"id":"a","type":"episodic","content":"kettle on the stove"}` + "\n"
+	hm := newTestMemory(t, func(c *HypergraphConfig) {
+		c.EmbeddingFunc = wordEmbedding("kettle", "stove")
+	})
+	if _, err := hm.ImportJSONL(context.Background(), strings.NewReader(input)); err != nil {
+		t.Fatal(err)
+	}
+	hm.mu.RLock()
+	embedding := hm.memories["a"].vector()
+	hm.mu.RUnlock()
+	if len(embedding) != 2 || embedding[0] == 0 || embedding[1] == 0 {
+		t.Errorf("embedding = %v, want one computed from the content", embedding)
+	}
+}
+
+func TestImportJSONLReplacesExisting(t *testing.T) {
+	hm := newTestMemory(t, nil)
+	input := `{"id":"a","type":"episodic","content":"first"}` + "\n"
+	if _, err := hm.ImportJSONL(context.Background(), strings.NewReader(input)); err != nil {
+		t.Fatal(err)
+	}
+	input = `{"id":"a","type":"declarative","content":"second"}` + "\n"
+	if _, err := hm.ImportJSONL(context.Background(), strings.NewReader(input)); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := hm.GetStats()["total_memories"]; got != 1 {
+		t.Errorf("total memories = %v, want 1 after replacing", got)
+	}
+	if recent := hm.Recent(EpisodicMemory, 10); len(recent) != 0 {
+		t.Errorf("episodic memories = %v, want the old collection entry gone", contents(recent))
+	}
+	if recent := hm.Recent(DeclarativeMemory, 10); len(recent) != 1 || recent[0].Content != "second" {
+		t.Errorf("declarative memories = %v, want the replacement", contents(recent))
+	}
+}
+
+func TestImportJSONLRejectsBadInput(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"malformed", `{"id":"a","type":"episodic","content":"ok"}` + "\n{not json\n", "line 2"},
+		{"missing id", `{"type":"episodic","content":"no id"}`, "line 1"},
+		{"missing type", `{"id":"a","content":"no type"}`, "line 1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hm := newTestMemory(t, nil)
+			n, err := hm.ImportJSONL(context.Background(), strings.NewReader(tt.input))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("ImportJSONL error = %v, want one mentioning %q", err, tt.want)
+			}
+			if n != 0 || hm.GetStats()["total_memories"] != 0 {
+				t.Errorf("imported %d memories from bad input, want none", n)
+			}
+		})
+	}
+}
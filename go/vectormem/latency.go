@@ -0,0 +1,79 @@
+package vectormem
+
+import "time"
+
+// latencyBuckets are the upper bounds of the query latency histogram buckets;
+// slower queries fall into a final overflow bucket
+var latencyBuckets = []time.Duration{
+	50 * time.Microsecond,
+	100 * time.Microsecond,
+	250 * time.Microsecond,
+	500 * time.Microsecond,
+	time.Millisecond,
+	2500 * time.Microsecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+}
+
+// latencyHistogram counts query latencies in fixed buckets so percentiles can
+// be estimated without keeping every sample
+type latencyHistogram struct {
+	counts []int64
+	total  int64
+	sum    time.Duration
+	max    time.Duration
+}
+
+// newLatencyHistogram creates an empty histogram over latencyBuckets
+func newLatencyHistogram() *latencyHistogram {
+	return &latencyHistogram{counts: make([]int64, len(latencyBuckets)+1)}
+}
+
+// observe records one latency
+func (h *latencyHistogram) observe(d time.Duration) {
+	i := 0
+	for i < len(latencyBuckets) && d > latencyBuckets[i] {
+		i++
+	}
+	h.counts[i]++
+	h.total++
+	h.sum += d
+	if d > h.max {
+		h.max = d
+	}
+}
+
+// percentile estimates the latency below which a fraction p of queries fell, as
+// the upper bound of the bucket holding that rank, capped at the slowest query
+// observed. It returns 0 when nothing has been observed.
+func (h *latencyHistogram) percentile(p float64) time.Duration {
+	if h.total == 0 {
+		return 0
+	}
+
+	rank := int64(p*float64(h.total) + 0.5)
+	if rank < 1 {
+		rank = 1
+	}
+
+	var seen int64
+	for i, count := range h.counts {
+		seen += count
+		if seen >= rank {
+			if i < len(latencyBuckets) && latencyBuckets[i] < h.max {
+				return latencyBuckets[i]
+			}
+			return h.max
+		}
+	}
+	return h.max
+}
@@ -0,0 +1,129 @@
+package vectormem
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestLatencyHistogramPercentiles(t *testing.T) {
+	h := newLatencyHistogram()
+	for i := 0; i < 90; i++ {
+		h.observe(time.Millisecond)
+	}
+	for i := 0; i < 9; i++ {
+		h.observe(20 * time.Millisecond)
+	}
+	h.observe(2 * time.Second)
+
+	tests := []struct {
+		p    float64
+		want time.Duration
+	}{
+		{0.50, time.Millisecond},
+		{0.90, time.Millisecond},
+		{0.95, 25 * time.Millisecond},
+		{0.99, 25 * time.Millisecond},
+		{1.00, 2 * time.Second}, // Capped at the slowest query, not the 2.5s bucket
+	}
+	for _, tt := range tests {
+		if got := h.percentile(tt.p); got != tt.want {
+			t.Errorf("percentile(%v) = %v, want %v", tt.p, got, tt.want)
+		}
+	}
+}
+
+func TestLatencyHistogramEdges(t *testing.T) {
+	h := newLatencyHistogram()
+	if got := h.percentile(0.99); got != 0 {
+		t.Errorf("empty percentile = %v, want 0", got)
+	}
+
+	// A lone sample reports itself rather than its bucket's upper bound
+	h.observe(3 * time.Millisecond)
+	if got := h.percentile(0.50); got != 3*time.Millisecond {
+		t.Errorf("single sample percentile = %v, want 3ms", got)
+	}
+
+	// Bucket bounds are inclusive
+	h = newLatencyHistogram()
+	h.observe(time.Millisecond)
+	h.observe(time.Hour)
+	if h.counts[4] != 1 {
+		t.Errorf("1ms landed in bucket counts %v, want the 1ms bucket", h.counts)
+	}
+	if h.counts[len(latencyBuckets)] != 1 {
+		t.Errorf("1h sample not counted in the overflow bucket: %v", h.counts)
+	}
+	if got := h.percentile(0.99); got != time.Hour {
+		t.Errorf("overflow percentile = %v, want 1h", got)
+	}
+}
+
+func TestGetStatsLatencyPercentiles(t *testing.T) {
+	hm := newTestMemory(t, nil)
+	ctx := context.Background()
+
+	stats := hm.GetStats()
+	for _, key := range []string{"query_latency_p50", "query_latency_p90", "query_latency_p99"} {
+		if stats[key] != "0s" {
+			t.Errorf("%s before any query = %v, want 0s", key, stats[key])
+		}
+	}
+
+	mustAdd(t, hm, EpisodicMemory, "alpha", nil)
+	for i := 0; i < 10; i++ {
+		if _, err := hm.Query(ctx, "alpha", "", 5); err != nil {
+			t.Fatalf("Query: %v", err)
+		}
+	}
+
+	stats = hm.GetStats()
+	if stats["total_queries"] != int64(10) {
+		t.Errorf("total_queries = %v, want 10", stats["total_queries"])
+	}
+	var prev time.Duration
+	for _, key := range []string{"query_latency_p50", "query_latency_p90", "query_latency_p99"} {
+		d, err := time.ParseDuration(stats[key].(string))
+		if err != nil {
+			t.Fatalf("%s = %v: %v", key, stats[key], err)
+		}
+		if d <= 0 || d < prev {
+			t.Errorf("%s = %v, want positive and no less than %v", key, d, prev)
+		}
+		prev = d
+	}
+	if _, ok := stats["avg_query_latency"].(string); !ok {
+		t.Errorf("avg_query_latency missing from stats: %v", stats)
+	}
+
+	// Stats report the histogram's percentiles directly
+	hm.mu.Lock()
+	hm.queryLatencies = newLatencyHistogram()
+	for i := 0; i < 99; i++ {
+		hm.queryLatencies.observe(80 * time.Microsecond)
+	}
+	hm.queryLatencies.observe(400 * time.Millisecond)
+	hm.mu.Unlock()
+
+	stats = hm.GetStats()
+	if stats["query_latency_p50"] != "100µs" || stats["query_latency_p99"] != "100µs" {
+		t.Errorf("p50/p99 = %v/%v, want 100µs/100µs", stats["query_latency_p50"], stats["query_latency_p99"])
+	}
+}
+
+func TestAverageQueryLatency(t *testing.T) {
+	hm := newTestMemory(t, nil)
+
+	hm.mu.Lock()
+	now := time.Now()
+	hm.recordQueryLatency(now.Add(-10 * time.Millisecond))
+	hm.recordQueryLatency(now.Add(-30 * time.Millisecond))
+	avg := hm.avgQueryLatency
+	hm.mu.Unlock()
+
+	// Both samples run slightly long, since they are measured from now
+	if avg < 20*time.Millisecond || avg > 25*time.Millisecond {
+		t.Errorf("average latency = %v, want about 20ms", avg)
+	}
+}
@@ -0,0 +1,89 @@
+package vectormem
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// logEntry is one message captured by recordingLogger
+type logEntry struct {
+	level   string
+	msg     string
+	keyvals []interface{}
+}
+
+// value returns the value logged under key, if any
+func (e logEntry) value(key string) (interface{}, bool) {
+	for i := 0; i+1 < len(e.keyvals); i += 2 {
+		if e.keyvals[i] == key {
+			return e.keyvals[i+1], true
+		}
+	}
+	return nil, false
+}
+
+// recordingLogger captures every message for inspection
+type recordingLogger struct {
+	mu      sync.Mutex
+	entries []logEntry
+}
+
+func (l *recordingLogger) record(level, msg string, keyvals []interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = append(l.entries, logEntry{level: level, msg: msg, keyvals: keyvals})
+}
+
+func (l *recordingLogger) Debug(msg string, keyvals ...interface{}) { l.record("debug", msg, keyvals) }
+func (l *recordingLogger) Info(msg string, keyvals ...interface{})  { l.record("info", msg, keyvals) }
+func (l *recordingLogger) Warn(msg string, keyvals ...interface{})  { l.record("warn", msg, keyvals) }
+func (l *recordingLogger) Error(msg string, keyvals ...interface{}) { l.record("error", msg, keyvals) }
+
+// find returns the entries with the given message
+func (l *recordingLogger) find(msg string) []logEntry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var found []logEntry
+	for _, e := range l.entries {
+		if e.msg == msg {
+			found = append(found, e)
+		}
+	}
+	return found
+}
+
+func TestConsolidationEvictionIsLogged(t *testing.T) {
+	logger := &recordingLogger{}
+	clock := newFakeClock()
+	hm := newTestMemory(t, func(c *HypergraphConfig) {
+		c.Logger = logger
+		c.Clock = clock
+		c.MaxMemories = 2
+	})
+
+	stale := mustAdd(t, hm, EpisodicMemory, "an old afternoon", nil)
+	clock.Advance(72 * time.Hour)
+	mustAdd(t, hm, EpisodicMemory, "this morning", nil)
+	mustAdd(t, hm, EpisodicMemory, "just now", nil)
+
+	evictions := logger.find("consolidation evicted memory")
+	if len(evictions) != 1 {
+		t.Fatalf("got %d eviction logs, want 1", len(evictions))
+	}
+	if evictions[0].level != "info" {
+		t.Errorf("eviction logged at %s, want info", evictions[0].level)
+	}
+	if id, _ := evictions[0].value("id"); id != stale.ID {
+		t.Errorf("eviction logged id %v, want %s", id, stale.ID)
+	}
+}
+
+func TestNilLoggerDefaultsToNop(t *testing.T) {
+	hm := newTestMemory(t, func(c *HypergraphConfig) {
+		c.Logger = nil
+		c.MaxMemories = 1
+	})
+	mustAdd(t, hm, EpisodicMemory, "first", nil)
+	mustAdd(t, hm, EpisodicMemory, "second", nil)
+}
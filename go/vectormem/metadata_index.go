@@ -0,0 +1,122 @@
+package vectormem
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// metadataIndex maps an indexed metadata key to its values, and each value to
+// the memories holding it, keyed by memory ID
+type metadataIndex map[string]map[string]map[string]*Memory
+
+// metadataValueKey converts a scalar metadata value to a comparable index key.
+// Numbers of any type share one representation, since values decoded from JSON
+// are always float64. Non-scalar values can't be indexed or filtered on.
+func metadataValueKey(v interface{}) (string, bool) {
+	switch x := v.(type) {
+	case string:
+		return "s:" + x, true
+	case bool:
+		return "b:" + strconv.FormatBool(x), true
+	case float64:
+		return "n:" + strconv.FormatFloat(x, 'g', -1, 64), true
+	case float32:
+		return "n:" + strconv.FormatFloat(float64(x), 'g', -1, 64), true
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		f, _ := strconv.ParseFloat(fmt.Sprint(x), 64)
+		return "n:" + strconv.FormatFloat(f, 'g', -1, 64), true
+	default:
+		return "", false
+	}
+}
+
+// matchesMetadata reports whether a memory holds every key/value pair of the filter
+func matchesMetadata(mem *Memory, filter map[string]interface{}) bool {
+	for k, want := range filter {
+		wantKey, ok := metadataValueKey(want)
+		if !ok {
+			return false
+		}
+		got, ok := metadataValueKey(mem.Metadata[k])
+		if !ok || got != wantKey {
+			return false
+		}
+	}
+	return true
+}
+
+// indexMemory adds a memory's indexed metadata to the index (must hold lock)
+func (hm *HypergraphMemory) indexMemory(mem *Memory) {
+	if hm.metaIndex == nil {
+		return
+	}
+	for key, values := range hm.metaIndex {
+		valueKey, ok := metadataValueKey(mem.Metadata[key])
+		if !ok {
+			continue
+		}
+		if values[valueKey] == nil {
+			values[valueKey] = make(map[string]*Memory)
+		}
+		values[valueKey][mem.ID] = mem
+	}
+}
+
+// unindexMemory removes a memory from the index (must hold lock)
+func (hm *HypergraphMemory) unindexMemory(mem *Memory) {
+	if hm.metaIndex == nil {
+		return
+	}
+	for key, values := range hm.metaIndex {
+		valueKey, ok := metadataValueKey(mem.Metadata[key])
+		if !ok {
+			continue
+		}
+		delete(values[valueKey], mem.ID)
+		if len(values[valueKey]) == 0 {
+			delete(values, valueKey)
+		}
+	}
+}
+
+// rebuildIndex reindexes every memory from scratch (must hold lock)
+func (hm *HypergraphMemory) rebuildIndex() {
+	if hm.metaIndex == nil {
+		return
+	}
+	for key := range hm.metaIndex {
+		hm.metaIndex[key] = make(map[string]map[string]*Memory)
+	}
+	for _, mem := range hm.memories {
+		hm.indexMemory(mem)
+	}
+}
+
+// indexedCandidates returns the memories matching the filter's most selective
+// indexed key, or false if no filter key is indexed (must hold lock)
+func (hm *HypergraphMemory) indexedCandidates(filter map[string]interface{}) ([]*Memory, bool) {
+	var best map[string]*Memory
+	found := false
+	for k, v := range filter {
+		values, indexed := hm.metaIndex[k]
+		if !indexed {
+			continue
+		}
+		valueKey, ok := metadataValueKey(v)
+		if !ok {
+			return nil, true // Non-scalar filter values match nothing
+		}
+		if set := values[valueKey]; !found || len(set) < len(best) {
+			best, found = set, true
+		}
+	}
+	if !found {
+		return nil, false
+	}
+
+	candidates := make([]*Memory, 0, len(best))
+	for _, mem := range best {
+		candidates = append(candidates, mem)
+	}
+	return candidates, true
+}
@@ -0,0 +1,152 @@
+package vectormem
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+// checkIndex fails the test unless the metadata index matches one rebuilt
+// from the memories currently held
+func checkIndex(t *testing.T, hm *HypergraphMemory, step string) {
+	t.Helper()
+	hm.mu.RLock()
+	defer hm.mu.RUnlock()
+
+	want := make(map[string]map[string][]string)
+	for key := range hm.metaIndex {
+		want[key] = make(map[string][]string)
+		for id, mem := range hm.memories {
+			if valueKey, ok := metadataValueKey(mem.Metadata[key]); ok {
+				want[key][valueKey] = append(want[key][valueKey], id)
+			}
+		}
+	}
+
+	got := make(map[string]map[string][]string)
+	for key, values := range hm.metaIndex {
+		got[key] = make(map[string][]string)
+		for valueKey, mems := range values {
+			for id, mem := range mems {
+				if hm.memories[id] != mem {
+					t.Errorf("%s: index holds stale memory %s", step, id)
+				}
+				got[key][valueKey] = append(got[key][valueKey], id)
+			}
+		}
+	}
+
+	for _, index := range []map[string]map[string][]string{want, got} {
+		for _, values := range index {
+			for _, ids := range values {
+				sort.Strings(ids)
+			}
+		}
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("%s: index = %v, want %v", step, got, want)
+	}
+}
+
+func TestMetadataIndexStaysConsistent(t *testing.T) {
+	clock := newFakeClock()
+	embed := wordEmbedding("kettle", "stove", "harbour", "boats", "tide")
+	hm := newTestMemory(t, func(c *HypergraphConfig) {
+		c.Clock = clock
+		c.EmbeddingFunc = embed
+		c.DedupThreshold = 0.99
+		c.IndexedMetadataKeys = []string{"room", "speaker"}
+	})
+	ctx := context.Background()
+
+	kettle := mustAdd(t, hm, EpisodicMemory, "kettle", map[string]interface{}{"room": "kitchen"})
+	mustAdd(t, hm, EpisodicMemory, "stove", map[string]interface{}{"room": "kitchen", "speaker": "ana"})
+	mustAdd(t, hm, EpisodicMemory, "harbour", map[string]interface{}{"room": "outside", "count": 3})
+	if _, err := hm.AddWithTTL(ctx, EpisodicMemory, "boats", map[string]interface{}{"speaker": "ben"}, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	checkIndex(t, hm, "add")
+
+	// A duplicate insert merges its metadata into the existing memory
+	if mem, err := hm.Add(ctx, EpisodicMemory, "kettle", map[string]interface{}{"speaker": "ana"}); err != nil || mem.ID != kettle.ID {
+		t.Fatalf("duplicate Add = %v, %v; want a merge into %s", mem, err, kettle.ID)
+	}
+	checkIndex(t, hm, "dedup merge")
+
+	clock.Advance(time.Hour)
+	if n := hm.RemoveExpired(); n != 1 {
+		t.Fatalf("RemoveExpired removed %d, want 1", n)
+	}
+	checkIndex(t, hm, "expiry")
+
+	input := `{"id":"` + kettle.ID + `","type":"episodic","content":"kettle","metadata":{"room":"pantry"}}` + "\n"
+	if _, err := hm.ImportJSONL(ctx, strings.NewReader(input)); err != nil {
+		t.Fatal(err)
+	}
+	checkIndex(t, hm, "import replace")
+
+	if err := hm.Reclassify(kettle.ID, DeclarativeMemory); err != nil {
+		t.Fatal(err)
+	}
+	checkIndex(t, hm, "reclassify")
+
+	// Compacting every episodic memory into one averages and fills in metadata
+	if n, err := hm.CompactClusters(ctx, 0); err != nil || n != 1 {
+		t.Fatalf("CompactClusters = %d, %v; want 1 merged", n, err)
+	}
+	checkIndex(t, hm, "compaction")
+}
+
+func TestIndexedQueriesMatchScans(t *testing.T) {
+	build := func(indexed []string) *HypergraphMemory {
+		hm := newTestMemory(t, func(c *HypergraphConfig) {
+			c.Clock = newFakeClock()
+			c.EmbeddingFunc = wordEmbedding("kettle", "stove", "harbour", "boats")
+			c.IndexedMetadataKeys = indexed
+		})
+		for _, m := range []struct {
+			content  string
+			memType  MemoryType
+			metadata map[string]interface{}
+		}{
+			{"kettle on", EpisodicMemory, map[string]interface{}{"room": "kitchen", "floor": 1}},
+			{"stove hot", EpisodicMemory, map[string]interface{}{"room": "kitchen", "floor": 2.0}},
+			{"kettle stove", DeclarativeMemory, map[string]interface{}{"room": "kitchen", "floor": 1}},
+			{"harbour boats", EpisodicMemory, map[string]interface{}{"room": "outside", "floor": 1}},
+			{"boats", EpisodicMemory, map[string]interface{}{"tags": []interface{}{"kitchen"}}},
+		} {
+			mustAdd(t, hm, m.memType, m.content, m.metadata)
+		}
+		return hm
+	}
+	scan := build(nil)
+	indexed := build([]string{"room", "floor"})
+
+	filters := []QueryOptions{
+		{Limit: 10, Metadata: map[string]interface{}{"room": "kitchen"}},
+		{Limit: 10, Metadata: map[string]interface{}{"room": "kitchen"}, Type: EpisodicMemory},
+		{Limit: 10, Metadata: map[string]interface{}{"floor": 1}},
+		{Limit: 10, Metadata: map[string]interface{}{"floor": float64(1), "room": "kitchen"}},
+		{Limit: 10, Metadata: map[string]interface{}{"room": "attic"}},
+		{Limit: 10, Metadata: map[string]interface{}{"tags": "kitchen"}},
+		{Limit: 10, Metadata: map[string]interface{}{"room": []string{"kitchen"}}},
+		{Limit: 1, Metadata: map[string]interface{}{"room": "kitchen"}},
+	}
+	for _, opts := range filters {
+		want, err := scan.QueryWithOptions(context.Background(), "kettle", opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := indexed.QueryWithOptions(context.Background(), "kettle", opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(contents(got), contents(want)) {
+			t.Errorf("filter %v (type %q, limit %d): indexed %v, scan %v",
+				opts.Metadata, opts.Type, opts.Limit, contents(got), contents(want))
+		}
+	}
+}
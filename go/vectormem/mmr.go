@@ -0,0 +1,63 @@
+package vectormem
+
+// selectDiverse picks up to limit results by Maximal Marginal Relevance: each
+// pick maximizes lambda*relevance - (1-lambda)*(similarity to the closest result
+// already picked). Relevance is the query score scaled into [0, 1] by the best
+// score, so lambda weighs comparable quantities.
+func (hm *HypergraphMemory) selectDiverse(scored []ScoredResult, limit int, lambda float64) []ScoredResult {
+	if limit > len(scored) {
+		limit = len(scored)
+	}
+	if limit <= 0 {
+		return scored[:0]
+	}
+
+	maxScore := 0.0
+	for _, r := range scored {
+		if r.Score > maxScore {
+			maxScore = r.Score
+		}
+	}
+
+	selected := make([]ScoredResult, 0, limit)
+	used := make([]bool, len(scored))
+	// closest[i] is candidate i's highest similarity to any selected result
+	closest := make([]float64, len(scored))
+
+	for len(selected) < limit {
+		best, bestValue := -1, 0.0
+		for i, r := range scored {
+			if used[i] {
+				continue
+			}
+			relevance := 0.0
+			if maxScore > 0 {
+				relevance = r.Score / maxScore
+			}
+			value := lambda*relevance - (1-lambda)*closest[i]
+			if best < 0 || value > bestValue {
+				best, bestValue = i, value
+			}
+		}
+
+		used[best] = true
+		selected = append(selected, scored[best])
+		for i, r := range scored {
+			if !used[i] {
+				if sim := hm.resultSimilarity(r.Memory, scored[best].Memory); sim > closest[i] {
+					closest[i] = sim
+				}
+			}
+		}
+	}
+
+	return selected
+}
+
+// resultSimilarity compares two memories in [0, 1], by embedding when both have one, otherwise by text
+func (hm *HypergraphMemory) resultSimilarity(a, b *Memory) float64 {
+	if a.hasEmbedding() && b.hasEmbedding() {
+		return hm.normalizeSimilarity(hm.similarityTo(a.vector(), b))
+	}
+	return textSimilarity(a.Content, b.Content)
+}
@@ -0,0 +1,82 @@
+package vectormem
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// newClusteredMemory holds two tight clusters of three memories each: "kitchen"
+// memories close to the query and "harbour" memories less relevant but
+// unlike the kitchen ones
+func newClusteredMemory(t *testing.T) *HypergraphMemory {
+	t.Helper()
+	table := map[string][]float32{
+		"query":     {1, 0.8},
+		"kitchen 1": {1, 0.01},
+		"kitchen 2": {1, 0.02},
+		"kitchen 3": {1, 0.03},
+		"harbour 1": {0.01, 1},
+		"harbour 2": {0.02, 1},
+		"harbour 3": {0.03, 1},
+	}
+	hm := newTestMemory(t, func(c *HypergraphConfig) { c.EmbeddingFunc = tableEmbedding(table) })
+	for _, content := range []string{"kitchen 1", "kitchen 2", "kitchen 3", "harbour 1", "harbour 2", "harbour 3"} {
+		mustAdd(t, hm, EpisodicMemory, content, nil)
+	}
+	return hm
+}
+
+// clusters returns the distinct cluster names among the results
+func clusters(views []MemoryView) map[string]bool {
+	names := make(map[string]bool)
+	for _, v := range views {
+		names[strings.Fields(v.Content)[0]] = true
+	}
+	return names
+}
+
+func TestDiverseQuerySpansClusters(t *testing.T) {
+	tests := []struct {
+		name string
+		opts QueryOptions
+		want []string
+	}{
+		{"relevance only", QueryOptions{Limit: 3}, []string{"kitchen"}},
+		{"lambda 1", QueryOptions{Limit: 3, Diverse: true, DiversityLambda: 1}, []string{"kitchen"}},
+		{"balanced", QueryOptions{Limit: 3, Diverse: true, DiversityLambda: 0.5}, []string{"kitchen", "harbour"}},
+		{"diversity only", QueryOptions{Limit: 2, Diverse: true, DiversityLambda: 0}, []string{"kitchen", "harbour"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hm := newClusteredMemory(t)
+			results, err := hm.QueryWithOptions(context.Background(), "query", tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(results) != tt.opts.Limit {
+				t.Fatalf("got %d results, want %d", len(results), tt.opts.Limit)
+			}
+			got := clusters(results)
+			if len(got) != len(tt.want) {
+				t.Errorf("results %v span clusters %v, want %v", contents(results), got, tt.want)
+			}
+			for _, name := range tt.want {
+				if !got[name] {
+					t.Errorf("results %v miss the %s cluster", contents(results), name)
+				}
+			}
+		})
+	}
+}
+
+func TestDiverseQueryStartsWithMostRelevant(t *testing.T) {
+	hm := newClusteredMemory(t)
+	results, err := hm.QueryWithOptions(context.Background(), "query", QueryOptions{Limit: 2, Diverse: true, DiversityLambda: 0.5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(results[0].Content, "kitchen") || !strings.HasPrefix(results[1].Content, "harbour") {
+		t.Errorf("results = %v, want the best kitchen match then a harbour one", contents(results))
+	}
+}
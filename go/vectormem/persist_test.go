@@ -0,0 +1,103 @@
+package vectormem
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/o9nn/un9n/go/persist"
+)
+
+func TestSaveLoadRoundTrip(t *testing.T) {
+	for _, format := range []persist.Format{persist.FormatJSON, persist.FormatGob} {
+		t.Run(string(format), func(t *testing.T) {
+			clock := newFakeClock()
+			path := filepath.Join(t.TempDir(), "memories")
+			configure := func(c *HypergraphConfig) {
+				c.Clock = clock
+				c.PersistPath = path
+				c.Format = format
+				c.EmbeddingFunc = wordEmbedding("tide", "moon", "kettle")
+			}
+
+			hm := newTestMemory(t, configure)
+			a := mustAdd(t, hm, EpisodicMemory, "the tide follows the moon", map[string]interface{}{"place": "harbour"})
+			b := mustAdd(t, hm, DeclarativeMemory, "the kettle whistles", nil)
+			if err := hm.Connect(a.ID, b.ID); err != nil {
+				t.Fatal(err)
+			}
+			if err := hm.Save(); err != nil {
+				t.Fatal(err)
+			}
+
+			loaded := newTestMemory(t, configure)
+			if got, want := loaded.Recent("", -1), hm.Recent("", -1); !reflect.DeepEqual(got, want) {
+				t.Errorf("loaded memories differ:\n got %+v\nwant %+v", got, want)
+			}
+			if got := loaded.Recent(DeclarativeMemory, -1); len(got) != 1 || got[0].ID != b.ID {
+				t.Errorf("declarative collection after load = %v, want only %s", contents(got), b.ID)
+			}
+		})
+	}
+}
+
+func TestSaveGobIsSmallerThanJSON(t *testing.T) {
+	sizes := make(map[persist.Format]int)
+	for _, format := range []persist.Format{persist.FormatJSON, persist.FormatGob} {
+		store := &memoryStore{}
+		hm := newTestMemory(t, func(c *HypergraphConfig) {
+			c.Clock = newFakeClock()
+			c.PersistPath = "memories"
+			c.Store = store
+			c.Format = format
+			c.EmbeddingFunc = wordEmbedding("tide", "moon", "kettle", "stove", "harbour")
+		})
+		for _, content := range []string{"tide and moon", "kettle on the stove", "harbour tide", "moon over the harbour"} {
+			mustAdd(t, hm, EpisodicMemory, content, nil)
+		}
+		if err := hm.Save(); err != nil {
+			t.Fatal(err)
+		}
+		sizes[format] = len(store.data["memories"])
+	}
+	if sizes[persist.FormatGob] >= sizes[persist.FormatJSON] {
+		t.Errorf("gob file is %d bytes, json %d; want gob smaller", sizes[persist.FormatGob], sizes[persist.FormatJSON])
+	}
+}
+
+func TestCompressedSave(t *testing.T) {
+	sizes := make(map[bool]int)
+	for _, compress := range []bool{false, true} {
+		store := &memoryStore{}
+		clock := newFakeClock()
+		configure := func(compress bool) func(*HypergraphConfig) {
+			return func(c *HypergraphConfig) {
+				c.Clock = clock
+				c.PersistPath = "memories"
+				c.Store = store
+				c.Compress = compress
+			}
+		}
+		hm := newTestMemory(t, configure(compress))
+		for _, content := range []string{"tide and moon", "kettle on the stove", "harbour tide", "moon over the harbour"} {
+			mustAdd(t, hm, EpisodicMemory, content, nil)
+		}
+		if err := hm.Save(); err != nil {
+			t.Fatal(err)
+		}
+		data := store.data["memories"]
+		sizes[compress] = len(data)
+		if gzipped := len(data) > 2 && data[0] == 0x1f && data[1] == 0x8b; gzipped != compress {
+			t.Errorf("Compress=%v saved gzip=%v", compress, gzipped)
+		}
+
+		// Load detects compression whatever the loading config says
+		loaded := newTestMemory(t, configure(!compress))
+		if got, want := loaded.Recent("", -1), hm.Recent("", -1); !reflect.DeepEqual(got, want) {
+			t.Errorf("Compress=%v: loaded memories differ:\n got %+v\nwant %+v", compress, got, want)
+		}
+	}
+	if sizes[true] >= sizes[false] {
+		t.Errorf("compressed state is %d bytes, uncompressed %d; want smaller", sizes[true], sizes[false])
+	}
+}
@@ -0,0 +1,68 @@
+package vectormem
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+)
+
+// latencyQuantiles are the query latency percentiles WritePrometheus reports
+var latencyQuantiles = []float64{0.5, 0.9, 0.99}
+
+// WritePrometheus writes the memory's metrics in the Prometheus text exposition
+// format: memory counts per type, query and insert totals, and the query
+// latency histogram along with its estimated percentiles. Serve it from a
+// /metrics handler to scrape the memory.
+func (hm *HypergraphMemory) WritePrometheus(w io.Writer) error {
+	var buf bytes.Buffer
+
+	hm.mu.RLock()
+	types := make([]string, 0, len(hm.collections))
+	for mt := range hm.collections {
+		types = append(types, string(mt))
+	}
+	sort.Strings(types)
+
+	buf.WriteString("# HELP vectormem_memories Number of stored memories by type.\n")
+	buf.WriteString("# TYPE vectormem_memories gauge\n")
+	for _, mt := range types {
+		fmt.Fprintf(&buf, "vectormem_memories{type=%q} %d\n", mt, len(hm.collections[MemoryType(mt)]))
+	}
+
+	buf.WriteString("# HELP vectormem_queries_total Queries answered.\n")
+	buf.WriteString("# TYPE vectormem_queries_total counter\n")
+	fmt.Fprintf(&buf, "vectormem_queries_total %d\n", hm.totalQueries)
+
+	buf.WriteString("# HELP vectormem_inserts_total Memories inserted.\n")
+	buf.WriteString("# TYPE vectormem_inserts_total counter\n")
+	fmt.Fprintf(&buf, "vectormem_inserts_total %d\n", hm.totalInserts)
+
+	h := hm.queryLatencies
+	buf.WriteString("# HELP vectormem_query_latency_seconds Query latency.\n")
+	buf.WriteString("# TYPE vectormem_query_latency_seconds histogram\n")
+	var cumulative int64
+	for i, bound := range latencyBuckets {
+		cumulative += h.counts[i]
+		fmt.Fprintf(&buf, "vectormem_query_latency_seconds_bucket{le=%q} %d\n", formatFloat(bound.Seconds()), cumulative)
+	}
+	fmt.Fprintf(&buf, "vectormem_query_latency_seconds_bucket{le=\"+Inf\"} %d\n", h.total)
+	fmt.Fprintf(&buf, "vectormem_query_latency_seconds_sum %s\n", formatFloat(h.sum.Seconds()))
+	fmt.Fprintf(&buf, "vectormem_query_latency_seconds_count %d\n", h.total)
+
+	buf.WriteString("# HELP vectormem_query_latency_quantile_seconds Estimated query latency percentiles.\n")
+	buf.WriteString("# TYPE vectormem_query_latency_quantile_seconds gauge\n")
+	for _, q := range latencyQuantiles {
+		fmt.Fprintf(&buf, "vectormem_query_latency_quantile_seconds{quantile=%q} %s\n", formatFloat(q), formatFloat(h.percentile(q).Seconds()))
+	}
+	hm.mu.RUnlock()
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// formatFloat formats a sample value the way Prometheus expects
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
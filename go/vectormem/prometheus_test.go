@@ -0,0 +1,55 @@
+package vectormem
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWritePrometheus(t *testing.T) {
+	hm := newTestMemory(t, nil)
+	ctx := context.Background()
+	mustAdd(t, hm, EpisodicMemory, "alpha", nil)
+	mustAdd(t, hm, EpisodicMemory, "beta", nil)
+	mustAdd(t, hm, DeclarativeMemory, "gamma", nil)
+	if _, err := hm.Query(ctx, "alpha", "", 5); err != nil {
+		t.Fatal(err)
+	}
+
+	hm.mu.Lock()
+	hm.queryLatencies = newLatencyHistogram()
+	for i := 0; i < 99; i++ {
+		hm.queryLatencies.observe(80 * time.Microsecond)
+	}
+	hm.queryLatencies.observe(400 * time.Millisecond)
+	hm.mu.Unlock()
+
+	var out strings.Builder
+	if err := hm.WritePrometheus(&out); err != nil {
+		t.Fatal(err)
+	}
+	text := out.String()
+
+	for _, want := range []string{
+		"# TYPE vectormem_memories gauge\n",
+		`vectormem_memories{type="declarative"} 1` + "\n",
+		`vectormem_memories{type="episodic"} 2` + "\n",
+		"vectormem_queries_total 1\n",
+		"vectormem_inserts_total 3\n",
+		"# TYPE vectormem_query_latency_seconds histogram\n",
+		`vectormem_query_latency_seconds_bucket{le="5e-05"} 0` + "\n",
+		`vectormem_query_latency_seconds_bucket{le="0.0001"} 99` + "\n",
+		`vectormem_query_latency_seconds_bucket{le="0.25"} 99` + "\n",
+		`vectormem_query_latency_seconds_bucket{le="0.5"} 100` + "\n",
+		`vectormem_query_latency_seconds_bucket{le="+Inf"} 100` + "\n",
+		"vectormem_query_latency_seconds_sum 0.40792\n",
+		"vectormem_query_latency_seconds_count 100\n",
+		`vectormem_query_latency_quantile_seconds{quantile="0.5"} 0.0001` + "\n",
+		`vectormem_query_latency_quantile_seconds{quantile="0.99"} 0.0001` + "\n",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("output missing %q:\n%s", want, text)
+		}
+	}
+}
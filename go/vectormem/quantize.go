@@ -0,0 +1,107 @@
+package vectormem
+
+import "math"
+
+// QuantizedEmbedding is an int8 embedding with a per-vector scale, using about
+// a quarter of the memory of float32. Component i is approximately Values[i] * Scale.
+type QuantizedEmbedding struct {
+	Values []int8  `json:"values"`
+	Scale  float32 `json:"scale"`
+}
+
+// quantize maps an embedding onto int8 using its largest magnitude as the scale
+func quantize(embedding []float32) *QuantizedEmbedding {
+	maxAbs := 0.0
+	for _, v := range embedding {
+		maxAbs = math.Max(maxAbs, math.Abs(float64(v)))
+	}
+
+	q := &QuantizedEmbedding{Values: make([]int8, len(embedding))}
+	if maxAbs == 0 {
+		return q
+	}
+
+	q.Scale = float32(maxAbs / 127)
+	for i, v := range embedding {
+		q.Values[i] = int8(math.Round(float64(v) / maxAbs * 127))
+	}
+	return q
+}
+
+// dequantize reconstructs an approximate float32 embedding
+func (q *QuantizedEmbedding) dequantize() []float32 {
+	embedding := make([]float32, len(q.Values))
+	for i, v := range q.Values {
+		embedding[i] = float32(v) * q.Scale
+	}
+	return embedding
+}
+
+// hasEmbedding reports whether the memory has a full or quantized embedding
+func (m *Memory) hasEmbedding() bool {
+	return m.Embedding != nil || m.Quantized != nil
+}
+
+// vector returns the memory's embedding, dequantizing it if necessary, or nil if it has none
+func (m *Memory) vector() []float32 {
+	if m.Embedding != nil {
+		return m.Embedding
+	}
+	if m.Quantized != nil {
+		return m.Quantized.dequantize()
+	}
+	return nil
+}
+
+// setEmbedding stores an embedding on a memory, quantizing it when configured
+func (hm *HypergraphMemory) setEmbedding(mem *Memory, embedding []float32) {
+	if hm.quantize && embedding != nil {
+		mem.Embedding = nil
+		mem.Quantized = quantize(embedding)
+		return
+	}
+	mem.Embedding = embedding
+	mem.Quantized = nil
+}
+
+// similarityTo compares a full-precision embedding with a memory's embedding
+// using the configured metric. Quantized memories are compared directly in
+// their int8 form without dequantizing; for cosine the scale cancels out.
+func (hm *HypergraphMemory) similarityTo(embedding []float32, mem *Memory) float64 {
+	if mem.Embedding != nil || mem.Quantized == nil {
+		return hm.similarity(embedding, mem.Embedding)
+	}
+
+	q := mem.Quantized
+	if len(q.Values) != len(embedding) {
+		return 0
+	}
+
+	scale := float64(q.Scale)
+	switch hm.distanceMetric {
+	case MetricDot:
+		var sum float64
+		for i, v := range q.Values {
+			sum += float64(embedding[i]) * float64(v)
+		}
+		return sum * scale
+	case MetricEuclidean:
+		var sum float64
+		for i, v := range q.Values {
+			d := float64(embedding[i]) - float64(v)*scale
+			sum += d * d
+		}
+		return 1.0 / (1.0 + math.Sqrt(sum))
+	default:
+		var dot, normA, normB float64
+		for i, v := range q.Values {
+			dot += float64(embedding[i]) * float64(v)
+			normA += float64(embedding[i]) * float64(embedding[i])
+			normB += float64(v) * float64(v)
+		}
+		if normA == 0 || normB == 0 {
+			return 0
+		}
+		return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+	}
+}
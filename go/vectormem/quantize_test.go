@@ -0,0 +1,132 @@
+package vectormem
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// randomEmbeddings builds n random embeddings of the given dimension keyed by name
+func randomEmbeddings(r *rand.Rand, prefix string, n, dim int, table map[string][]float32) []string {
+	names := make([]string, n)
+	for i := range names {
+		vec := make([]float32, dim)
+		for j := range vec {
+			vec[j] = float32(r.NormFloat64())
+		}
+		names[i] = fmt.Sprintf("%s %d", prefix, i)
+		table[names[i]] = vec
+	}
+	return names
+}
+
+func TestQuantizedRecall(t *testing.T) {
+	for _, metric := range []DistanceMetric{MetricCosine, MetricDot, MetricEuclidean} {
+		t.Run(string(metric), func(t *testing.T) {
+			r := rand.New(rand.NewSource(1))
+			table := make(map[string][]float32)
+			memories := randomEmbeddings(r, "memory", 200, 64, table)
+			queries := randomEmbeddings(r, "query", 20, 64, table)
+
+			build := func(quantized bool) *HypergraphMemory {
+				hm := newTestMemory(t, func(c *HypergraphConfig) {
+					c.Clock = newFakeClock()
+					c.EmbeddingFunc = tableEmbedding(table)
+					c.DistanceMetric = metric
+					c.QuantizeEmbeddings = quantized
+				})
+				for _, name := range memories {
+					mustAdd(t, hm, DeclarativeMemory, name, nil)
+				}
+				return hm
+			}
+			full, quantized := build(false), build(true)
+
+			overlap := 0
+			for _, query := range queries {
+				want, err := full.Query(context.Background(), query, DeclarativeMemory, 5)
+				if err != nil {
+					t.Fatal(err)
+				}
+				got, err := quantized.Query(context.Background(), query, DeclarativeMemory, 5)
+				if err != nil {
+					t.Fatal(err)
+				}
+				for _, g := range got {
+					if hasContent(want, g.Content) {
+						overlap++
+					}
+				}
+			}
+			if recall := float64(overlap) / float64(5*len(queries)); recall < 0.8 {
+				t.Errorf("top-5 overlap = %.2f, want at least 0.8", recall)
+			}
+		})
+	}
+}
+
+func TestQuantizeRoundTrip(t *testing.T) {
+	embedding := []float32{0.5, -1.0, 0.25, 0, 0.9}
+	q := quantize(embedding)
+	if len(q.Values) != len(embedding) {
+		t.Fatalf("quantized %d values, want %d", len(q.Values), len(embedding))
+	}
+	if q.Values[1] != -127 {
+		t.Errorf("largest magnitude quantized to %d, want -127", q.Values[1])
+	}
+	for i, v := range q.dequantize() {
+		if math.Abs(float64(v-embedding[i])) > float64(q.Scale) {
+			t.Errorf("component %d dequantized to %v, want within one step of %v", i, v, embedding[i])
+		}
+	}
+
+	zero := quantize([]float32{0, 0})
+	if zero.Scale != 0 || zero.dequantize()[0] != 0 {
+		t.Errorf("zero vector quantized to %+v", zero)
+	}
+}
+
+func TestQuantizedMemoriesStoreOnlyInt8(t *testing.T) {
+	hm := newTestMemory(t, func(c *HypergraphConfig) {
+		c.EmbeddingFunc = wordEmbedding("kettle", "stove")
+		c.QuantizeEmbeddings = true
+	})
+	mem := mustAdd(t, hm, EpisodicMemory, "kettle stove stove", nil)
+
+	hm.mu.RLock()
+	stored := hm.memories[mem.ID]
+	embedding, quantized := stored.Embedding, stored.Quantized
+	hm.mu.RUnlock()
+	if embedding != nil || quantized == nil {
+		t.Fatalf("stored embedding %v and quantized %v, want only the quantized form", embedding, quantized)
+	}
+
+	views, err := hm.Query(context.Background(), "kettle stove stove", EpisodicMemory, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(views) != 1 || len(views[0].Embedding) != 2 {
+		t.Errorf("view = %+v, want a dequantized embedding", views)
+	}
+}
+
+func TestQuantizedSimilarityMatchesFull(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	for _, metric := range []DistanceMetric{MetricCosine, MetricDot, MetricEuclidean} {
+		hm := newTestMemory(t, func(c *HypergraphConfig) { c.DistanceMetric = metric })
+		for i := 0; i < 10; i++ {
+			a := make([]float32, 32)
+			b := make([]float32, 32)
+			for j := range a {
+				a[j], b[j] = float32(r.NormFloat64()), float32(r.NormFloat64())
+			}
+			exact := hm.similarity(a, b)
+			approx := hm.similarityTo(a, &Memory{Quantized: quantize(b)})
+			if math.Abs(exact-approx) > 0.05*math.Max(1, math.Abs(exact)) {
+				t.Errorf("%s: quantized similarity %v, exact %v", metric, approx, exact)
+			}
+		}
+	}
+}
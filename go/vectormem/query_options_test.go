@@ -0,0 +1,125 @@
+package vectormem
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestMinScoreDropsOffTopicResults(t *testing.T) {
+	hm := newTestMemory(t, func(c *HypergraphConfig) {
+		c.EmbeddingFunc = wordEmbedding("kettle", "stove", "harbour", "boats", "quantum")
+	})
+	mustAdd(t, hm, EpisodicMemory, "kettle on the stove", nil)
+	mustAdd(t, hm, EpisodicMemory, "boats in the harbour", nil)
+	ctx := context.Background()
+
+	// Without a threshold every memory comes back, however unrelated
+	all, err := hm.QueryWithOptions(ctx, "quantum", QueryOptions{Limit: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 2 {
+		t.Errorf("without MinScore got %v, want both memories", contents(all))
+	}
+
+	offTopic, err := hm.QueryWithOptions(ctx, "quantum", QueryOptions{Limit: 5, MinScore: 0.2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(offTopic) != 0 {
+		t.Errorf("off-topic query returned %v, want nothing", contents(offTopic))
+	}
+
+	onTopic, err := hm.QueryWithOptions(ctx, "kettle", QueryOptions{Limit: 5, MinScore: 0.2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(onTopic) != 1 || onTopic[0].Content != "kettle on the stove" {
+		t.Errorf("on-topic query returned %v, want only the kettle memory", contents(onTopic))
+	}
+}
+
+func TestMinScoreAppliesBeforeLimit(t *testing.T) {
+	hm := newTestMemory(t, nil)
+	mustAdd(t, hm, EpisodicMemory, "a walk by the harbour", nil)
+	mustAdd(t, hm, EpisodicMemory, "the kettle whistles", nil)
+	mustAdd(t, hm, EpisodicMemory, "kettle on", nil)
+
+	results, err := hm.QueryWithOptions(context.Background(), "kettle", QueryOptions{Limit: 1, MinScore: 0.5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || results[0].Content != "kettle on" {
+		t.Errorf("got %v, want the best match above the threshold", contents(results))
+	}
+}
+
+func TestCreatedWindowFilters(t *testing.T) {
+	clock := newFakeClock()
+	hm := newTestMemory(t, func(c *HypergraphConfig) { c.Clock = clock })
+	start := clock.Now()
+
+	// One memory per hour, alternating types, tagged by the hour they arrived
+	for i, content := range []string{"kettle at nine", "kettle at ten", "kettle at eleven", "kettle at noon"} {
+		memType := EpisodicMemory
+		if i%2 == 1 {
+			memType = DeclarativeMemory
+		}
+		mustAdd(t, hm, memType, content, map[string]interface{}{"half": i / 2})
+		clock.Advance(time.Hour)
+	}
+
+	hour := func(n int) time.Time { return start.Add(time.Duration(n) * time.Hour) }
+	tests := []struct {
+		name string
+		opts QueryOptions
+		want []string
+	}{
+		{"after is inclusive", QueryOptions{CreatedAfter: hour(1)},
+			[]string{"kettle at eleven", "kettle at noon", "kettle at ten"}},
+		{"before is exclusive", QueryOptions{CreatedBefore: hour(2)},
+			[]string{"kettle at nine", "kettle at ten"}},
+		{"window", QueryOptions{CreatedAfter: hour(1), CreatedBefore: hour(3)},
+			[]string{"kettle at eleven", "kettle at ten"}},
+		{"empty window", QueryOptions{CreatedAfter: hour(3), CreatedBefore: hour(3)}, []string{}},
+		{"with type", QueryOptions{Type: DeclarativeMemory, CreatedAfter: hour(1)},
+			[]string{"kettle at noon", "kettle at ten"}},
+		{"with metadata", QueryOptions{Metadata: map[string]interface{}{"half": 1}, CreatedBefore: hour(3)},
+			[]string{"kettle at eleven"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.opts.Limit = 10
+			results, err := hm.QueryWithOptions(context.Background(), "kettle", tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := contents(results)
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreatedWindowAppliesBeforeLimit(t *testing.T) {
+	clock := newFakeClock()
+	hm := newTestMemory(t, func(c *HypergraphConfig) { c.Clock = clock })
+	mustAdd(t, hm, EpisodicMemory, "kettle kettle kettle", nil)
+	mustAdd(t, hm, EpisodicMemory, "kettle on", nil)
+	clock.Advance(time.Hour)
+	mustAdd(t, hm, EpisodicMemory, "kettle whistling in the kitchen", nil)
+
+	// The best matches are outside the window, but the limit still fills from inside it
+	results, err := hm.QueryWithOptions(context.Background(), "kettle", QueryOptions{Limit: 1, CreatedAfter: clock.Now()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || results[0].Content != "kettle whistling in the kitchen" {
+		t.Errorf("got %v, want the only memory in the window", contents(results))
+	}
+}
@@ -0,0 +1,87 @@
+package vectormem
+
+import "time"
+
+// QueryRecord captures one query for auditing retrieval
+type QueryRecord struct {
+	Query     string         `json:"query"`
+	Timestamp time.Time      `json:"timestamp"`
+	Results   []ResultRecord `json:"results"`
+}
+
+// ResultRecord is one memory a query returned, with the score that ranked it
+type ResultRecord struct {
+	ID    string  `json:"id"`
+	Score float64 `json:"score"`
+}
+
+// queryLog is a fixed-size ring buffer of the latest queries
+type queryLog struct {
+	records []QueryRecord
+	next    int
+	full    bool
+}
+
+// newQueryLog creates a log holding up to size queries
+func newQueryLog(size int) *queryLog {
+	return &queryLog{records: make([]QueryRecord, size)}
+}
+
+// add records a query, overwriting the oldest once the log is full
+func (l *queryLog) add(record QueryRecord) {
+	l.records[l.next] = record
+	l.next = (l.next + 1) % len(l.records)
+	if l.next == 0 {
+		l.full = true
+	}
+}
+
+// recent returns up to n of the latest records, oldest first
+func (l *queryLog) recent(n int) []QueryRecord {
+	count := l.next
+	if l.full {
+		count = len(l.records)
+	}
+	if n < 0 || n > count {
+		n = count
+	}
+
+	recent := make([]QueryRecord, n)
+	for i := range recent {
+		idx := (l.next - n + i + len(l.records)) % len(l.records)
+		record := l.records[idx]
+		record.Results = append([]ResultRecord(nil), record.Results...)
+		recent[i] = record
+	}
+	return recent
+}
+
+// RecentQueries returns up to n of the latest logged queries in the order they
+// were issued, each with the IDs and scores of the memories it returned. A
+// negative n returns the whole log. It is empty unless QueryLogSize is set.
+func (hm *HypergraphMemory) RecentQueries(n int) []QueryRecord {
+	hm.mu.RLock()
+	defer hm.mu.RUnlock()
+
+	if hm.queryLog == nil {
+		return []QueryRecord{}
+	}
+	return hm.queryLog.recent(n)
+}
+
+// logQuery records a query and its returned results when the query log is enabled (must hold lock)
+func (hm *HypergraphMemory) logQuery(query string, results []ScoredResult) {
+	if hm.queryLog == nil {
+		return
+	}
+
+	record := QueryRecord{
+		Query:     query,
+		Timestamp: hm.clock.Now(),
+		Results:   make([]ResultRecord, len(results)),
+	}
+	for i, result := range results {
+		record.Results[i] = ResultRecord{ID: result.Memory.ID, Score: result.Score}
+	}
+	hm.queryLog.add(record)
+}
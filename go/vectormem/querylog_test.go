@@ -0,0 +1,107 @@
+package vectormem
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func newLoggedMemory(t *testing.T, size int) (*HypergraphMemory, *fakeClock) {
+	clock := newFakeClock()
+	hm := newTestMemory(t, func(c *HypergraphConfig) {
+		c.Clock = clock
+		c.QueryLogSize = size
+		c.EmbeddingFunc = wordEmbedding("sea", "salt", "wind")
+	})
+	mustAdd(t, hm, EpisodicMemory, "sea salt", nil)
+	mustAdd(t, hm, EpisodicMemory, "salt wind", nil)
+	return hm, clock
+}
+
+func loggedQueries(records []QueryRecord) []string {
+	queries := make([]string, len(records))
+	for i, r := range records {
+		queries[i] = r.Query
+	}
+	return queries
+}
+
+func TestRecentQueriesCapturesQueriesInOrder(t *testing.T) {
+	ctx := context.Background()
+	hm, clock := newLoggedMemory(t, 10)
+
+	results, err := hm.Query(ctx, "sea", "", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clock.Advance(time.Minute)
+	if _, err := hm.Query(ctx, "wind", "", 2); err != nil {
+		t.Fatal(err)
+	}
+
+	records := hm.RecentQueries(-1)
+	if got, want := loggedQueries(records), []string{"sea", "wind"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("logged queries = %v, want %v", got, want)
+	}
+	first := records[0]
+	if len(first.Results) != 1 || first.Results[0].ID != results[0].ID || first.Results[0].Score <= 0 {
+		t.Errorf("first record results = %+v, want the returned %s with its score", first.Results, results[0].ID)
+	}
+	if !records[1].Timestamp.Equal(first.Timestamp.Add(time.Minute)) {
+		t.Errorf("timestamps %v and %v, want a minute apart", first.Timestamp, records[1].Timestamp)
+	}
+	if len(records[1].Results) != 2 || records[1].Results[0].Score < records[1].Results[1].Score {
+		t.Errorf("second record results = %+v, want both memories, best first", records[1].Results)
+	}
+}
+
+func TestRecentQueriesBounded(t *testing.T) {
+	ctx := context.Background()
+	hm, _ := newLoggedMemory(t, 3)
+
+	for _, q := range []string{"one", "two", "three", "four", "five"} {
+		if _, err := hm.Query(ctx, q, "", 1); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got, want := loggedQueries(hm.RecentQueries(-1)), []string{"three", "four", "five"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("full log = %v, want %v", got, want)
+	}
+	if got, want := loggedQueries(hm.RecentQueries(2)), []string{"four", "five"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("RecentQueries(2) = %v, want %v", got, want)
+	}
+	if got := hm.RecentQueries(0); len(got) != 0 {
+		t.Errorf("RecentQueries(0) = %v, want none", loggedQueries(got))
+	}
+}
+
+func TestRecentQueriesReturnsCopies(t *testing.T) {
+	hm, _ := newLoggedMemory(t, 2)
+	if _, err := hm.Query(context.Background(), "salt", "", 2); err != nil {
+		t.Fatal(err)
+	}
+
+	records := hm.RecentQueries(1)
+	records[0].Results[0].ID = "changed"
+	if got := hm.RecentQueries(1)[0].Results[0].ID; got == "changed" {
+		t.Error("modifying a returned record changed the log")
+	}
+}
+
+func TestRecentQueriesDisabled(t *testing.T) {
+	hm, _ := newLoggedMemory(t, 0)
+	if _, err := hm.Query(context.Background(), "salt", "", 2); err != nil {
+		t.Fatal(err)
+	}
+	if got := hm.RecentQueries(-1); got == nil || len(got) != 0 {
+		t.Errorf("RecentQueries without a log = %v, want empty", got)
+	}
+
+	config := DefaultConfig()
+	config.QueryLogSize = -1
+	if _, err := NewHypergraphMemory(config); err == nil {
+		t.Error("NewHypergraphMemory accepted a negative query log size")
+	}
+}
@@ -0,0 +1,63 @@
+package vectormem
+
+import (
+	"context"
+	"testing"
+)
+
+func TestReclassifyMovesBetweenCollections(t *testing.T) {
+	hm := newTestMemory(t, nil)
+	ctx := context.Background()
+
+	mem := mustAdd(t, hm, EpisodicMemory, "water boils at 100C at sea level", nil)
+	other := mustAdd(t, hm, EpisodicMemory, "boiled the kettle this morning", nil)
+	if err := hm.Connect(mem.ID, other.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := hm.Reclassify(mem.ID, DeclarativeMemory); err != nil {
+		t.Fatal(err)
+	}
+
+	if hasContent(hm.Recent(EpisodicMemory, -1), mem.Content) {
+		t.Error("memory still in the episodic collection")
+	}
+	declarative := hm.Recent(DeclarativeMemory, -1)
+	if len(declarative) != 1 || declarative[0].ID != mem.ID || declarative[0].Type != DeclarativeMemory {
+		t.Errorf("declarative collection = %+v, want the reclassified memory", declarative)
+	}
+
+	results, err := hm.Query(ctx, "boils", DeclarativeMemory, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !hasContent(results, mem.Content) {
+		t.Errorf("typed query returned %v, want the reclassified memory", contents(results))
+	}
+
+	connected, err := hm.GetConnected(mem.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(connected) != 1 || connected[0].ID != other.ID {
+		t.Errorf("connections after reclassify = %v, want %s", contents(connected), other.ID)
+	}
+}
+
+func TestReclassifyErrors(t *testing.T) {
+	hm := newTestMemory(t, nil)
+	mem := mustAdd(t, hm, EpisodicMemory, "a walk by the harbour", nil)
+
+	if err := hm.Reclassify("missing", DeclarativeMemory); err == nil {
+		t.Error("reclassifying a missing memory succeeded")
+	}
+	if err := hm.Reclassify(mem.ID, MemoryType("dream")); err == nil {
+		t.Error("reclassifying to an unknown type succeeded")
+	}
+	if err := hm.Reclassify(mem.ID, EpisodicMemory); err != nil {
+		t.Errorf("reclassifying to the same type: %v", err)
+	}
+	if n := len(hm.Recent(EpisodicMemory, -1)); n != 1 {
+		t.Errorf("episodic collection has %d memories, want 1", n)
+	}
+}
@@ -0,0 +1,97 @@
+package vectormem
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+)
+
+// importance reads a memory's current importance
+func importance(hm *HypergraphMemory, id string) float64 {
+	hm.mu.RLock()
+	defer hm.mu.RUnlock()
+	return hm.memories[id].Importance
+}
+
+func newReinforcedMemory(t *testing.T, rate float64) (*HypergraphMemory, *fakeClock) {
+	t.Helper()
+	clock := newFakeClock()
+	hm := newTestMemory(t, func(c *HypergraphConfig) {
+		c.Clock = clock
+		c.ReinforcementRate = rate
+		c.EmbeddingFunc = wordEmbedding("kettle", "harbour")
+	})
+	return hm, clock
+}
+
+func TestQueryHitsRaiseImportance(t *testing.T) {
+	hm, clock := newReinforcedMemory(t, 0.05)
+	kettle := mustAdd(t, hm, EpisodicMemory, "kettle", nil)
+	harbour := mustAdd(t, hm, EpisodicMemory, "harbour", nil)
+
+	last := importance(hm, kettle.ID)
+	for i := 0; i < 5; i++ {
+		clock.Advance(time.Minute)
+		if _, err := hm.Query(context.Background(), "kettle", EpisodicMemory, 1); err != nil {
+			t.Fatal(err)
+		}
+		got := importance(hm, kettle.ID)
+		if got <= last {
+			t.Fatalf("query %d: importance = %v, want above %v", i+1, got, last)
+		}
+		last = got
+	}
+	if got := importance(hm, harbour.ID); got != 1.0 {
+		t.Errorf("unaccessed importance = %v, want it to stay at 1", got)
+	}
+}
+
+func TestReinforcementFavoursRecentAccess(t *testing.T) {
+	hm, clock := newReinforcedMemory(t, 0.05)
+	kettle := mustAdd(t, hm, EpisodicMemory, "kettle", nil)
+	query := func() float64 {
+		t.Helper()
+		before := importance(hm, kettle.ID)
+		if _, err := hm.Query(context.Background(), "kettle", EpisodicMemory, 1); err != nil {
+			t.Fatal(err)
+		}
+		return importance(hm, kettle.ID) - before
+	}
+
+	// Accessed just now: the full rate
+	if boost := query(); math.Abs(boost-0.05) > 1e-9 {
+		t.Errorf("boost after no gap = %v, want 0.05", boost)
+	}
+	// Nine days since the last access: a tenth of the rate
+	clock.Advance(9 * 24 * time.Hour)
+	if boost := query(); math.Abs(boost-0.005) > 1e-9 {
+		t.Errorf("boost after nine days = %v, want 0.005", boost)
+	}
+}
+
+func TestReinforcementIsCapped(t *testing.T) {
+	hm, _ := newReinforcedMemory(t, 1.0)
+	kettle := mustAdd(t, hm, EpisodicMemory, "kettle", nil)
+	for i := 0; i < 10; i++ {
+		if _, err := hm.Query(context.Background(), "kettle", EpisodicMemory, 1); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := importance(hm, kettle.ID); got != maxImportance {
+		t.Errorf("importance = %v, want capped at %v", got, maxImportance)
+	}
+}
+
+func TestReinforcementDisabledByDefault(t *testing.T) {
+	hm, _ := newReinforcedMemory(t, 0)
+	kettle := mustAdd(t, hm, EpisodicMemory, "kettle", nil)
+	for i := 0; i < 3; i++ {
+		if _, err := hm.Query(context.Background(), "kettle", EpisodicMemory, 1); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := importance(hm, kettle.ID); got != 1.0 {
+		t.Errorf("importance = %v, want 1 with reinforcement disabled", got)
+	}
+}
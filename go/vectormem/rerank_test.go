@@ -0,0 +1,82 @@
+package vectormem
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// reverse is a reranker that returns the results in reverse order
+func reverse(query string, results []ScoredResult) []ScoredResult {
+	reversed := make([]ScoredResult, len(results))
+	for i, result := range results {
+		reversed[len(results)-1-i] = result
+	}
+	return reversed
+}
+
+func newRerankMemory(t *testing.T) *HypergraphMemory {
+	t.Helper()
+	hm := newTestMemory(t, func(c *HypergraphConfig) {
+		c.EmbeddingFunc = wordEmbedding("kettle", "stove", "tea")
+	})
+	mustAdd(t, hm, EpisodicMemory, "kettle kettle kettle", nil)
+	mustAdd(t, hm, EpisodicMemory, "kettle stove", nil)
+	mustAdd(t, hm, EpisodicMemory, "kettle stove stove tea", nil)
+	return hm
+}
+
+func TestRerankerReordersBeforeLimit(t *testing.T) {
+	hm := newRerankMemory(t)
+	ctx := context.Background()
+
+	plain, err := hm.QueryWithOptions(ctx, "kettle", QueryOptions{Limit: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(contents(plain), "|"); got != "kettle kettle kettle|kettle stove|kettle stove stove tea" {
+		t.Fatalf("unranked order = %s", got)
+	}
+
+	var seen int
+	var seenQuery string
+	reranked, err := hm.QueryWithOptions(ctx, "kettle", QueryOptions{
+		Limit: 2,
+		Reranker: func(query string, results []ScoredResult) []ScoredResult {
+			seen, seenQuery = len(results), query
+			return reverse(query, results)
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if seen != 3 || seenQuery != "kettle" {
+		t.Errorf("reranker saw %d results for %q, want all 3 for kettle", seen, seenQuery)
+	}
+	// The limit applies after reranking, so the weakest matches come back
+	if got := strings.Join(contents(reranked), "|"); got != "kettle stove stove tea|kettle stove" {
+		t.Errorf("reranked results = %s, want the reversed order truncated to 2", got)
+	}
+}
+
+func TestRerankerCanDropResults(t *testing.T) {
+	hm := newRerankMemory(t)
+	results, err := hm.QueryWithOptions(context.Background(), "kettle", QueryOptions{
+		Limit: 3,
+		Reranker: func(query string, results []ScoredResult) []ScoredResult {
+			kept := results[:0]
+			for _, result := range results {
+				if !strings.Contains(result.Memory.Content, "tea") {
+					kept = append(kept, result)
+				}
+			}
+			return kept
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(contents(results), "|"); got != "kettle kettle kettle|kettle stove" {
+		t.Errorf("results = %s, want the tea memory dropped", got)
+	}
+}
@@ -0,0 +1,250 @@
+package vectormem
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"time"
+)
+
+// ShardStrategy selects how ShardedMemory assigns memories to shards
+type ShardStrategy string
+
+const (
+	// ShardByHash spreads memories evenly by a hash of their content
+	ShardByHash ShardStrategy = "hash"
+	// ShardByType keeps each memory type in one shard, so typed queries
+	// touch only that shard
+	ShardByType ShardStrategy = "type"
+)
+
+func (s ShardStrategy) valid() bool {
+	switch s {
+	case ShardByHash, ShardByType:
+		return true
+	}
+	return false
+}
+
+// ShardedConfig holds configuration for a sharded memory
+type ShardedConfig struct {
+	Shards   int           // Number of HypergraphMemory instances
+	Strategy ShardStrategy // Defaults to ShardByHash
+
+	// Shard configures every shard (defaults to DefaultConfig). A PersistPath
+	// is suffixed with the shard index so each shard saves separately, and
+	// MaxMemories is the total capacity, split evenly between the shards.
+	Shard *HypergraphConfig
+}
+
+// ShardedMemory partitions memories across several HypergraphMemory instances
+// and merges their query results by score, for sets too large to hold
+// comfortably in one. Connections never span shards, and DedupThreshold only
+// merges near-duplicates routed to the same shard: exact repeats always are
+// under ShardByHash, but similar memories with different content may not be.
+type ShardedMemory struct {
+	shards   []*HypergraphMemory
+	strategy ShardStrategy
+}
+
+// NewShardedMemory creates a sharded memory, loading each shard's saved state
+func NewShardedMemory(config *ShardedConfig) (*ShardedMemory, error) {
+	if config == nil {
+		return nil, fmt.Errorf("invalid sharded config: nil")
+	}
+	if config.Shards <= 0 {
+		return nil, fmt.Errorf("invalid shard count: %d", config.Shards)
+	}
+
+	sm := &ShardedMemory{
+		shards:   make([]*HypergraphMemory, 0, config.Shards),
+		strategy: config.Strategy,
+	}
+	if sm.strategy == "" {
+		sm.strategy = ShardByHash
+	}
+	if !sm.strategy.valid() {
+		return nil, fmt.Errorf("unknown shard strategy: %s", sm.strategy)
+	}
+
+	template := config.Shard
+	if template == nil {
+		template = DefaultConfig()
+	}
+	for i := 0; i < config.Shards; i++ {
+		shardConfig := *template
+		if shardConfig.PersistPath != "" {
+			shardConfig.PersistPath = fmt.Sprintf("%s.shard%d", template.PersistPath, i)
+		}
+		if shardConfig.MaxMemories > 0 {
+			// Round up so the shards together hold at least MaxMemories
+			shardConfig.MaxMemories = (template.MaxMemories + config.Shards - 1) / config.Shards
+		}
+		shard, err := NewHypergraphMemory(&shardConfig)
+		if err != nil {
+			sm.Stop()
+			return nil, fmt.Errorf("failed to create shard %d: %w", i, err)
+		}
+		// Shards share a clock, so the index keeps their IDs apart
+		shard.idPrefix = fmt.Sprintf("shard%d_", i)
+		sm.shards = append(sm.shards, shard)
+	}
+
+	return sm, nil
+}
+
+// shardFor returns the index of the shard that stores a memory
+func (sm *ShardedMemory) shardFor(memType MemoryType, content string) int {
+	key := content
+	if sm.strategy == ShardByType {
+		key = string(memType)
+	}
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return int(h.Sum32() % uint32(len(sm.shards)))
+}
+
+// Add adds a new memory to the shard it routes to and returns a view of it
+func (sm *ShardedMemory) Add(ctx context.Context, memType MemoryType, content string, metadata map[string]interface{}) (MemoryView, error) {
+	return sm.shards[sm.shardFor(memType, content)].Add(ctx, memType, content, metadata)
+}
+
+// Query searches every shard that may hold matching memories and returns the
+// overall best limit results, ranked exactly as a single HypergraphMemory
+// holding all the memories would rank them. All searched shards stay locked
+// for the duration so the merged results are consistent.
+func (sm *ShardedMemory) Query(ctx context.Context, query string, memType MemoryType, limit int) ([]MemoryView, error) {
+	if limit < 0 {
+		return nil, fmt.Errorf("invalid limit: %d", limit)
+	}
+
+	shards := sm.shards
+	if sm.strategy == ShardByType && memType != "" {
+		shards = []*HypergraphMemory{sm.shards[sm.shardFor(memType, "")]}
+	}
+
+	for _, shard := range shards {
+		shard.mu.Lock()
+		defer shard.mu.Unlock()
+	}
+
+	start := time.Now()
+	defer func() {
+		for _, shard := range shards {
+			shard.recordQueryLatency(start)
+		}
+	}()
+
+	// Shards share one configuration, so the first embeds for all of them
+	queryEmbedding, err := shards[0].embedQuery(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	for _, shard := range shards[1:] {
+		if queryEmbedding == nil {
+			break
+		}
+		if err := shard.checkEmbeddingDim(queryEmbedding); err != nil {
+			return nil, err
+		}
+	}
+
+	opts := QueryOptions{Type: memType, Limit: limit}
+	owner := make(map[*Memory]*HypergraphMemory)
+	var merged []ScoredResult
+	for _, shard := range shards {
+		scored := shard.scoreMemories(queryEmbedding, query, opts)
+		if len(scored) > limit {
+			scored = scored[:limit]
+		}
+		for _, result := range scored {
+			owner[result.Memory] = shard
+		}
+		merged = append(merged, scored...)
+	}
+
+	// Same ordering as scoreMemories, so ties resolve as in a single shard
+	sort.Slice(merged, func(i, j int) bool {
+		if merged[i].Score != merged[j].Score {
+			return merged[i].Score > merged[j].Score
+		}
+		return merged[i].Memory.ID < merged[j].Memory.ID
+	})
+	if len(merged) > limit {
+		merged = merged[:limit]
+	}
+
+	// Update access statistics within each shard that supplied results
+	byShard := make(map[*HypergraphMemory][]ScoredResult)
+	for _, result := range merged {
+		shard := owner[result.Memory]
+		byShard[shard] = append(byShard[shard], result)
+	}
+	for _, shard := range shards {
+		results := byShard[shard]
+		memories := make([]*Memory, len(results))
+		for i, result := range results {
+			memories[i] = result.Memory
+		}
+		shard.markRetrieved(memories)
+		shard.logQuery(query, results)
+	}
+
+	results := make([]MemoryView, len(merged))
+	for i, result := range merged {
+		results[i] = result.Memory.view()
+	}
+	return results, nil
+}
+
+// Len returns the total number of memories across all shards
+func (sm *ShardedMemory) Len() int {
+	total := 0
+	for _, shard := range sm.shards {
+		shard.mu.RLock()
+		total += len(shard.memories)
+		shard.mu.RUnlock()
+	}
+	return total
+}
+
+// Save persists each shard to its own path, continuing past failures and
+// returning the first
+func (sm *ShardedMemory) Save() error {
+	var firstErr error
+	for i, shard := range sm.shards {
+		if err := shard.Save(); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("failed to save shard %d: %w", i, err)
+		}
+	}
+	return firstErr
+}
+
+// Start begins background maintenance on every shard
+func (sm *ShardedMemory) Start(ctx context.Context) error {
+	for _, shard := range sm.shards {
+		if err := shard.Start(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Stop stops background maintenance on every shard
+func (sm *ShardedMemory) Stop() {
+	for _, shard := range sm.shards {
+		shard.Stop()
+	}
+}
+
+// Close closes every shard, saving any unsaved changes
+func (sm *ShardedMemory) Close() error {
+	var firstErr error
+	for i, shard := range sm.shards {
+		if err := shard.Close(); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("failed to close shard %d: %w", i, err)
+		}
+	}
+	return firstErr
+}
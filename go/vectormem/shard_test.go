@@ -0,0 +1,217 @@
+package vectormem
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+)
+
+// fanEmbeddings spreads n memories over a quarter circle, so "m00" is closest
+// to the query "north" and each later memory is strictly less similar
+func fanEmbeddings(n int) map[string][]float32 {
+	table := map[string][]float32{"north": {1, 0}}
+	for i := 0; i < n; i++ {
+		angle := float64(i) * math.Pi / 2 / float64(n)
+		table[fmt.Sprintf("m%02d", i)] = []float32{float32(math.Cos(angle)), float32(math.Sin(angle))}
+	}
+	return table
+}
+
+func newTestSharded(t *testing.T, shards int, strategy ShardStrategy, configure func(*HypergraphConfig)) *ShardedMemory {
+	t.Helper()
+	config := DefaultConfig()
+	if configure != nil {
+		configure(config)
+	}
+	sm, err := NewShardedMemory(&ShardedConfig{Shards: shards, Strategy: strategy, Shard: config})
+	if err != nil {
+		t.Fatalf("NewShardedMemory: %v", err)
+	}
+	t.Cleanup(sm.Stop)
+	return sm
+}
+
+func TestShardedQueryReturnsGlobalTopK(t *testing.T) {
+	ctx := context.Background()
+	const n = 20
+	embed := func(c *HypergraphConfig) { c.EmbeddingFunc = tableEmbedding(fanEmbeddings(n)) }
+	sm := newTestSharded(t, 4, ShardByHash, embed)
+	single := newTestMemory(t, embed)
+
+	for i := 0; i < n; i++ {
+		content := fmt.Sprintf("m%02d", i)
+		if _, err := sm.Add(ctx, EpisodicMemory, content, nil); err != nil {
+			t.Fatal(err)
+		}
+		mustAdd(t, single, EpisodicMemory, content, nil)
+	}
+	if sm.Len() != n {
+		t.Fatalf("Len = %d, want %d", sm.Len(), n)
+	}
+
+	got, err := sm.Query(ctx, "north", "", 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"m00", "m01", "m02", "m03", "m04"}
+	if !reflect.DeepEqual(contents(got), want) {
+		t.Errorf("sharded Query = %v, want %v", contents(got), want)
+	}
+	expected, err := single.Query(ctx, "north", "", 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(contents(got), contents(expected)) {
+		t.Errorf("sharded Query = %v, single memory = %v", contents(got), contents(expected))
+	}
+
+	// The merge only matters if the winners live in different shards
+	shards := make(map[int]bool)
+	for _, content := range want {
+		shards[sm.shardFor(EpisodicMemory, content)] = true
+	}
+	if len(shards) < 2 {
+		t.Error("top results all routed to one shard, leaving the merge untested")
+	}
+}
+
+func TestShardedQueryLimitBeyondSize(t *testing.T) {
+	ctx := context.Background()
+	sm := newTestSharded(t, 3, ShardByHash, func(c *HypergraphConfig) {
+		c.EmbeddingFunc = tableEmbedding(fanEmbeddings(4))
+	})
+	for i := 0; i < 4; i++ {
+		if _, err := sm.Add(ctx, EpisodicMemory, fmt.Sprintf("m%02d", i), nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, err := sm.Query(ctx, "north", "", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"m00", "m01", "m02", "m03"}; !reflect.DeepEqual(contents(got), want) {
+		t.Errorf("Query = %v, want every memory in order %v", contents(got), want)
+	}
+}
+
+func TestShardByTypeKeepsTypesTogether(t *testing.T) {
+	ctx := context.Background()
+	sm := newTestSharded(t, 4, ShardByType, nil)
+
+	for i := 0; i < 3; i++ {
+		if _, err := sm.Add(ctx, EpisodicMemory, fmt.Sprintf("walk by the river %d", i), nil); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := sm.Add(ctx, DeclarativeMemory, fmt.Sprintf("rivers flow downhill %d", i), nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, memType := range []MemoryType{EpisodicMemory, DeclarativeMemory} {
+		shard := sm.shards[sm.shardFor(memType, "")]
+		shard.mu.RLock()
+		held := len(shard.collections[memType])
+		shard.mu.RUnlock()
+		if held != 3 {
+			t.Errorf("%s shard holds %d %s memories, want all 3", memType, held, memType)
+		}
+	}
+
+	got, err := sm.Query(ctx, "river", EpisodicMemory, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 3 {
+		t.Fatalf("typed Query returned %d memories, want 3", len(got))
+	}
+	for _, v := range got {
+		if v.Type != EpisodicMemory {
+			t.Errorf("typed Query returned %s memory %q", v.Type, v.Content)
+		}
+	}
+}
+
+func TestShardedCapacitySplit(t *testing.T) {
+	sm := newTestSharded(t, 3, ShardByHash, func(c *HypergraphConfig) {
+		c.MaxMemories = 10
+	})
+	for i, shard := range sm.shards {
+		if shard.maxMemories != 4 {
+			t.Errorf("shard %d capacity = %d, want 4 so the shards hold at least 10", i, shard.maxMemories)
+		}
+	}
+}
+
+func TestShardedPersistence(t *testing.T) {
+	ctx := context.Background()
+	store := &memoryStore{}
+	configure := func(c *HypergraphConfig) {
+		c.PersistPath = "memories"
+		c.Store = store
+	}
+	sm := newTestSharded(t, 3, ShardByHash, configure)
+	for i := 0; i < 9; i++ {
+		if _, err := sm.Add(ctx, EpisodicMemory, fmt.Sprintf("memory number %d", i), nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := sm.Save(); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		if _, ok := store.data[fmt.Sprintf("memories.shard%d", i)]; !ok {
+			t.Errorf("shard %d not saved under memories.shard%d", i, i)
+		}
+	}
+
+	loaded := newTestSharded(t, 3, ShardByHash, configure)
+	if loaded.Len() != 9 {
+		t.Errorf("reloaded %d memories, want 9", loaded.Len())
+	}
+}
+
+func TestShardedConfigErrors(t *testing.T) {
+	for _, config := range []*ShardedConfig{
+		nil,
+		{Shards: 0},
+		{Shards: 2, Strategy: "round-robin"},
+	} {
+		if _, err := NewShardedMemory(config); err == nil {
+			t.Errorf("NewShardedMemory(%+v) succeeded, want an error", config)
+		}
+	}
+}
+
+func TestShardedQueryNegativeLimit(t *testing.T) {
+	sm := newTestSharded(t, 2, ShardByHash, nil)
+	if _, err := sm.Add(context.Background(), EpisodicMemory, "alpha", nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sm.Query(context.Background(), "alpha", "", -1); err == nil {
+		t.Error("Query with a negative limit succeeded, want an error")
+	}
+}
+
+func TestShardedIDsUniqueUnderFixedClock(t *testing.T) {
+	ctx := context.Background()
+	sm := newTestSharded(t, 4, ShardByHash, func(c *HypergraphConfig) { c.Clock = newFakeClock() })
+
+	seen := make(map[string]string)
+	for i := 0; i < 40; i++ {
+		content := fmt.Sprintf("memory %d", i)
+		mem, err := sm.Add(ctx, EpisodicMemory, content, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if prev, ok := seen[mem.ID]; ok {
+			t.Fatalf("%q and %q share ID %s", prev, content, mem.ID)
+		}
+		seen[mem.ID] = content
+	}
+	if n := sm.Len(); n != 40 {
+		t.Errorf("Len = %d, want 40", n)
+	}
+}
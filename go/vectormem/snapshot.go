@@ -0,0 +1,35 @@
+package vectormem
+
+import "sort"
+
+// memorySnapshot is a point-in-time view of a memory's immutable content, letting
+// long-running maintenance work outside the lock and re-apply results with short locks
+type memorySnapshot struct {
+	id        string
+	memType   MemoryType
+	content   string
+	embedding []float32 // Shared with the memory; embeddings are replaced, never modified in place
+}
+
+// snapshotMemories captures the unexpired memories in ID order under a brief read lock
+func (hm *HypergraphMemory) snapshotMemories() []memorySnapshot {
+	hm.mu.RLock()
+	defer hm.mu.RUnlock()
+
+	now := hm.clock.Now()
+	snapshot := make([]memorySnapshot, 0, len(hm.memories))
+	for _, mem := range hm.memories {
+		if mem.expired(now) {
+			continue
+		}
+		snapshot = append(snapshot, memorySnapshot{
+			id:        mem.ID,
+			memType:   mem.Type,
+			content:   mem.Content,
+			embedding: mem.vector(),
+		})
+	}
+
+	sort.Slice(snapshot, func(i, j int) bool { return snapshot[i].id < snapshot[j].id })
+	return snapshot
+}
@@ -0,0 +1,90 @@
+package vectormem
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestQueriesProceedDuringReembed(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	embed := wordEmbedding("kettle", "stove", "harbour")
+	hm := newTestMemory(t, func(c *HypergraphConfig) {
+		c.EmbeddingFunc = embed
+		c.BatchEmbeddingFunc = func(ctx context.Context, texts []string) ([][]float32, error) {
+			close(started)
+			<-release
+			out := make([][]float32, len(texts))
+			for i, text := range texts {
+				out[i], _ = embed(ctx, text)
+			}
+			return out, nil
+		}
+	})
+	mustAdd(t, hm, EpisodicMemory, "kettle on the stove", nil)
+	mustAdd(t, hm, EpisodicMemory, "boats in the harbour", nil)
+
+	type result struct {
+		n   int
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		n, err := hm.ReembedAll(context.Background())
+		done <- result{n, err}
+	}()
+	<-started
+
+	// The slow embedding holds no lock, so queries and inserts still succeed
+	queried := make(chan error, 1)
+	go func() {
+		if _, err := hm.Add(context.Background(), EpisodicMemory, "kettle whistles", nil); err != nil {
+			queried <- err
+			return
+		}
+		_, err := hm.Query(context.Background(), "kettle", EpisodicMemory, 5)
+		queried <- err
+	}()
+	select {
+	case err := <-queried:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("query blocked behind ReembedAll")
+	}
+
+	close(release)
+	res := <-done
+	if res.err != nil {
+		t.Fatal(res.err)
+	}
+	// Only the memories in the snapshot are re-embedded
+	if res.n != 2 {
+		t.Errorf("ReembedAll updated %d memories, want the 2 in its snapshot", res.n)
+	}
+}
+
+func TestSnapshotMemoriesSkipsExpired(t *testing.T) {
+	clock := newFakeClock()
+	hm := newTestMemory(t, func(c *HypergraphConfig) { c.Clock = clock })
+	keep := mustAdd(t, hm, EpisodicMemory, "kept", nil)
+	if _, err := hm.AddWithTTL(context.Background(), EpisodicMemory, "fleeting", nil, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	other := mustAdd(t, hm, DeclarativeMemory, "also kept", nil)
+	clock.Advance(time.Hour)
+
+	snapshot := hm.snapshotMemories()
+	if len(snapshot) != 2 {
+		t.Fatalf("snapshot has %d memories, want 2", len(snapshot))
+	}
+	want := []string{keep.ID, other.ID}
+	if want[1] < want[0] {
+		want[0], want[1] = want[1], want[0]
+	}
+	if snapshot[0].id != want[0] || snapshot[1].id != want[1] {
+		t.Errorf("snapshot order = %s, %s; want %v", snapshot[0].id, snapshot[1].id, want)
+	}
+}
@@ -0,0 +1,43 @@
+package vectormem
+
+import (
+	"context"
+	"sort"
+)
+
+// provenance records where a memory came from
+type provenance struct {
+	source    string
+	sourceRef string
+}
+
+// AddFromSource adds a memory like Add, recording the source it was ingested
+// from (e.g. "chat", "docs", "web") and a reference within that source such as
+// a URL or message ID. When the memory merges into an existing duplicate, the
+// duplicate keeps its own source unless it had none.
+func (hm *HypergraphMemory) AddFromSource(ctx context.Context, memType MemoryType, content string, metadata map[string]interface{}, source, sourceRef string) (MemoryView, error) {
+	return hm.add(ctx, memType, content, metadata, nil, provenance{source: source, sourceRef: sourceRef})
+}
+
+// QueryBySource returns views of the unexpired memories ingested from a
+// source, newest first
+func (hm *HypergraphMemory) QueryBySource(source string) []MemoryView {
+	hm.mu.RLock()
+	defer hm.mu.RUnlock()
+
+	now := hm.clock.Now()
+	found := make([]MemoryView, 0)
+	for _, mem := range hm.memories {
+		if mem.Source == source && !mem.expired(now) {
+			found = append(found, mem.view())
+		}
+	}
+
+	sort.Slice(found, func(i, j int) bool {
+		if !found[i].CreatedAt.Equal(found[j].CreatedAt) {
+			return found[i].CreatedAt.After(found[j].CreatedAt)
+		}
+		return found[i].ID < found[j].ID
+	})
+	return found
+}
@@ -0,0 +1,104 @@
+package vectormem
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/o9nn/un9n/go/persist"
+)
+
+func TestQueryBySource(t *testing.T) {
+	ctx := context.Background()
+	clock := newFakeClock()
+	hm := newTestMemory(t, func(c *HypergraphConfig) { c.Clock = clock })
+
+	if _, err := hm.AddFromSource(ctx, EpisodicMemory, "said hello", nil, "chat", "msg-1"); err != nil {
+		t.Fatal(err)
+	}
+	clock.Advance(time.Minute)
+	if _, err := hm.AddFromSource(ctx, DeclarativeMemory, "water boils at 100C", nil, "docs", "https://example.com/water"); err != nil {
+		t.Fatal(err)
+	}
+	clock.Advance(time.Minute)
+	if _, err := hm.AddFromSource(ctx, EpisodicMemory, "said goodbye", nil, "chat", "msg-2"); err != nil {
+		t.Fatal(err)
+	}
+	plain := mustAdd(t, hm, EpisodicMemory, "had a thought", nil)
+
+	chat := hm.QueryBySource("chat")
+	if got, want := contents(chat), []string{"said goodbye", "said hello"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("chat memories = %v, want newest first %v", got, want)
+	}
+	if chat[0].Source != "chat" || chat[0].SourceRef != "msg-2" {
+		t.Errorf("chat view provenance = %q/%q, want chat/msg-2", chat[0].Source, chat[0].SourceRef)
+	}
+	if got := contents(hm.QueryBySource("docs")); !reflect.DeepEqual(got, []string{"water boils at 100C"}) {
+		t.Errorf("docs memories = %v", got)
+	}
+	if got := hm.QueryBySource("web"); len(got) != 0 {
+		t.Errorf("web memories = %v, want none", contents(got))
+	}
+	if got := hm.QueryBySource(""); len(got) != 1 || got[0].ID != plain.ID {
+		t.Errorf("sourceless memories = %v, want only %q", contents(got), plain.Content)
+	}
+}
+
+func TestSourceSurvivesSaveLoad(t *testing.T) {
+	ctx := context.Background()
+	for _, format := range []persist.Format{persist.FormatJSON, persist.FormatGob} {
+		t.Run(string(format), func(t *testing.T) {
+			store := &memoryStore{}
+			configure := func(c *HypergraphConfig) {
+				c.Clock = newFakeClock()
+				c.PersistPath = "memories"
+				c.Store = store
+				c.Format = format
+			}
+
+			hm := newTestMemory(t, configure)
+			mem, err := hm.AddFromSource(ctx, DeclarativeMemory, "the moon is drifting away", nil, "web", "https://example.com/moon")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := hm.Save(); err != nil {
+				t.Fatal(err)
+			}
+
+			loaded := newTestMemory(t, configure)
+			got := loaded.QueryBySource("web")
+			if len(got) != 1 || got[0].ID != mem.ID {
+				t.Fatalf("web memories after load = %v, want %s", contents(got), mem.ID)
+			}
+			if got[0].SourceRef != "https://example.com/moon" {
+				t.Errorf("source ref after load = %q", got[0].SourceRef)
+			}
+		})
+	}
+}
+
+func TestDuplicateKeepsFirstSource(t *testing.T) {
+	ctx := context.Background()
+	hm := newTestMemory(t, func(c *HypergraphConfig) {
+		c.DedupThreshold = 0.95
+		c.EmbeddingFunc = wordEmbedding("tide", "moon")
+	})
+
+	first := mustAdd(t, hm, EpisodicMemory, "the tide and the moon", nil)
+	if _, err := hm.AddFromSource(ctx, EpisodicMemory, "the tide and the moon", nil, "chat", "msg-1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := hm.AddFromSource(ctx, EpisodicMemory, "the tide and the moon", nil, "docs", "page-3"); err != nil {
+		t.Fatal(err)
+	}
+
+	// A sourceless memory takes the first source that repeats it
+	got := hm.QueryBySource("chat")
+	if len(got) != 1 || got[0].ID != first.ID || got[0].SourceRef != "msg-1" {
+		t.Errorf("chat memories = %+v, want %s from msg-1", got, first.ID)
+	}
+	if got := hm.QueryBySource("docs"); len(got) != 0 {
+		t.Errorf("docs memories = %v, want the duplicate to keep its chat source", contents(got))
+	}
+}
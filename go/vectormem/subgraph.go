@@ -0,0 +1,101 @@
+package vectormem
+
+import "fmt"
+
+// Subgraph returns a new, unpersisted memory containing copies of the memories of
+// the given type (or all types when memType is empty) whose importance is at least
+// minImportance. Connections to memories outside the subgraph are dropped. The
+// subgraph publishes no events and only consolidates on ConsolidateNow, so it
+// needs no Close.
+func (hm *HypergraphMemory) Subgraph(memType MemoryType, minImportance float64) (*HypergraphMemory, error) {
+	hm.mu.RLock()
+	defer hm.mu.RUnlock()
+
+	config := hm.config
+	config.PersistPath = ""
+	config.Store = nil
+	config.Events = nil
+	config.ConsolidationPolicy = ConsolidateManual
+	sub, err := NewHypergraphMemory(&config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create subgraph: %w", err)
+	}
+
+	now := hm.clock.Now()
+	for id, mem := range hm.memories {
+		if memType != "" && mem.Type != memType {
+			continue
+		}
+		if mem.Importance < minImportance || mem.expired(now) {
+			continue
+		}
+		sub.memories[id] = mem.clone()
+	}
+
+	// Restrict connections to nodes present in the subgraph
+	for _, mem := range sub.memories {
+		kept := make([]string, 0, len(mem.Connections))
+		for _, connID := range mem.Connections {
+			if _, ok := sub.memories[connID]; ok {
+				kept = append(kept, connID)
+			}
+		}
+		mem.Connections = kept
+		sub.collections[mem.Type] = append(sub.collections[mem.Type], mem)
+	}
+	sub.rebuildIndex()
+
+	return sub, nil
+}
+
+// clone returns a deep copy of the memory
+func (m *Memory) clone() *Memory {
+	c := *m
+	if m.Embedding != nil {
+		c.Embedding = append([]float32(nil), m.Embedding...)
+	}
+	if m.Quantized != nil {
+		c.Quantized = &QuantizedEmbedding{
+			Values: append([]int8(nil), m.Quantized.Values...),
+			Scale:  m.Quantized.Scale,
+		}
+	}
+	if m.Metadata != nil {
+		c.Metadata = copyMetadata(m.Metadata)
+	}
+	c.Connections = append([]string(nil), m.Connections...)
+	if m.ExpiresAt != nil {
+		expiresAt := *m.ExpiresAt
+		c.ExpiresAt = &expiresAt
+	}
+	return &c
+}
+
+// copyMetadata deep-copies metadata, including nested maps and slices, so a
+// clone never shares mutable state with the original
+func copyMetadata(metadata map[string]interface{}) map[string]interface{} {
+	c := make(map[string]interface{}, len(metadata))
+	for k, v := range metadata {
+		c[k] = copyMetadataValue(v)
+	}
+	return c
+}
+
+// copyMetadataValue deep-copies a metadata value of the shapes produced by
+// JSON decoding; other values are copied as is
+func copyMetadataValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		return copyMetadata(v)
+	case []interface{}:
+		c := make([]interface{}, len(v))
+		for i, elem := range v {
+			c[i] = copyMetadataValue(elem)
+		}
+		return c
+	case []string:
+		return append([]string(nil), v...)
+	default:
+		return v
+	}
+}
@@ -0,0 +1,90 @@
+package vectormem
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestSubgraphSelectsAndPrunes(t *testing.T) {
+	store := &memoryStore{}
+	hm := newTestMemory(t, func(c *HypergraphConfig) {
+		c.PersistPath = "memories"
+		c.Store = store
+	})
+
+	fact := mustAdd(t, hm, DeclarativeMemory, "water boils at 100C", nil)
+	related := mustAdd(t, hm, DeclarativeMemory, "steam is water vapour", nil)
+	minor := mustAdd(t, hm, DeclarativeMemory, "the kettle is blue", nil)
+	episode := mustAdd(t, hm, EpisodicMemory, "boiled the kettle this morning", nil)
+	for _, pair := range [][2]string{{fact.ID, related.ID}, {fact.ID, minor.ID}, {fact.ID, episode.ID}} {
+		if err := hm.Connect(pair[0], pair[1]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, id := range []string{fact.ID, related.ID, episode.ID} {
+		if err := hm.RecordFeedback(id, true); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	sub, err := hm.Subgraph(DeclarativeMemory, 1.05)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(sub.Stop)
+
+	got := contents(sub.Recent("", -1))
+	sort.Strings(got)
+	want := []string{"steam is water vapour", "water boils at 100C"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Fatalf("subgraph holds %v, want %v", got, want)
+	}
+
+	// Edges to the unimportant and episodic memories are pruned
+	connected, err := sub.GetConnected(fact.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(connected) != 1 || connected[0].ID != related.ID {
+		t.Errorf("subgraph connections of %s = %v, want only %s", fact.ID, contents(connected), related.ID)
+	}
+
+	// The original graph keeps all its edges
+	connected, err = hm.GetConnected(fact.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(connected) != 3 {
+		t.Errorf("original connections = %v, want 3", contents(connected))
+	}
+}
+
+func TestSubgraphIsIndependent(t *testing.T) {
+	store := &memoryStore{}
+	hm := newTestMemory(t, func(c *HypergraphConfig) {
+		c.PersistPath = "memories"
+		c.Store = store
+	})
+	mem := mustAdd(t, hm, EpisodicMemory, "a walk by the harbour", nil)
+
+	sub, err := hm.Subgraph("", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(sub.Stop)
+
+	if err := sub.RecordFeedback(mem.ID, true); err != nil {
+		t.Fatal(err)
+	}
+	if got := hm.Recent("", 1)[0].Importance; got != 1.0 {
+		t.Errorf("original importance = %v after changing the subgraph, want 1.0", got)
+	}
+
+	// A subgraph is never persisted, even over the original's store
+	if err := sub.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if len(store.data) != 0 {
+		t.Errorf("subgraph Save wrote %d keys, want none", len(store.data))
+	}
+}
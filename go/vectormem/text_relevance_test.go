@@ -0,0 +1,56 @@
+package vectormem
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestTextModeRanking(t *testing.T) {
+	hm := newTestMemory(t, nil)
+	for _, content := range []string{
+		"a walk by the harbour",
+		"the kettle whistles",
+		"I left the kettle on the stove all morning while reading the paper",
+		"kettle on stove",
+	} {
+		mustAdd(t, hm, EpisodicMemory, content, nil)
+	}
+
+	results, err := hm.Query(context.Background(), "Kettle, stove?", EpisodicMemory, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Full matches beat partial ones regardless of length; the tighter full
+	// match wins the tie, and unrelated memories come last
+	want := []string{
+		"kettle on stove",
+		"I left the kettle on the stove all morning while reading the paper",
+		"the kettle whistles",
+		"a walk by the harbour",
+	}
+	if got := contents(results); !reflect.DeepEqual(got, want) {
+		t.Errorf("ranking = %v, want %v", got, want)
+	}
+}
+
+func TestTextRelevanceMatchesEmbeddingScale(t *testing.T) {
+	// An exact match scores 1 on either path, and nothing scores outside [0, 1]
+	if got := textRelevance("kettle on stove", "Kettle on stove."); got != 1 {
+		t.Errorf("exact text match = %v, want 1", got)
+	}
+	if got := textRelevance("kettle", "a walk by the harbour"); got != 0 {
+		t.Errorf("unrelated text = %v, want 0", got)
+	}
+	if got := textRelevance("", "anything"); got != 0 {
+		t.Errorf("empty query = %v, want 0", got)
+	}
+
+	hm := newTestMemory(t, func(c *HypergraphConfig) {
+		c.EmbeddingFunc = wordEmbedding("kettle", "stove")
+	})
+	mem := mustAdd(t, hm, EpisodicMemory, "kettle stove", nil)
+	if got := hm.relevance([]float32{1, 1}, "kettle stove", hm.memories[mem.ID]); got < 0.9999 || got > 1 {
+		t.Errorf("identical embedding relevance = %v, want 1", got)
+	}
+}
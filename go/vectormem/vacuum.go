@@ -0,0 +1,26 @@
+package vectormem
+
+// Vacuum removes connection IDs that point to memories that no longer exist,
+// such as those left behind by a partial save, and returns how many were removed
+func (hm *HypergraphMemory) Vacuum() int {
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+	return hm.vacuum()
+}
+
+// vacuum strips dangling connection IDs across the graph (must hold lock)
+func (hm *HypergraphMemory) vacuum() int {
+	removed := 0
+	for _, mem := range hm.memories {
+		kept := mem.Connections[:0]
+		for _, connID := range mem.Connections {
+			if _, ok := hm.memories[connID]; ok {
+				kept = append(kept, connID)
+			} else {
+				removed++
+			}
+		}
+		mem.Connections = kept
+	}
+	return removed
+}
@@ -0,0 +1,73 @@
+package vectormem
+
+import (
+	"testing"
+
+	"github.com/o9nn/un9n/go/persist"
+)
+
+func TestLoadVacuumsDanglingConnections(t *testing.T) {
+	created := newFakeClock().Now()
+	saved := map[string]*Memory{
+		"episodic_1": {ID: "episodic_1", Type: EpisodicMemory, Content: "kettle on", Connections: []string{"episodic_2", "episodic_gone"}, CreatedAt: created, AccessedAt: created, Importance: 1, Decay: 1},
+		"episodic_2": {ID: "episodic_2", Type: EpisodicMemory, Content: "kettle whistles", Connections: []string{"episodic_1"}, CreatedAt: created, AccessedAt: created, Importance: 1, Decay: 1},
+		"episodic_3": {ID: "episodic_3", Type: EpisodicMemory, Content: "tea steeping", Connections: []string{"episodic_gone", "declarative_gone"}, CreatedAt: created, AccessedAt: created, Importance: 1, Decay: 1},
+	}
+	data, err := persist.Encode(saved, persist.FormatJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := &memoryStore{data: map[string][]byte{"memories": data}}
+	logger := &recordingLogger{}
+
+	hm := newTestMemory(t, func(c *HypergraphConfig) {
+		c.Clock = newFakeClock()
+		c.PersistPath = "memories"
+		c.Store = store
+		c.Logger = logger
+	})
+
+	connections := make(map[string][]string)
+	hm.Walk(func(mem MemoryView, neighbors []MemoryView) bool {
+		connections[mem.ID] = mem.Connections
+		return true
+	})
+	if got := connections["episodic_1"]; len(got) != 1 || got[0] != "episodic_2" {
+		t.Errorf("episodic_1 connections = %v, want [episodic_2]", got)
+	}
+	if got := connections["episodic_3"]; len(got) != 0 {
+		t.Errorf("episodic_3 connections = %v, want none", got)
+	}
+
+	warnings := logger.find("removed dangling connections")
+	if len(warnings) != 1 {
+		t.Fatalf("got %d dangling-connection warnings, want 1", len(warnings))
+	}
+	if got, _ := warnings[0].value("count"); got != 3 {
+		t.Errorf("warning count = %v, want 3", got)
+	}
+}
+
+func TestVacuum(t *testing.T) {
+	hm := newTestMemory(t, nil)
+	keep := mustAdd(t, hm, EpisodicMemory, "kettle on", nil)
+	other := mustAdd(t, hm, EpisodicMemory, "kettle whistles", nil)
+	if err := hm.Connect(keep.ID, other.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := hm.Vacuum(); n != 0 {
+		t.Errorf("Vacuum on a clean graph removed %d, want 0", n)
+	}
+
+	// Simulate a stale reference left behind by a partial save
+	hm.mu.Lock()
+	hm.memories[keep.ID].Connections = append(hm.memories[keep.ID].Connections, "episodic_gone")
+	hm.mu.Unlock()
+	if n := hm.Vacuum(); n != 1 {
+		t.Errorf("Vacuum removed %d, want 1", n)
+	}
+	if n := hm.Vacuum(); n != 0 {
+		t.Errorf("second Vacuum removed %d, want 0", n)
+	}
+}
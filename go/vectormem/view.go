@@ -0,0 +1,62 @@
+package vectormem
+
+import "time"
+
+// MemoryView is a read-only copy of a memory returned by queries, other read
+// APIs, and the Add family. Changing a view never affects the stored memory, and
+// views may be used freely after the call returns without racing with the
+// memory's lock.
+type MemoryView struct {
+	ID          string                 `json:"id"`
+	Type        MemoryType             `json:"type"`
+	Content     string                 `json:"content"`
+	Embedding   []float32              `json:"embedding,omitempty"` // Dequantized when quantization is enabled
+	Metadata    map[string]interface{} `json:"metadata"`
+	Connections []string               `json:"connections"`
+	CreatedAt   time.Time              `json:"created_at"`
+	AccessedAt  time.Time              `json:"accessed_at"`
+	AccessCount int                    `json:"access_count"`
+	Importance  float64                `json:"importance"`
+	Decay       float64                `json:"decay"`
+	ExpiresAt   *time.Time             `json:"expires_at,omitempty"`
+	Useful      int                    `json:"useful,omitempty"`
+	Unhelpful   int                    `json:"unhelpful,omitempty"`
+	Source      string                 `json:"source,omitempty"`
+	SourceRef   string                 `json:"source_ref,omitempty"`
+}
+
+// view copies a memory into a MemoryView (must hold lock)
+func (m *Memory) view() MemoryView {
+	c := m.clone()
+	embedding := c.Embedding
+	if embedding == nil && c.Quantized != nil {
+		embedding = c.Quantized.dequantize()
+	}
+	return MemoryView{
+		ID:          c.ID,
+		Type:        c.Type,
+		Content:     c.Content,
+		Embedding:   embedding,
+		Metadata:    c.Metadata,
+		Connections: c.Connections,
+		CreatedAt:   c.CreatedAt,
+		AccessedAt:  c.AccessedAt,
+		AccessCount: c.AccessCount,
+		Importance:  c.Importance,
+		Decay:       c.Decay,
+		ExpiresAt:   c.ExpiresAt,
+		Useful:      c.Useful,
+		Unhelpful:   c.Unhelpful,
+		Source:      c.Source,
+		SourceRef:   c.SourceRef,
+	}
+}
+
+// views copies memories into MemoryViews, preserving order (must hold lock)
+func views(memories []*Memory) []MemoryView {
+	result := make([]MemoryView, len(memories))
+	for i, mem := range memories {
+		result[i] = mem.view()
+	}
+	return result
+}
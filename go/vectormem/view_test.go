@@ -0,0 +1,145 @@
+package vectormem
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// scribble mutates every field of a view a caller could reach into
+func scribble(v MemoryView) {
+	v.Content = "scribbled"
+	if v.Metadata != nil {
+		v.Metadata["mood"] = "scribbled"
+	}
+	for i := range v.Connections {
+		v.Connections[i] = "scribbled"
+	}
+	for i := range v.Embedding {
+		v.Embedding[i] = -1
+	}
+	if v.ExpiresAt != nil {
+		*v.ExpiresAt = time.Time{}
+	}
+}
+
+// snapshotViews serializes every memory as seen through Walk, leaving out
+// the access statistics queries update
+func snapshotViews(t *testing.T, hm *HypergraphMemory) map[string]string {
+	seen := make(map[string]string)
+	hm.Walk(func(mem MemoryView, neighbors []MemoryView) bool {
+		mem.AccessCount, mem.AccessedAt, mem.Decay = 0, time.Time{}, 0
+		data, err := json.Marshal(mem)
+		if err != nil {
+			t.Fatal(err)
+		}
+		seen[mem.ID] = string(data)
+		return true
+	})
+	return seen
+}
+
+func TestMutatingViewsLeavesMemoryIntact(t *testing.T) {
+	ctx := context.Background()
+	hm := newTestMemory(t, func(c *HypergraphConfig) {
+		c.EmbeddingFunc = wordEmbedding("sea", "salt", "wind")
+	})
+	sea := mustAdd(t, hm, EpisodicMemory, "sea salt", map[string]interface{}{"mood": "calm"})
+	salt, err := hm.AddWithTTL(ctx, EpisodicMemory, "salt wind", map[string]interface{}{"mood": "wild"}, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := hm.Connect(sea.ID, salt.ID); err != nil {
+		t.Fatal(err)
+	}
+	want := snapshotViews(t, hm)
+
+	// Readers keep querying, and so updating access statistics, while the
+	// returned views are scribbled on
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			if _, err := hm.Query(ctx, "salt", "", 2); err != nil {
+				t.Error(err)
+				return
+			}
+		}
+	}()
+
+	for i := 0; i < 50; i++ {
+		results, err := hm.Query(ctx, "sea salt wind", "", 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		connected, err := hm.GetConnected(sea.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		results = append(results, connected...)
+		results = append(results, hm.Recent(EpisodicMemory, 2)...)
+		results = append(results, hm.TopHubs(2)...)
+		for _, v := range results {
+			scribble(v)
+		}
+		hm.Walk(func(mem MemoryView, neighbors []MemoryView) bool {
+			scribble(mem)
+			for _, n := range neighbors {
+				scribble(n)
+			}
+			return true
+		})
+	}
+	close(stop)
+	wg.Wait()
+
+	if got := snapshotViews(t, hm); !reflect.DeepEqual(got, want) {
+		t.Errorf("memories changed through their views:\n got %v\nwant %v", got, want)
+	}
+}
+
+func TestWriteAPIsReturnViews(t *testing.T) {
+	ctx := context.Background()
+	hm := newTestMemory(t, func(c *HypergraphConfig) {
+		c.EmbeddingFunc = wordEmbedding("sea", "salt", "wind")
+		c.DedupThreshold = 0.99
+	})
+
+	var added []MemoryView
+	record := func(v MemoryView, err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatal(err)
+		}
+		added = append(added, v)
+	}
+	record(hm.Add(ctx, EpisodicMemory, "sea salt", map[string]interface{}{"mood": "calm"}))
+	record(hm.AddWithTTL(ctx, EpisodicMemory, "salt wind", map[string]interface{}{"mood": "wild"}, time.Hour))
+	record(hm.AddFromSource(ctx, EpisodicMemory, "wind", map[string]interface{}{"mood": "cold"}, "chat", "msg-1"))
+	activated, _, err := hm.AddAndActivate(ctx, EpisodicMemory, "sea", map[string]interface{}{"mood": "deep"}, 1)
+	record(activated, err)
+
+	// A near-duplicate merges into the first memory and returns a view of it
+	record(hm.Add(ctx, EpisodicMemory, "sea salt", map[string]interface{}{"mood": "calm"}))
+	if added[4].ID != added[0].ID {
+		t.Fatalf("duplicate got ID %s, want the existing %s", added[4].ID, added[0].ID)
+	}
+
+	want := snapshotViews(t, hm)
+	for _, v := range added {
+		scribble(v)
+	}
+	if got := snapshotViews(t, hm); !reflect.DeepEqual(got, want) {
+		t.Errorf("memories changed through views returned by writes:\n got %v\nwant %v", got, want)
+	}
+}
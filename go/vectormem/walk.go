@@ -0,0 +1,31 @@
+package vectormem
+
+import "sort"
+
+// Walk calls visitor with a view of every memory in ID order, along with views of
+// its connected memories, stopping early if visitor returns false. It holds the
+// read lock for the duration of the walk, so the visitor must not call back into
+// the HypergraphMemory.
+func (hm *HypergraphMemory) Walk(visitor func(mem MemoryView, neighbors []MemoryView) bool) {
+	hm.mu.RLock()
+	defer hm.mu.RUnlock()
+
+	ids := make([]string, 0, len(hm.memories))
+	for id := range hm.memories {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		mem := hm.memories[id]
+		neighbors := make([]MemoryView, 0, len(mem.Connections))
+		for _, connID := range mem.Connections {
+			if conn, ok := hm.memories[connID]; ok {
+				neighbors = append(neighbors, conn.view())
+			}
+		}
+		if !visitor(mem.view(), neighbors) {
+			return
+		}
+	}
+}
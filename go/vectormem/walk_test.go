@@ -0,0 +1,93 @@
+package vectormem
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// newStarGraph builds a hub connected to three spokes, one of which also
+// connects to a leaf, plus an isolated memory
+func newStarGraph(t *testing.T) *HypergraphMemory {
+	t.Helper()
+	hm := newTestMemory(t, func(c *HypergraphConfig) { c.Clock = newFakeClock() })
+	hub := mustAdd(t, hm, DeclarativeMemory, "hub", nil)
+	var spokes []MemoryView
+	for _, content := range []string{"spoke one", "spoke two", "spoke three"} {
+		spoke := mustAdd(t, hm, DeclarativeMemory, content, nil)
+		if err := hm.Connect(hub.ID, spoke.ID); err != nil {
+			t.Fatal(err)
+		}
+		spokes = append(spokes, spoke)
+	}
+	leaf := mustAdd(t, hm, EpisodicMemory, "leaf", nil)
+	if err := hm.Connect(spokes[0].ID, leaf.ID); err != nil {
+		t.Fatal(err)
+	}
+	mustAdd(t, hm, EpisodicMemory, "isolated", nil)
+	return hm
+}
+
+func TestWalkDegreeDistribution(t *testing.T) {
+	hm := newStarGraph(t)
+
+	degrees := make(map[int]int)
+	endpoints := 0
+	var visited []string
+	hm.Walk(func(mem MemoryView, neighbors []MemoryView) bool {
+		visited = append(visited, mem.ID)
+		degrees[len(neighbors)]++
+		endpoints += len(neighbors)
+		return true
+	})
+
+	if want := map[int]int{3: 1, 2: 1, 1: 3, 0: 1}; !reflect.DeepEqual(degrees, want) {
+		t.Errorf("degree distribution = %v, want %v", degrees, want)
+	}
+	if want := hm.DegreeDistribution(); !reflect.DeepEqual(degrees, want) {
+		t.Errorf("walk distribution %v differs from DegreeDistribution %v", degrees, want)
+	}
+	if got := hm.GetStats()["total_connections"]; got != endpoints/2 {
+		t.Errorf("GetStats total_connections = %v, walk counted %d", got, endpoints/2)
+	}
+	if !sort.StringsAreSorted(visited) || len(visited) != 6 {
+		t.Errorf("visited %v, want all six memories in ID order", visited)
+	}
+}
+
+func TestWalkStopsEarly(t *testing.T) {
+	hm := newStarGraph(t)
+
+	calls := 0
+	hm.Walk(func(mem MemoryView, neighbors []MemoryView) bool {
+		calls++
+		return calls < 2
+	})
+	if calls != 2 {
+		t.Errorf("visitor called %d times, want 2", calls)
+	}
+}
+
+func TestWalkViewsCopyNestedMetadata(t *testing.T) {
+	hm := newTestMemory(t, nil)
+	mustAdd(t, hm, DeclarativeMemory, "nested", map[string]interface{}{
+		"tags":  []interface{}{"a", "b"},
+		"attrs": map[string]interface{}{"color": "blue"},
+	})
+
+	hm.Walk(func(mem MemoryView, neighbors []MemoryView) bool {
+		mem.Metadata["tags"].([]interface{})[0] = "scribbled"
+		mem.Metadata["attrs"].(map[string]interface{})["color"] = "scribbled"
+		return true
+	})
+
+	hm.Walk(func(mem MemoryView, neighbors []MemoryView) bool {
+		if got := mem.Metadata["tags"].([]interface{})[0]; got != "a" {
+			t.Errorf("nested slice changed through a view: %v", got)
+		}
+		if got := mem.Metadata["attrs"].(map[string]interface{})["color"]; got != "blue" {
+			t.Errorf("nested map changed through a view: %v", got)
+		}
+		return true
+	})
+}